@@ -0,0 +1,184 @@
+#ifdef SWIGGO
+
+//
+// read a C++ DocumentVector vector from go. the vector and the document
+// vectors it points to are allocated for the caller, so both are freed
+// once copied.
+//
+%{
+extern "C" {
+
+typedef struct indri_go_document_field {
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_document_field;
+
+intgo indri_go_document_vectors_size( std::vector<indri::api::DocumentVector*>* vectors ) {
+  return (intgo) vectors->size();
+}
+
+indri::api::DocumentVector* indri_go_document_vectors_get( std::vector<indri::api::DocumentVector*>* vectors, intgo i ) {
+  return (*vectors)[i];
+}
+
+void indri_go_document_vectors_delete( std::vector<indri::api::DocumentVector*>* vectors ) {
+  for( size_t i=0; i<vectors->size(); i++ )
+    delete (*vectors)[i];
+  delete vectors;
+}
+
+intgo indri_go_document_vector_stems_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->stems().size();
+}
+
+const char* indri_go_document_vector_stem( indri::api::DocumentVector* vector, intgo i, intgo* length ) {
+  const std::string& stem = vector->stems()[i];
+  *length = (intgo) stem.length();
+  return stem.data();
+}
+
+intgo indri_go_document_vector_positions_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->positions().size();
+}
+
+void indri_go_document_vector_positions( indri::api::DocumentVector* vector, intgo* out ) {
+  for( size_t i=0; i<vector->positions().size(); i++ )
+    out[i] = vector->positions()[i];
+}
+
+intgo indri_go_document_vector_fields_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->fields().size();
+}
+
+const char* indri_go_document_vector_field( indri::api::DocumentVector* vector, intgo i, indri_go_document_field* out ) {
+  const indri::api::DocumentVector::Field& field = vector->fields()[i];
+  out->begin = field.begin;
+  out->end = field.end;
+  out->number = field.number;
+  out->ordinal = field.ordinal;
+  out->parentOrdinal = field.parentOrdinal;
+  return field.name.c_str();
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+typedef struct indri_go_document_field {
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_document_field;
+extern swig_intgo indri_go_document_vectors_size(uintptr_t arg1);
+extern uintptr_t indri_go_document_vectors_get(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_document_vectors_delete(uintptr_t arg1);
+extern swig_intgo indri_go_document_vector_stems_size(uintptr_t arg1);
+extern const char *indri_go_document_vector_stem(uintptr_t arg1, swig_intgo arg2, swig_intgo *arg3);
+extern swig_intgo indri_go_document_vector_positions_size(uintptr_t arg1);
+extern void indri_go_document_vector_positions(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_document_vector_fields_size(uintptr_t arg1);
+extern const char *indri_go_document_vector_field(uintptr_t arg1, swig_intgo arg2, indri_go_document_field *arg3);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend DocumentVector.i
+//
+
+// DocumentField is a go copy of an indri::api::DocumentVector::Field
+type DocumentField struct {
+    Name string
+    Begin int
+    End int
+    Number int64
+    Ordinal int
+    ParentOrdinal int
+}
+
+//
+// DocumentVector is a go copy of an indri::api::DocumentVector. Each entry
+// of Positions is an index into Stems, index 0 is the out of vocabulary stem
+// used for stopwords. Field Begin and End are indexes into Positions.
+//
+type DocumentVector struct {
+    Stems []string
+    Positions []int
+    Fields []DocumentField
+}
+
+//
+// TermFrequencies counts how often each stem occurs in the document,
+// stopwords are not counted.
+//
+func (v DocumentVector) TermFrequencies() map[string]int {
+    tf := make(map[string]int)
+    for _, p := range v.Positions {
+        if p > 0 {
+            tf[v.Stems[p]]++
+        }
+    }
+    return tf
+}
+
+func copyDocumentVector(arg1 uintptr) (_swig_ret DocumentVector) {
+    _swig_i_0 := C.uintptr_t(arg1)
+
+    n := int(C.indri_go_document_vector_stems_size(_swig_i_0))
+    _swig_ret.Stems = make([]string, n)
+    for i := range _swig_ret.Stems {
+        var length C.swig_intgo
+        p := C.indri_go_document_vector_stem(_swig_i_0, C.swig_intgo(i), &length)
+        _swig_ret.Stems[i] = C.GoStringN(p, C.int(length))
+    }
+
+    n = int(C.indri_go_document_vector_positions_size(_swig_i_0))
+    _swig_ret.Positions = make([]int, n)
+    if n > 0 {
+        positions := make([]C.swig_intgo, n)
+        C.indri_go_document_vector_positions(_swig_i_0, &positions[0])
+        for i, p := range positions {
+            _swig_ret.Positions[i] = int(p)
+        }
+    }
+
+    n = int(C.indri_go_document_vector_fields_size(_swig_i_0))
+    _swig_ret.Fields = make([]DocumentField, n)
+    for i := range _swig_ret.Fields {
+        var f C.indri_go_document_field
+        name := C.indri_go_document_vector_field(_swig_i_0, C.swig_intgo(i), &f)
+        _swig_ret.Fields[i] = DocumentField{
+            Name: C.GoString(name),
+            Begin: int(f.begin),
+            End: int(f.end),
+            Number: int64(f.number),
+            Ordinal: int(f.ordinal),
+            ParentOrdinal: int(f.parentOrdinal),
+        }
+    }
+    return
+}
+
+//
+// takeDocumentVectors copies and then frees a C++ DocumentVector vector,
+// including the document vectors, returned by value.
+//
+func takeDocumentVectors(arg1 Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_) (_swig_ret []DocumentVector) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    defer C.indri_go_document_vectors_delete(_swig_i_0)
+    n := int(C.indri_go_document_vectors_size(_swig_i_0))
+    _swig_ret = make([]DocumentVector, n)
+    for i := range _swig_ret {
+        _swig_ret[i] = copyDocumentVector(uintptr(C.indri_go_document_vectors_get(_swig_i_0, C.swig_intgo(i))))
+    }
+    return
+}
+
+%}
+
+#endif
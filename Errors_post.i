@@ -0,0 +1,163 @@
+#ifdef SWIGGO
+
+//
+// lemur exceptions panic with their code in front of what(), so that catch
+// can tell their kinds apart. see LemurException.i setEx.
+//
+%{
+
+static void indri_go_lemur_exception( lemur::api::Exception& e ) {
+  const char* code = "LEMUR_GENERIC_ERROR";
+  switch( e.code() ) {
+  case LEMUR_MISSING_PARAMETER_ERROR: code = "LEMUR_MISSING_PARAMETER_ERROR"; break;
+  case LEMUR_BAD_PARAMETER_ERROR: code = "LEMUR_BAD_PARAMETER_ERROR"; break;
+  case LEMUR_PARSE_ERROR: code = "LEMUR_PARSE_ERROR"; break;
+  case LEMUR_KEYFILE_IO_ERROR: code = "LEMUR_KEYFILE_IO_ERROR"; break;
+  case LEMUR_IO_ERROR: code = "LEMUR_IO_ERROR"; break;
+  case LEMUR_RUNTIME_ERROR: code = "LEMUR_RUNTIME_ERROR"; break;
+  case LEMUR_NETWORK_ERROR: code = "LEMUR_NETWORK_ERROR"; break;
+  case LEMUR_INTERNAL_ERROR: code = "LEMUR_INTERNAL_ERROR"; break;
+  }
+  std::string message = std::string( "lemur exception " ) + code + ": " + e.what();
+  SWIG_exception( SWIG_RuntimeError, message.c_str() );
+}
+
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  structured errors
+//
+// catch returns a *LemurError for a lemur::api::Exception and a *PanicError
+// for any other panic. The kinds of lemur error below are told apart by the
+// exception code and message, test them with errors.Is.
+//
+
+var (
+    ErrRepositoryLocked = errors.New("indri_go: repository locked")
+    ErrRepositoryNotFound = errors.New("indri_go: repository not found")
+    ErrParse = errors.New("indri_go: parse error")
+    ErrIO = errors.New("indri_go: i/o error")
+    ErrBadParameter = errors.New("indri_go: bad parameter")
+    ErrUnknownFileClass = errors.New("indri_go: unknown file class")
+)
+
+//
+// LemurError is a lemur::api::Exception. Code is the name of its
+// LemurErrorType, such as LEMUR_IO_ERROR, and Location the C++ file and
+// line it was thrown from. Kind is one of the Err values above, or nil.
+// Stack is the go stack where it was recovered.
+//
+type LemurError struct {
+    Code string
+    Location string
+    Message string
+    Kind error
+    Stack []string
+}
+
+func (e *LemurError) Error() string {
+    if e.Location == "" {
+        return e.Message
+    }
+    return e.Location + ": " + e.Message
+}
+
+func (e *LemurError) Unwrap() error {
+    return e.Kind
+}
+
+//
+// PanicError is any other panic recovered by catch, a C++ exception that
+// is not a lemur::api::Exception or a go panic.
+//
+type PanicError struct {
+    Value interface{}
+    Stack []string
+}
+
+func (e *PanicError) Error() string {
+    return fmt.Sprint(e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+    err, _ := e.Value.(error)
+    return err
+}
+
+const lemurExceptionPrefix = "lemur exception "
+
+// newPanicError makes the error catch returns for the recovered value r
+func newPanicError(r interface{}, stack []string) error {
+    s, ok := r.(string)
+    if !ok {
+        if e, isError := r.(error); isError {
+            s, ok = e.Error(), true
+        }
+    }
+    if !ok || !strings.HasPrefix(s, lemurExceptionPrefix) {
+        return &PanicError{Value: r, Stack: stack}
+    }
+
+    // lemur exception CODE: location: message
+    s = strings.TrimPrefix(s, lemurExceptionPrefix)
+    e := &LemurError{Stack: stack}
+    if i := strings.Index(s, ": "); i >= 0 {
+        e.Code, s = s[:i], s[i+2:]
+    }
+    if i := strings.Index(s, ": "); i >= 0 && !strings.ContainsAny(s[:i], " \n") {
+        e.Location, s = s[:i], s[i+2:]
+    }
+    e.Message = s
+    e.Kind = lemurErrorKind(e.Code, e.Message)
+    return e
+}
+
+// the exact messages, by prefix, indri throws for the kinds below
+const (
+    // Parameters::load of the manifest of a repository that does not exist
+    lemurMissingManifestPrefix = "Couldn't open parameter file '"
+    lemurMissingManifestSuffix = "manifest' for reading."
+    // FileClassEnvironmentFactory of an unknown file class
+    lemurUnknownFileClassPrefix = "File class '"
+)
+
+// lemurLockedPrefixes start the messages of a repository held by another writer
+var lemurLockedPrefixes = []string{
+    "Unable to lock ",
+    "repository is locked",
+}
+
+//
+// lemurErrorKind maps an exception code and message to one of the Err
+// values. The code decides, the message only picks out the errors indri
+// throws with a known message under that code.
+//
+func lemurErrorKind(code, message string) error {
+    switch code {
+    case "LEMUR_IO_ERROR", "LEMUR_KEYFILE_IO_ERROR":
+        if strings.HasPrefix(message, lemurMissingManifestPrefix) && strings.HasSuffix(message, lemurMissingManifestSuffix) {
+            return ErrRepositoryNotFound
+        }
+        for _, prefix := range lemurLockedPrefixes {
+            if strings.HasPrefix(message, prefix) {
+                return ErrRepositoryLocked
+            }
+        }
+        return ErrIO
+    case "LEMUR_RUNTIME_ERROR":
+        if strings.HasPrefix(message, lemurUnknownFileClassPrefix) {
+            return ErrUnknownFileClass
+        }
+    case "LEMUR_PARSE_ERROR":
+        return ErrParse
+    case "LEMUR_BAD_PARAMETER_ERROR", "LEMUR_MISSING_PARAMETER_ERROR":
+        return ErrBadParameter
+    }
+    return nil
+}
+
+%}
+
+#endif
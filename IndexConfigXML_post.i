@@ -0,0 +1,335 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  read and write IndexConfig as IndriBuildIndex parameters xml
+//
+
+// xmlName reads both <field>name</field> and <field><name>name</name></field>
+type xmlName struct {
+    Text string `xml:",chardata"`
+    Name string `xml:"name,omitempty"`
+}
+
+func (n xmlName) value() string {
+    if n.Name != "" {
+        return strings.TrimSpace(n.Name)
+    }
+    return strings.TrimSpace(n.Text)
+}
+
+type xmlIndexField struct {
+    Name string `xml:"name"`
+    Numeric string `xml:"numeric,omitempty"`
+    ParserName string `xml:"parserName,omitempty"`
+    Ordinal string `xml:"ordinal,omitempty"`
+    Parental string `xml:"parental,omitempty"`
+}
+
+type xmlIndexMetadata struct {
+    Forward []string `xml:"forward"`
+    Backward []string `xml:"backward"`
+    Fields []xmlName `xml:"field"`
+}
+
+type xmlIndexCorpus struct {
+    Path string `xml:"path"`
+    Class string `xml:"class,omitempty"`
+    Annotations string `xml:"annotations,omitempty"`
+    Metadata string `xml:"metadata,omitempty"`
+    Inlink string `xml:"inlink,omitempty"`
+}
+
+type xmlIndexConfig struct {
+    XMLName xml.Name `xml:"parameters"`
+    Index string `xml:"index,omitempty"`
+    Corpora []xmlIndexCorpus `xml:"corpus"`
+    Metadata *xmlIndexMetadata `xml:"metadata"`
+    Fields []xmlIndexField `xml:"field"`
+    Memory string `xml:"memory,omitempty"`
+    Stemmer *xmlName `xml:"stemmer"`
+    Normalize string `xml:"normalize,omitempty"`
+    StoreDocs string `xml:"storeDocs,omitempty"`
+    Stopwords []string `xml:"stopper>word"`
+}
+
+//
+// ParseMemorySize parses a memory size the way indri::api::Parameters does,
+// a number of bytes with an optional K, M or G suffix.
+//
+func ParseMemorySize(input string) (int64, error) {
+    s := strings.TrimSpace(input)
+    var scale int64 = 1
+    if n := len(s); n > 0 {
+        switch s[n-1] {
+        case 'k', 'K':
+            scale = 1024
+        case 'm', 'M':
+            scale = 1024*1024
+        case 'g', 'G':
+            scale = 1024*1024*1024
+        }
+        if scale > 1 {
+            s = s[:n-1]
+        }
+    }
+    v, err := strconv.ParseInt(s, 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid memory size %q", input)
+    }
+    if v > math.MaxInt64 / scale || v < math.MinInt64 / scale {
+        return 0, fmt.Errorf("memory size %q out of range", input)
+    }
+    return v * scale, nil
+}
+
+//
+// FormatMemorySize formats a memory size with the largest K, M or G suffix
+// that divides it.
+//
+func FormatMemorySize(v int64) string {
+    switch {
+    case v != 0 && v % (1024*1024*1024) == 0:
+        return fmt.Sprintf("%vg", v / (1024*1024*1024))
+    case v != 0 && v % (1024*1024) == 0:
+        return fmt.Sprintf("%vm", v / (1024*1024))
+    case v != 0 && v % 1024 == 0:
+        return fmt.Sprintf("%vk", v / 1024)
+    }
+    return strconv.FormatInt(v, 10)
+}
+
+// parseParameterBool accepts the boolean spellings indri::api::Parameters does
+func parseParameterBool(s string, def bool) (bool, error) {
+    switch strings.ToLower(strings.TrimSpace(s)) {
+    case "":
+        return def, nil
+    case "1", "true", "yes", "y", "on":
+        return true, nil
+    case "0", "false", "no", "n", "off":
+        return false, nil
+    }
+    return def, fmt.Errorf("invalid boolean %q", s)
+}
+
+//
+// ParseIndexConfig reads IndriBuildIndex parameters xml into an IndexConfig.
+// Parameters missing from the xml keep their NewIndexConfig defaults.
+//
+func ParseIndexConfig(s string) (cfg IndexConfig, err error) {
+    var x xmlIndexConfig
+    if err = xml.Unmarshal([]byte(s), &x); err != nil {
+        err = fmt.Errorf("failed to parse index config: %v", err)
+        return
+    }
+
+    cfg = NewIndexConfig()
+    cfg.Index = strings.TrimSpace(x.Index)
+    if x.Memory != "" {
+        if cfg.Memory, err = ParseMemorySize(x.Memory); err != nil {
+            return
+        }
+    }
+    if x.Stemmer != nil {
+        cfg.Stemmer = x.Stemmer.value()
+    }
+    if cfg.Normalize, err = parseParameterBool(x.Normalize, cfg.Normalize); err != nil {
+        err = fmt.Errorf("normalize: %v", err)
+        return
+    }
+    if cfg.StoreDocs, err = parseParameterBool(x.StoreDocs, cfg.StoreDocs); err != nil {
+        err = fmt.Errorf("storeDocs: %v", err)
+        return
+    }
+    for _, w := range x.Stopwords {
+        cfg.Stopwords = append(cfg.Stopwords, strings.TrimSpace(w))
+    }
+
+    for _, f := range x.Fields {
+        field := IndexField{Name: strings.TrimSpace(f.Name), Parser: strings.TrimSpace(f.ParserName)}
+        if field.Numeric, err = parseParameterBool(f.Numeric, false); err == nil {
+            if field.Ordinal, err = parseParameterBool(f.Ordinal, false); err == nil {
+                field.Parental, err = parseParameterBool(f.Parental, false)
+            }
+        }
+        if err != nil {
+            err = fmt.Errorf("field %q: %v", field.Name, err)
+            return
+        }
+        cfg.Fields = append(cfg.Fields, field)
+    }
+
+    if x.Metadata != nil {
+        for _, f := range x.Metadata.Fields {
+            cfg.Metadata.Fields = append(cfg.Metadata.Fields, f.value())
+        }
+        for _, f := range x.Metadata.Forward {
+            cfg.Metadata.Forward = append(cfg.Metadata.Forward, strings.TrimSpace(f))
+        }
+        for _, f := range x.Metadata.Backward {
+            cfg.Metadata.Backward = append(cfg.Metadata.Backward, strings.TrimSpace(f))
+        }
+    }
+
+    for _, c := range x.Corpora {
+        cfg.Corpora = append(cfg.Corpora, IndexCorpus{
+            Path: strings.TrimSpace(c.Path),
+            Class: strings.TrimSpace(c.Class),
+            Annotations: strings.TrimSpace(c.Annotations),
+            Metadata: strings.TrimSpace(c.Metadata),
+            Inlink: strings.TrimSpace(c.Inlink),
+        })
+    }
+    return
+}
+
+//
+// LoadIndexConfig reads an IndriBuildIndex parameters file, such as
+// data/params.xml, into an IndexConfig.
+//
+func LoadIndexConfig(path string) (cfg IndexConfig, err error) {
+    b, err := ioutil.ReadFile(path)
+    if err != nil {
+        return
+    }
+    return ParseIndexConfig(string(b))
+}
+
+//
+// Set applies an IndriBuildIndex command line parameter, -memory=100M is
+// Set("memory", "100M"). corpus.path, field.name, stopper.word and the
+// metadata lists append an entry, the other corpus and field keys set the
+// entry appended last.
+//
+func (cfg *IndexConfig) Set(key string, value string) (err error) {
+    value = strings.TrimSpace(value)
+    // the entry appended last, or nil with err set when there is none yet
+    lastCorpus := func() *IndexCorpus {
+        if len(cfg.Corpora) == 0 {
+            err = fmt.Errorf("given before corpus.path")
+            return nil
+        }
+        return &cfg.Corpora[len(cfg.Corpora)-1]
+    }
+    lastField := func() *IndexField {
+        if len(cfg.Fields) == 0 {
+            err = fmt.Errorf("given before field.name")
+            return nil
+        }
+        return &cfg.Fields[len(cfg.Fields)-1]
+    }
+    switch key {
+    case "index":
+        cfg.Index = value
+    case "memory":
+        cfg.Memory, err = ParseMemorySize(value)
+    case "stemmer", "stemmer.name":
+        cfg.Stemmer = value
+    case "normalize":
+        cfg.Normalize, err = parseParameterBool(value, cfg.Normalize)
+    case "storeDocs":
+        cfg.StoreDocs, err = parseParameterBool(value, cfg.StoreDocs)
+    case "stopper.word":
+        cfg.Stopwords = append(cfg.Stopwords, value)
+    case "metadata.field":
+        cfg.Metadata.Fields = append(cfg.Metadata.Fields, value)
+    case "metadata.forward":
+        cfg.Metadata.Forward = append(cfg.Metadata.Forward, value)
+    case "metadata.backward":
+        cfg.Metadata.Backward = append(cfg.Metadata.Backward, value)
+    case "corpus", "corpus.path":
+        cfg.Corpora = append(cfg.Corpora, IndexCorpus{Path: value})
+    case "corpus.class":
+        if c := lastCorpus(); c != nil {
+            c.Class = value
+        }
+    case "corpus.annotations":
+        if c := lastCorpus(); c != nil {
+            c.Annotations = value
+        }
+    case "corpus.metadata":
+        if c := lastCorpus(); c != nil {
+            c.Metadata = value
+        }
+    case "corpus.inlink":
+        if c := lastCorpus(); c != nil {
+            c.Inlink = value
+        }
+    case "field", "field.name":
+        cfg.Fields = append(cfg.Fields, IndexField{Name: value})
+    case "field.numeric":
+        if f := lastField(); f != nil {
+            f.Numeric, err = parseParameterBool(value, false)
+        }
+    case "field.parserName":
+        if f := lastField(); f != nil {
+            f.Parser = value
+        }
+    case "field.ordinal":
+        if f := lastField(); f != nil {
+            f.Ordinal, err = parseParameterBool(value, false)
+        }
+    case "field.parental":
+        if f := lastField(); f != nil {
+            f.Parental, err = parseParameterBool(value, false)
+        }
+    default:
+        return fmt.Errorf("unknown parameter %q", key)
+    }
+    if err != nil {
+        err = fmt.Errorf("%v: %v", key, err)
+    }
+    return
+}
+
+//
+// Marshal writes cfg as IndriBuildIndex parameters xml, which both
+// ParseIndexConfig and Parameters.MyLoad read back. memory is always
+// written, a zero Memory would otherwise read back as the default.
+//
+func (cfg IndexConfig) Marshal() (string, error) {
+    x := xmlIndexConfig{
+        Index: cfg.Index,
+        Normalize: strconv.FormatBool(cfg.Normalize),
+        StoreDocs: strconv.FormatBool(cfg.StoreDocs),
+        Memory: FormatMemorySize(cfg.Memory),
+        Stopwords: cfg.Stopwords,
+    }
+    if cfg.Stemmer != "" {
+        x.Stemmer = &xmlName{Name: cfg.Stemmer}
+    }
+    for _, c := range cfg.Corpora {
+        x.Corpora = append(x.Corpora, xmlIndexCorpus(c))
+    }
+    m := cfg.Metadata
+    if len(m.Fields) > 0 || len(m.Forward) > 0 || len(m.Backward) > 0 {
+        x.Metadata = &xmlIndexMetadata{Forward: m.Forward, Backward: m.Backward}
+        for _, f := range m.Fields {
+            x.Metadata.Fields = append(x.Metadata.Fields, xmlName{Name: f})
+        }
+    }
+    for _, f := range cfg.Fields {
+        field := xmlIndexField{Name: f.Name, ParserName: f.Parser}
+        if f.Numeric {
+            field.Numeric = "true"
+        }
+        if f.Ordinal {
+            field.Ordinal = "true"
+        }
+        if f.Parental {
+            field.Parental = "true"
+        }
+        x.Fields = append(x.Fields, field)
+    }
+
+    b, err := xml.MarshalIndent(x, "", "    ")
+    if err != nil {
+        return "", err
+    }
+    return string(b) + "\n", nil
+}
+
+%}
+
+#endif
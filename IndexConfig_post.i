@@ -0,0 +1,277 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  typed IndriBuildIndex configuration
+//
+
+//
+// IndexField is an indexed field, see the <field> parameters of
+// IndriBuildIndex. Parser names the annotator used for a numeric field,
+// it defaults to NumericFieldAnnotator.
+//
+type IndexField struct {
+    Name string
+    Numeric bool
+    Parser string
+    Ordinal bool
+    Parental bool
+}
+
+//
+// IndexMetadata lists the metadata fields of a repository. Forward fields
+// can be looked up by document id, Backward fields can be used to find
+// documents by value. docno is always added to both.
+//
+type IndexMetadata struct {
+    Fields []string
+    Forward []string
+    Backward []string
+}
+
+// IndexCorpus is a file or directory of documents to index
+type IndexCorpus struct {
+    Path string
+    Class string
+    Annotations string
+    Metadata string
+    Inlink string
+}
+
+//
+// IndexConfig is a go copy of the IndriBuildIndex parameters. Memory is in
+// bytes, an empty Stemmer means no stemming.
+//
+type IndexConfig struct {
+    Index string
+    Memory int64
+    Stemmer string
+    Normalize bool
+    StoreDocs bool
+    Stopwords []string
+    Fields []IndexField
+    Metadata IndexMetadata
+    Corpora []IndexCorpus
+}
+
+//
+// IndexConfigError holds every problem found validating an IndexConfig.
+//
+type IndexConfigError struct {
+    Problems []string
+}
+
+func (e *IndexConfigError) Error() string {
+    return fmt.Sprintf("invalid index config: %v", strings.Join(e.Problems, "; "))
+}
+
+// DefaultIndexMemory is the IndriBuildIndex default memory, 1G
+const DefaultIndexMemory int64 = 1024*1024*1024
+
+//
+// NewIndexConfig returns a config with the IndriBuildIndex defaults.
+//
+func NewIndexConfig() IndexConfig {
+    return IndexConfig{
+        Memory: DefaultIndexMemory,
+        Normalize: true,
+        StoreDocs: true,
+    }
+}
+
+// stemmers known to indri::parse::StemmerFactory
+var indexStemmers = map[string]bool{
+    "krovetz": true,
+    "kstem": true,
+    "porter": true,
+    "arabic_stop": true,
+    "arabic_norm2": true,
+    "arabic_norm2_stop": true,
+    "arabic_light10": true,
+    "arabic_light10_stop": true,
+}
+
+//
+// Validate checks cfg, a nil env skips the corpus file class checks.
+// All problems are returned together in an *IndexConfigError.
+//
+func (cfg IndexConfig) Validate(env IndexEnvironment) error {
+    var problems []string
+
+    if cfg.Memory < 0 {
+        problems = append(problems, fmt.Sprintf("memory %v is negative", cfg.Memory))
+    }
+    if cfg.Stemmer != "" && !indexStemmers[strings.ToLower(cfg.Stemmer)] {
+        problems = append(problems, fmt.Sprintf("unknown stemmer %q", cfg.Stemmer))
+    }
+    for i, w := range cfg.Stopwords {
+        if strings.TrimSpace(w) == "" {
+            problems = append(problems, fmt.Sprintf("stopword %v is empty", i))
+        }
+    }
+
+    fields := make(map[string]bool)
+    for i, f := range cfg.Fields {
+        name := strings.ToLower(f.Name)
+        if name == "" {
+            problems = append(problems, fmt.Sprintf("field %v has no name", i))
+            continue
+        }
+        if fields[name] {
+            problems = append(problems, fmt.Sprintf("field %q is listed more than once", f.Name))
+        }
+        fields[name] = true
+        if f.Parser != "" && !f.Numeric {
+            problems = append(problems, fmt.Sprintf("field %q has a parser but is not numeric", f.Name))
+        }
+    }
+
+    for _, m := range []struct {
+        kind string
+        names []string
+    }{
+        {"metadata field", cfg.Metadata.Fields},
+        {"forward metadata field", cfg.Metadata.Forward},
+        {"backward metadata field", cfg.Metadata.Backward},
+    } {
+        for i, name := range m.names {
+            if name == "" {
+                problems = append(problems, fmt.Sprintf("%v %v has no name", m.kind, i))
+            }
+        }
+    }
+
+    for i, c := range cfg.Corpora {
+        if c.Path == "" {
+            problems = append(problems, fmt.Sprintf("corpus %v has no path", i))
+        }
+        if c.Class != "" && env != nil && !hasFileClass(env, c.Class) {
+            problems = append(problems, fmt.Sprintf("corpus %v has unknown file class %q", i, c.Class))
+        }
+    }
+
+    if len(problems) > 0 {
+        return &IndexConfigError{Problems: problems}
+    }
+    return nil
+}
+
+func hasFileClass(env IndexEnvironment, class string) bool {
+    spec, err := env.GetFileClassSpec(class)
+    if err != nil || spec == nil || spec.Swigcptr() == 0 {
+        return false
+    }
+    Wrapped_deleteFileClassSpec(spec)
+    return true
+}
+
+//
+// metadataIndexedFields returns the forward and backward metadata lists as
+// IndriBuildIndex sets them, lower case and always including docno.
+//
+func (cfg IndexConfig) metadataIndexedFields() (forward []string, backward []string) {
+    withDocno := func(names []string) []string {
+        lower := make([]string, 0, len(names) + 1)
+        found := false
+        for _, n := range names {
+            n = strings.ToLower(n)
+            found = found || n == "docno"
+            lower = append(lower, n)
+        }
+        if !found {
+            lower = append(lower, "docno")
+        }
+        return lower
+    }
+    return withDocno(cfg.Metadata.Forward), withDocno(cfg.Metadata.Backward)
+}
+
+//
+// Configure validates cfg and applies it to env in the order IndriBuildIndex
+// does: memory, normalization, stored documents, stemmer, stopwords,
+// metadata and then fields. env must not have been created or opened yet.
+// Corpora are only validated, they are added when the index is built.
+//
+func Configure(env IndexEnvironment, cfg IndexConfig) (err error) {
+    defer catch(&err)
+
+    if err = cfg.Validate(env); err != nil {
+        return
+    }
+
+    if cfg.Memory > 0 {
+        if err = env.SetMemory(cfg.Memory); err != nil {
+            return
+        }
+    }
+    if err = env.SetNormalization(cfg.Normalize); err != nil {
+        return
+    }
+    if err = env.SetStoreDocs(cfg.StoreDocs); err != nil {
+        return
+    }
+    if cfg.Stemmer != "" {
+        if err = env.SetStemmer(strings.ToLower(cfg.Stemmer)); err != nil {
+            return
+        }
+    }
+    if len(cfg.Stopwords) > 0 {
+        stopwords := newStringVector(cfg.Stopwords)
+        defer DeleteStringVector(stopwords)
+        if err = env.SetStopwords(stopwords); err != nil {
+            return
+        }
+    }
+
+    forward, backward := cfg.metadataIndexedFields()
+    forwardVector := newStringVector(forward)
+    defer DeleteStringVector(forwardVector)
+    backwardVector := newStringVector(backward)
+    defer DeleteStringVector(backwardVector)
+    if err = env.SetMetadataIndexedFields(forwardVector, backwardVector); err != nil {
+        return
+    }
+
+    if len(cfg.Fields) > 0 {
+        names := make([]string, len(cfg.Fields))
+        for i, f := range cfg.Fields {
+            names[i] = strings.ToLower(f.Name)
+        }
+        fields := newStringVector(names)
+        defer DeleteStringVector(fields)
+        if err = env.SetIndexedFields(fields); err != nil {
+            return
+        }
+        for i, f := range cfg.Fields {
+            if f.Numeric {
+                parser := f.Parser
+                if parser == "" {
+                    parser = "NumericFieldAnnotator"
+                }
+                if err = env.SetNumericField(names[i], true, parser); err != nil {
+                    return
+                }
+            }
+        }
+        for i, f := range cfg.Fields {
+            if f.Ordinal {
+                if err = env.SetOrdinalField(names[i], true); err != nil {
+                    return
+                }
+            }
+        }
+        for i, f := range cfg.Fields {
+            if f.Parental {
+                if err = env.SetParentalField(names[i], true); err != nil {
+                    return
+                }
+            }
+        }
+    }
+    return
+}
+
+%}
+
+#endif
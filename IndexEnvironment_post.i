@@ -1,6 +1,183 @@
 
 #ifdef SWIGGO
 
+//
+// add a document with go metadata. keys and values are packed into a single
+// go string with their lengths alongside, so the metadata crosses into C++
+// in the one call without any go pointers stored in C memory.
+//
+%{
+
+//
+// every repository created or opened from go gets an indri_go_cancel_status
+// as its callback. it passes status calls on to the caller's IndexStatus,
+// then to the check set by AddFileContext, and throws once cancelled so
+// that addFile stops after the current document. without a caller
+// IndexStatus it throws file errors, as indri does when there is no
+// callback at all.
+//
+class indri_go_cancel_status : public indri::api::IndexStatus {
+public:
+  indri::api::IndexStatus* next;
+  indri::api::IndexStatus* check;
+  bool cancelled;
+
+  indri_go_cancel_status( indri::api::IndexStatus* n ) : next(n), check(0), cancelled(false) {}
+
+  void status( int code, const std::string& documentPath, const std::string& error, int documentsIndexed, int documentsSeen ) {
+    if( next )
+      (*next)( code, documentPath, error, documentsIndexed, documentsSeen );
+    if( check )
+      (*check)( code, documentPath, error, documentsIndexed, documentsSeen );
+    if( cancelled )
+      LEMUR_THROW( LEMUR_RUNTIME_ERROR, "indexing of " + documentPath + " cancelled" );
+    if( !next && code == indri::api::IndexStatus::FileError )
+      LEMUR_THROW( LEMUR_IO_ERROR, error );
+  }
+};
+
+//
+// IndexEnvironment keeps its Repository private, QueryEnvironment being its
+// only friend. an explicit instantiation may name a private member, so
+// indri_go_repository_of instantiates indri_go_repository_member with the
+// member pointer and the friend function it defines hands it back.
+//
+struct indri_go_repository_tag {
+  typedef indri::collection::Repository indri::api::IndexEnvironment::*type;
+  friend type indri_go_repository_member( indri_go_repository_tag );
+};
+
+template<typename Tag, typename Tag::type member>
+struct indri_go_repository_of {
+  friend typename Tag::type indri_go_repository_member( Tag ) { return member; }
+};
+
+template struct indri_go_repository_of<indri_go_repository_tag, &indri::api::IndexEnvironment::_repository>;
+
+extern "C" {
+
+indri::api::IndexStatus* indri_go_cancel_status_new( indri::api::IndexStatus* next ) {
+  return new indri_go_cancel_status( next );
+}
+
+void indri_go_cancel_status_check( indri::api::IndexStatus* status, indri::api::IndexStatus* check ) {
+  static_cast<indri_go_cancel_status*>(status)->check = check;
+  static_cast<indri_go_cancel_status*>(status)->cancelled = false;
+}
+
+void indri_go_cancel_status_cancel( indri::api::IndexStatus* status ) {
+  static_cast<indri_go_cancel_status*>(status)->cancelled = true;
+}
+
+void indri_go_cancel_status_delete( indri::api::IndexStatus* status ) {
+  delete static_cast<indri_go_cancel_status*>(status);
+}
+
+intgo indri_go_index_environment_add_document( indri::api::IndexEnvironment* env, _gostring_ text, _gostring_ fileClass, _gostring_ metadata, intgo* lengths, intgo count ) {
+  intgo id = 0;
+  lemur::api::Exception* error = 0;
+  {
+    std::vector<std::string> strings;
+    std::vector<indri::parse::MetadataPair> pairs;
+    const char* p = metadata.p;
+
+    strings.reserve( 2*count );
+    for( intgo i=0; i<2*count; i++ ) {
+      strings.push_back( std::string( p, lengths[i] ) );
+      p += lengths[i];
+    }
+
+    // values are stored with their trailing null, as the indri parsers do
+    for( intgo i=0; i<count; i++ ) {
+      indri::parse::MetadataPair pair;
+      pair.key = strings[2*i].c_str();
+      pair.value = strings[2*i+1].c_str();
+      pair.valueLength = strings[2*i+1].length() + 1;
+      pairs.push_back( pair );
+    }
+
+    try {
+      id = env->addString( std::string( text.p, text.n ), std::string( fileClass.p, fileClass.n ), pairs );
+    } catch( lemur::api::Exception& e ) {
+      error = new lemur::api::Exception( e );
+    }
+  }
+  // raised once strings and pairs are destroyed, as the go panic skips
+  // their destructors
+  if( error ) {
+    indri_go_lemur_exception( *error );
+  }
+  return id;
+}
+
+//
+// the live documents of an open repository with the given metadata value,
+// looked up through a QueryEnvironment over the IndexEnvironment itself, as
+// the repository is not shared with any other reader. deleted documents
+// keep their metadata until the repository is compacted, so the ids in the
+// repository's deleted list are dropped. indri_go_lemur_exception unwinds
+// as a go panic, which skips C++ destructors, so it is only called once qe
+// is closed and destroyed.
+//
+std::vector<int>* indri_go_index_environment_document_ids( indri::api::IndexEnvironment* env, _gostring_ field, _gostring_ value ) {
+  std::vector<int>* ids = new std::vector<int>();
+  lemur::api::Exception* error = 0;
+  {
+    indri::api::QueryEnvironment qe;
+    try {
+      std::vector<std::string> values( 1, std::string( value.p, value.n ) );
+      qe.addIndex( *env );
+      std::vector<lemur::api::DOCID_T> found = qe.documentIDsFromMetadata( std::string( field.p, field.n ), values );
+
+      indri::index::DeletedDocumentList& deleted = ( env->*indri_go_repository_member( indri_go_repository_tag() ) ).deletedList();
+      for( size_t i = 0; i < found.size(); i++ ) {
+        if( !deleted.isDeleted( found[i] ) )
+          ids->push_back( found[i] );
+      }
+    } catch( lemur::api::Exception& e ) {
+      error = new lemur::api::Exception( e );
+    }
+    try {
+      qe.close();
+    } catch( lemur::api::Exception& e ) {
+      if( !error )
+        error = new lemur::api::Exception( e );
+    }
+  }
+  if( error ) {
+    delete ids;
+    indri_go_lemur_exception( *error );
+  }
+  return ids;
+}
+
+//
+// 1 when docid is a document of the open repository of env that is not
+// deleted, 0 for a deleted document or an id the repository never had.
+//
+intgo indri_go_index_environment_document_live( indri::api::IndexEnvironment* env, intgo docid ) {
+  try {
+    indri::collection::Repository& repository = env->*indri_go_repository_member( indri_go_repository_tag() );
+    return repository.collection()->exists( (lemur::api::DOCID_T) docid ) && !repository.deletedList().isDeleted( (lemur::api::DOCID_T) docid );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+extern uintptr_t indri_go_cancel_status_new(uintptr_t arg1);
+extern void indri_go_cancel_status_check(uintptr_t arg1, uintptr_t arg2);
+extern void indri_go_cancel_status_cancel(uintptr_t arg1);
+extern void indri_go_cancel_status_delete(uintptr_t arg1);
+extern swig_intgo indri_go_index_environment_add_document(uintptr_t arg1, _gostring_ arg2, _gostring_ arg3, _gostring_ arg4, swig_intgo *arg5, swig_intgo arg6);
+extern uintptr_t indri_go_index_environment_document_ids(uintptr_t arg1, _gostring_ arg2, _gostring_ arg3);
+extern swig_intgo indri_go_index_environment_document_live(uintptr_t arg1, swig_intgo arg2);
+%}
+
 %insert(go_wrapper) %{
 
 //
@@ -30,14 +207,32 @@ type IndexEnvironment interface {
 	Open(a ...interface{}) (err error)
 	Close() (err error)
 	AddFile(a ...interface{}) (err error)
+	AddFileContext(ctx context.Context, a ...interface{}) (err error)
 	AddString(arg2 string, arg3 string, arg4 MetadataPairVector) (_swig_ret int, err error)
 	AddParsedDocument(arg2 ParsedDocument) (_swig_ret int, err error)
+	AddDocument(arg2 string, arg3 string, arg4 map[string]string) (_swig_ret int, err error)
+	DocumentIDsFromDocno(arg2 string) (_swig_ret []int, err error)
+	DocumentExists(arg2 int) (_swig_ret bool, err error)
+	UpsertDocument(docno string, text string, fileClass string, metadata map[string]string) (docid int, replaced []int, err error)
+	DeleteByDocno(docno string) (deleted []int, err error)
 	DocumentsIndexed() (_swig_ret int, err error)
 	DocumentsSeen() (_swig_ret int, err error)
 }
 
+//
+// indexEnvironment is the IndexEnvironment NewIndexEnvironment returns. It
+// overrides the methods that open, close and add files, to keep the
+// indri_go_cancel_status the repository was created or opened with.
+//
+type indexEnvironment struct {
+    SwigcptrWrapped_IndexEnvironment
+
+    mu sync.Mutex
+    status C.uintptr_t
+}
+
 func NewIndexEnvironment() IndexEnvironment {
-    return (IndexEnvironment)(SwigcptrWrapped_IndexEnvironment(C._wrap_new_Wrapped_IndexEnvironment_indri_go_add17ee78870902e()))
+    return &indexEnvironment{SwigcptrWrapped_IndexEnvironment: SwigcptrWrapped_IndexEnvironment(C._wrap_new_Wrapped_IndexEnvironment_indri_go_add17ee78870902e())}
 }
 
 func (e SwigcptrWrapped_IndexEnvironment) SetDocumentRoot(arg2 string) (err error) {
@@ -162,39 +357,80 @@ func (e SwigcptrWrapped_IndexEnvironment) SetStoreDocs(arg2 bool) (err error) {
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Create(a ...interface{}) (err error) {
+func (e *indexEnvironment) cancelStatus() (status C.uintptr_t, ok bool) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    return e.status, e.status != 0
+}
+
+func (e *indexEnvironment) setCancelStatus(status C.uintptr_t) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    if e.status != 0 {
+        C.indri_go_cancel_status_delete(e.status)
+    }
+    e.status = status
+}
+
+//
+// withCancelStatus wraps next, which may be nil, in a new cancel status,
+// passes it to create or open and keeps it once the repository is open.
+//
+func (e *indexEnvironment) withCancelStatus(next interface{}, open func(status IndexStatus)) {
+    var p C.uintptr_t
+    if next != nil {
+        p = C.uintptr_t(next.(IndexStatus).Swigcptr())
+    }
+    status := C.indri_go_cancel_status_new(p)
+    opened := false
+    defer func() {
+        if !opened {
+            C.indri_go_cancel_status_delete(status)
+        }
+    }()
+    open(SwigcptrIndexStatus(status))
+    opened = true
+    e.setCancelStatus(status)
+}
+
+func (e *indexEnvironment) Create(a ...interface{}) (err error) {
     defer catch(&err)
     argc := len(a)
-	if argc == 1 {
-		e.Wrapped_create(a[0].(string))
-		return
-	}
-	if argc == 2 {
-		e.Wrapped_create(a[0].(string), a[1].(IndexStatus))
+	if argc == 1 || argc == 2 {
+		var next interface{}
+		if argc == 2 {
+			next = a[1]
+		}
+		e.withCancelStatus(next, func(status IndexStatus) {
+			e.Wrapped_create(a[0].(string), status)
+		})
 		return
 	}
 	panic("No match for overloaded function call")
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Open(a ...interface{}) (err error) {
+func (e *indexEnvironment) Open(a ...interface{}) (err error) {
     defer catch(&err)
     argc := len(a)
-	if argc == 1 {
-		e.Wrapped_open(a[0].(string))
-		return
-	}
-	if argc == 2 {
-		e.Wrapped_open(a[0].(string), a[1].(IndexStatus))
+	if argc == 1 || argc == 2 {
+		var next interface{}
+		if argc == 2 {
+			next = a[1]
+		}
+		e.withCancelStatus(next, func(status IndexStatus) {
+			e.Wrapped_open(a[0].(string), status)
+		})
 		return
 	}
 	panic("No match for overloaded function call")
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Close() (err error) {
+func (e *indexEnvironment) Close() (err error) {
     defer catch(&err)
     e.Wrapped_close()
+    e.setCancelStatus(0)
     return
 }
 
@@ -213,6 +449,48 @@ func (e SwigcptrWrapped_IndexEnvironment) AddFile(a ...interface{}) (err error)
     return
 }
 
+// cancelCheck is the AddFileContext check on each status call
+type cancelCheck struct {
+    ctx context.Context
+    status C.uintptr_t
+}
+
+func (c *cancelCheck) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    if c.ctx.Err() != nil {
+        C.indri_go_cancel_status_cancel(c.status)
+    }
+}
+
+//
+// AddFileContext takes the same arguments as AddFile. ctx is checked on
+// each status call, once it is done the file is abandoned after the
+// document being indexed, documents already added are kept and ctx.Err()
+// is returned. The repository stays open.
+//
+func (e *indexEnvironment) AddFileContext(ctx context.Context, a ...interface{}) (err error) {
+    if err = ctx.Err(); err != nil {
+        return
+    }
+    status, ok := e.cancelStatus()
+    if !ok {
+        // not open, let AddFile report it
+        return e.AddFile(a...)
+    }
+
+    check := NewDirectorIndexStatus(&cancelCheck{ctx: ctx, status: status})
+    C.indri_go_cancel_status_check(status, C.uintptr_t(check.Swigcptr()))
+    defer func() {
+        C.indri_go_cancel_status_check(status, 0)
+        DeleteDirectorIndexStatus(check)
+    }()
+
+    err = e.AddFile(a...)
+    if err != nil && ctx.Err() != nil {
+        err = ctx.Err()
+    }
+    return
+}
+
 func (e SwigcptrWrapped_IndexEnvironment) AddString(arg2 string, arg3 string, arg4 MetadataPairVector) (_swig_ret int, err error) {
     defer catch(&err)
     _swig_ret = e.Wrapped_addString(arg2, arg3, arg4)
@@ -225,6 +503,105 @@ func (e SwigcptrWrapped_IndexEnvironment) AddParsedDocument(arg2 ParsedDocument)
     return
 }
 
+//
+// AddDocument adds a document held in text, parsed with fileClass, and
+// attaches metadata to it. It is AddString without the MetadataPairVector,
+// the metadata is copied into C++ in one call.
+//
+func (e SwigcptrWrapped_IndexEnvironment) AddDocument(arg2 string, arg3 string, arg4 map[string]string) (_swig_ret int, err error) {
+    defer catch(&err)
+
+    var metadata []byte
+    lengths := make([]C.swig_intgo, 0, 2 * len(arg4))
+    for k, v := range arg4 {
+        metadata = append(metadata, k...)
+        metadata = append(metadata, v...)
+        lengths = append(lengths, C.swig_intgo(len(k)), C.swig_intgo(len(v)))
+    }
+    var plengths *C.swig_intgo
+    if len(lengths) > 0 {
+        plengths = &lengths[0]
+    }
+
+    _swig_ret = int(C.indri_go_index_environment_add_document(C.uintptr_t(e), gostring(arg2), gostring(arg3), gostring(string(metadata)), plengths, C.swig_intgo(len(arg4))))
+    if Swig_escape_always_false {
+        Swig_escape_val = arg2
+        Swig_escape_val = arg3
+    }
+    return
+}
+
+//
+// DocumentIDsFromDocno returns the ids of the documents in the open
+// repository with the docno arg2. Deleted documents, which keep their
+// docno metadata until the repository is compacted, are left out.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DocumentIDsFromDocno(arg2 string) (_swig_ret []int, err error) {
+    defer catch(&err)
+    ids := SwigcptrIntVector(C.indri_go_index_environment_document_ids(C.uintptr_t(e), gostring("docno"), gostring(arg2)))
+    _swig_ret = takeInts(ids)
+    if Swig_escape_always_false {
+        Swig_escape_val = arg2
+    }
+    return
+}
+
+//
+// DocumentExists reports whether arg2 is the id of a document of the open
+// repository that is not deleted.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DocumentExists(arg2 int) (_swig_ret bool, err error) {
+    defer catch(&err)
+    _swig_ret = C.indri_go_index_environment_document_live(C.uintptr_t(e), C.swig_intgo(arg2)) != 0
+    return
+}
+
+//
+// UpsertDocument adds text as the document docno, replacing any document
+// already indexed with that docno. docno is added to metadata. It returns
+// the id of the new document and the ids of the replaced documents, which
+// are deleted once the new one is added.
+//
+func (e SwigcptrWrapped_IndexEnvironment) UpsertDocument(docno string, text string, fileClass string, metadata map[string]string) (docid int, replaced []int, err error) {
+    if replaced, err = e.DocumentIDsFromDocno(docno); err != nil {
+        return
+    }
+
+    m := make(map[string]string, len(metadata) + 1)
+    for k, v := range metadata {
+        m[k] = v
+    }
+    m["docno"] = docno
+    if docid, err = e.AddDocument(text, fileClass, m); err != nil {
+        return
+    }
+
+    for _, id := range replaced {
+        if err = e.DeleteDocument(id); err != nil {
+            return
+        }
+    }
+    return
+}
+
+//
+// DeleteByDocno deletes the documents indexed with docno and returns their
+// ids, none when there are no such documents.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DeleteByDocno(docno string) (deleted []int, err error) {
+    ids, err := e.DocumentIDsFromDocno(docno)
+    if err != nil {
+        return
+    }
+    for _, id := range ids {
+        if err = e.DeleteDocument(id); err != nil {
+            return
+        }
+        deleted = append(deleted, id)
+    }
+    return
+}
+
 func (e SwigcptrWrapped_IndexEnvironment) DocumentsIndexed() (_swig_ret int, err error) {
     defer catch(&err)
     _swig_ret = e.Wrapped_documentsIndexed()
@@ -272,16 +649,8 @@ func (om *overwrittenMethodsOnIndexStatus) Status( code int, documentFile string
 
     // C++ code defines class local enums, method signature uses int for code
     // swiggo generates constants we cannot use because of signature mismatch
-    // note: must match C++ definition
-    const (
-        FileOpen = iota
-        FileSkip
-        FileError
-        FileClose
-        DocumentCount
-    )
-
-    switch(code) {
+    // so we switch on the go IndexEventKind copy of the enum
+    switch(IndexEventKind(code)) {
     case FileOpen:
         Buildindex_print_event( fmt.Sprintf("Opened %v", documentFile) )
 
@@ -320,6 +689,136 @@ func DeleteMyStatusMonitor(isgs MyStatusMonitor) {
     isgs.DeleteIndexStatus()
 }
 
+//
+// IndexEventKind mirrors the C++ indri::api::IndexStatus::action_code enum.
+// note: must match C++ definition
+//
+type IndexEventKind int
+
+const (
+    FileOpen IndexEventKind = iota
+    FileSkip
+    FileError
+    FileClose
+    DocumentCount
+)
+
+func (k IndexEventKind) String() string {
+    switch k {
+    case FileOpen:
+        return "FileOpen"
+    case FileSkip:
+        return "FileSkip"
+    case FileError:
+        return "FileError"
+    case FileClose:
+        return "FileClose"
+    case DocumentCount:
+        return "DocumentCount"
+    }
+    return fmt.Sprintf("IndexEventKind(%d)", int(k))
+}
+
+// IndexEvent is one IndexStatus.Status call
+type IndexEvent struct {
+    Kind IndexEventKind
+    Path string
+    Error string
+    DocumentsIndexed int
+    DocumentsSeen int
+}
+
+//
+// IndexStatusChannel is an IndexStatus that sends each status call as an
+// IndexEvent on a channel, see NewIndexStatusChannel.
+//
+type IndexStatusChannel interface {
+    MyStatusMonitor
+}
+
+type indexStatusChannel struct {
+    IndexStatus
+}
+
+// the channel is closed first, releasing a Status call blocked on it
+func (isc *indexStatusChannel) DeleteIndexStatus() {
+    isc.IndexStatus.DirectorInterface().(*overwrittenMethodsOnIndexStatusChannel).close()
+    DeleteDirectorIndexStatus(isc.IndexStatus)
+}
+
+func (isc *indexStatusChannel) IsIndexStatus() {}
+
+//
+// overwrittenMethodsOnIndexStatusChannel sends events while holding mu for
+// reading, and close takes it for writing to close events. done is closed
+// first so a send blocked on a consumer that stopped reading gives up.
+//
+type overwrittenMethodsOnIndexStatusChannel struct {
+    events chan IndexEvent
+    done chan struct{}
+    stop sync.Once
+    mu sync.RWMutex
+    closed bool
+}
+
+//
+// Status blocks on a full channel for file events, so none are lost while
+// the channel is read. DocumentCount events carry running totals and are
+// dropped instead, the next one sent makes up for them.
+//
+func (om *overwrittenMethodsOnIndexStatusChannel) Status( code int, documentFile string, error string, documentsIndexed int, documentsSeen int ) {
+    om.mu.RLock()
+    defer om.mu.RUnlock()
+    if om.closed {
+        return
+    }
+
+    event := IndexEvent{
+        Kind: IndexEventKind(code),
+        Path: documentFile,
+        Error: error,
+        DocumentsIndexed: documentsIndexed,
+        DocumentsSeen: documentsSeen,
+    }
+    if event.Kind == DocumentCount {
+        select {
+        case om.events <- event:
+        default:
+        }
+        return
+    }
+    select {
+    case om.events <- event:
+    case <-om.done:
+    }
+}
+
+func (om *overwrittenMethodsOnIndexStatusChannel) close() {
+    om.stop.Do(func() { close(om.done) })
+    om.mu.Lock()
+    defer om.mu.Unlock()
+    if !om.closed {
+        om.closed = true
+        close(om.events)
+    }
+}
+
+//
+// NewIndexStatusChannel returns an IndexStatus for IndexEnvironment Create
+// and Open, and the channel its events are sent on. buffer is the channel
+// capacity. The channel must be drained while documents are added, and is
+// closed by DeleteIndexStatusChannel.
+//
+func NewIndexStatusChannel(buffer int) (IndexStatusChannel, <-chan IndexEvent) {
+    om := &overwrittenMethodsOnIndexStatusChannel{events: make(chan IndexEvent, buffer), done: make(chan struct{})}
+    isc := &indexStatusChannel{IndexStatus: NewDirectorIndexStatus(om)}
+    return isc, om.events
+}
+
+func DeleteIndexStatusChannel(isc IndexStatusChannel) {
+    isc.DeleteIndexStatus()
+}
+
 %}
 
 #endif
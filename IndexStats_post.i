@@ -0,0 +1,230 @@
+#ifdef SWIGGO
+
+%{
+extern "C" {
+
+//
+// the power of two histogram of the lengths of the documents of the
+// index-th repository of env that are not deleted, in the one call rather
+// than one a document. bucket 0 counts the empty documents, bucket i the
+// lengths 2^(i-1) to 2^i-1.
+//
+std::vector<int>* indri_go_document_length_histogram( indri::api::QueryEnvironment* env, intgo index ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    std::vector<int> buckets;
+    if( index >= 0 && index < (intgo) repositories.size() ) {
+      indri::collection::Repository* repository = repositories[index];
+      // ids run from 1 to the documents of all its indexes, deleted ones
+      // included, though the collection may not have them all
+      INT64 maximum = 0;
+      indri::collection::Repository::index_state indexes = repository->indexes();
+      for( size_t i = 0; i < indexes->size(); i++ ) {
+        indri::thread::ScopedLock lock( (*indexes)[i]->statisticsLock() );
+        maximum += (*indexes)[i]->documentCount();
+      }
+      for( lemur::api::DOCID_T id = 1; id <= maximum; id++ ) {
+        if( !repository->collection()->exists( id ) || repository->deletedList().isDeleted( id ) )
+          continue;
+        size_t i = 0;
+        for( int n = env->documentLength( id ); n > 0; n >>= 1 )
+          i++;
+        if( buckets.size() <= i )
+          buckets.resize( i + 1, 0 );
+        buckets[i]++;
+      }
+    }
+    return new std::vector<int>( buckets );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+//
+// the terms in the extents of field over the local repositories of env,
+// from the statistics of their indexes.
+//
+intgo indri_go_field_term_count( indri::api::QueryEnvironment* env, _gostring_ field ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    std::string name( field.p, field.n );
+    INT64 total = 0;
+    for( size_t r = 0; r < repositories.size(); r++ ) {
+      indri::collection::Repository::index_state indexes = repositories[r]->indexes();
+      for( size_t i = 0; i < indexes->size(); i++ ) {
+        indri::thread::ScopedLock lock( (*indexes)[i]->statisticsLock() );
+        total += (*indexes)[i]->fieldTermCount( name );
+      }
+    }
+    return total;
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+extern uintptr_t indri_go_document_length_histogram(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo indri_go_field_term_count(uintptr_t arg1, _gostring_ arg2);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  collection statistics
+//
+
+// FieldStats counts the extents of an indexed field and the terms in them
+type FieldStats struct {
+    Name string
+    Extents int64
+    Terms int64
+}
+
+// LengthBucket counts the documents with Min to Max terms
+type LengthBucket struct {
+    Min int
+    Max int
+    Documents int64
+}
+
+//
+// IndexStatistics describes the collection of a QueryEnvironment. The
+// MetadataFields are the forward and backward lookup fields read from the
+// manifest of each index, any other metadata is only stored.
+//
+type IndexStatistics struct {
+    Documents int64
+    Terms int64
+    UniqueTerms int64
+    AverageDocumentLength float64
+    Fields []FieldStats
+    DocumentLengths []LengthBucket
+    MetadataFields []string
+}
+
+//
+// IndexStats collects the statistics of the indexes and servers added to
+// env, which must come from NewQueryEnvironment as the indexes it keeps
+// track of are read from their manifests. Field extents are counted by
+// the query #any:field, and field terms from the statistics of the local
+// indexes. DocumentLengths come from the length of every document not
+// deleted, in power of two buckets. Document ids are only contiguous over
+// a single index, so DocumentLengths is left empty for an environment with
+// more than one index or any server.
+//
+func IndexStats(env QueryEnvironment) (stats IndexStatistics, err error) {
+    defer catch(&err)
+
+    qe, ok := env.(*queryEnvironment)
+    if !ok {
+        err = fmt.Errorf("IndexStats of a %T, expected a QueryEnvironment from NewQueryEnvironment", env)
+        return
+    }
+
+    if stats.Documents, err = env.DocumentCount(); err != nil {
+        return
+    }
+    if stats.Terms, err = env.TermCount(); err != nil {
+        return
+    }
+    if stats.UniqueTerms, err = env.TermCountUnique(); err != nil {
+        return
+    }
+    if stats.Documents > 0 {
+        stats.AverageDocumentLength = float64(stats.Terms) / float64(stats.Documents)
+    }
+
+    fields, err := env.FieldList()
+    if err != nil {
+        return
+    }
+    for _, name := range fields {
+        extents, e := env.ExpressionCount("#any:" + name)
+        if e != nil {
+            err = e
+            return
+        }
+        f := FieldStats{Name: name, Extents: int64(extents)}
+        if f.Terms, err = fieldTermCount(env, name); err != nil {
+            return
+        }
+        stats.Fields = append(stats.Fields, f)
+    }
+
+    indexes, servers := qe.sources()
+    if len(indexes) == 1 && len(servers) == 0 {
+        if stats.DocumentLengths, err = documentLengthHistogram(env); err != nil {
+            return
+        }
+    }
+
+    seen := make(map[string]bool)
+    for _, index := range indexes {
+        names, e := manifestMetadataFields(index)
+        if e != nil {
+            err = e
+            return
+        }
+        for _, name := range names {
+            if !seen[name] {
+                seen[name] = true
+                stats.MetadataFields = append(stats.MetadataFields, name)
+            }
+        }
+    }
+    return
+}
+
+// documentLengthHistogram buckets the document lengths of the single index of env
+func documentLengthHistogram(env QueryEnvironment) (buckets []LengthBucket, err error) {
+    defer catch(&err)
+    counts := takeInts(SwigcptrIntVector(C.indri_go_document_length_histogram(C.uintptr_t(env.Swigcptr()), 0)))
+    for i, n := range counts {
+        min, max := 0, 0
+        if i > 0 {
+            min, max = 1 << uint(i-1), 1 << uint(i) - 1
+        }
+        buckets = append(buckets, LengthBucket{Min: min, Max: max, Documents: int64(n)})
+    }
+    return
+}
+
+// fieldTermCount counts the terms in the extents of a field over the local indexes of env
+func fieldTermCount(env QueryEnvironment, name string) (count int64, err error) {
+    defer catch(&err)
+    count = int64(C.indri_go_field_term_count(C.uintptr_t(env.Swigcptr()), gostring(name)))
+    return
+}
+
+// manifestMetadataFields reads the metadata lookup fields of a repository
+func manifestMetadataFields(repositoryPath string) (names []string, err error) {
+    b, err := ioutil.ReadFile(filepath.Join(repositoryPath, "manifest"))
+    if err != nil {
+        return
+    }
+    var manifest struct {
+        Metadata xmlIndexMetadata `xml:"metadata"`
+    }
+    if err = xml.Unmarshal(b, &manifest); err != nil {
+        err = fmt.Errorf("failed to parse %v manifest: %v", repositoryPath, err)
+        return
+    }
+    seen := make(map[string]bool)
+    for _, name := range append(manifest.Metadata.Forward, manifest.Metadata.Backward...) {
+        name = strings.TrimSpace(name)
+        if name != "" && !seen[name] {
+            seen[name] = true
+            names = append(names, name)
+        }
+    }
+    return
+}
+
+%}
+
+#endif
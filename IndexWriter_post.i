@@ -0,0 +1,403 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  single writer index
+//
+// an IndexEnvironment is opened once and written by one thread at a time.
+// IndexWriter owns one on a goroutine locked to its OS thread, and runs
+// the operations queued by any goroutine in order, in batches.
+//
+
+//
+// IndexWriterOptions of an IndexWriter, a zero value uses the defaults.
+// QueueSize operations wait to run before adding another blocks, and up to
+// BatchSize are run together. Writes are committed, the repository closed
+// and reopened so that QueryEnvironments opened after see them, every
+// CommitInterval and after CommitDocuments writes, zero disables either.
+// Commit and Close always commit. Committed, when not nil, is called on
+// the writer goroutine after each commit that succeeds, to refresh the
+// readers of the repository, and must not wait for the writer. Once a
+// commit fails, the operations queued fail with its error until the
+// repository is reopened, by a Commit or by the next CommitInterval tick
+// retrying it.
+//
+type IndexWriterOptions struct {
+    QueueSize int
+    BatchSize int
+    CommitInterval time.Duration
+    CommitDocuments int
+    Committed func()
+}
+
+const (
+    DefaultIndexWriterQueueSize = 1024
+    DefaultIndexWriterBatchSize = 100
+)
+
+//
+// IndexResult is the result of an IndexWriter operation, Docid is the id
+// of a document added by AddString, Deleted the ids of documents deleted.
+//
+type IndexResult struct {
+    Docid int
+    Deleted []int
+}
+
+//
+// IndexFuture is the pending result of an operation, Done is closed once
+// it has run.
+//
+type IndexFuture struct {
+    done chan struct{}
+    result IndexResult
+    err error
+}
+
+func newIndexFuture() *IndexFuture {
+    return &IndexFuture{done: make(chan struct{})}
+}
+
+func (f *IndexFuture) resolve(result IndexResult, err error) {
+    f.result, f.err = result, err
+    close(f.done)
+}
+
+// Done is closed once the operation has run
+func (f *IndexFuture) Done() <-chan struct{} {
+    return f.done
+}
+
+// Wait waits for the operation and returns its result
+func (f *IndexFuture) Wait() (IndexResult, error) {
+    <-f.done
+    return f.result, f.err
+}
+
+//
+// WaitContext is Wait returning ctx.Err() if ctx is done first. The
+// operation still runs.
+//
+func (f *IndexFuture) WaitContext(ctx context.Context) (IndexResult, error) {
+    select {
+    case <-f.done:
+        return f.result, f.err
+    case <-ctx.Done():
+        return IndexResult{}, ctx.Err()
+    }
+}
+
+// IndexWriterStats counts the work of an IndexWriter
+type IndexWriterStats struct {
+    Queued int
+    Operations int64
+    Errors int64
+    Batches int64
+    Commits int64
+    LastCommit time.Time
+}
+
+// indexOp is a queued operation, a nil apply is a commit
+type indexOp struct {
+    apply func(env IndexEnvironment) (IndexResult, error)
+    future *IndexFuture
+}
+
+//
+// IndexWriter serializes the writes to a repository through one goroutine.
+//
+type IndexWriter struct {
+    cfg IndexConfig
+    opts IndexWriterOptions
+
+    // mu is held to queue, Close holds it exclusively to close ops
+    mu sync.RWMutex
+    closed bool
+    ops chan indexOp
+    stopped chan struct{}
+    closeErr error
+
+    statsMu sync.Mutex
+    stats IndexWriterStats
+}
+
+//
+// NewIndexWriter configures an IndexEnvironment with cfg, opens or
+// creates the repository cfg.Index and starts the writer goroutine. The
+// corpora of cfg are not added. It fails if the repository cannot be
+// opened.
+//
+func NewIndexWriter(cfg IndexConfig, opts IndexWriterOptions) (*IndexWriter, error) {
+    if cfg.Index == "" {
+        return nil, fmt.Errorf("Must specify a index parameter.")
+    }
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = DefaultIndexWriterQueueSize
+    }
+    if opts.BatchSize <= 0 {
+        opts.BatchSize = DefaultIndexWriterBatchSize
+    }
+    w := &IndexWriter{
+        cfg: cfg,
+        opts: opts,
+        ops: make(chan indexOp, opts.QueueSize),
+        stopped: make(chan struct{}),
+    }
+    opened := make(chan error, 1)
+    go w.run(opened)
+    if err := <-opened; err != nil {
+        return nil, err
+    }
+    return w, nil
+}
+
+// open returns a new environment on the repository
+func (w *IndexWriter) open() (env IndexEnvironment, err error) {
+    defer catch(&err)
+    env = NewIndexEnvironment()
+    if err = Configure(env, w.cfg); err == nil {
+        err = openRepository(env, w.cfg.Index, nil)
+    }
+    if err != nil {
+        DeleteWrapped_IndexEnvironment(env)
+        env = nil
+    }
+    return
+}
+
+func closeIndexEnvironment(env IndexEnvironment) error {
+    defer DeleteWrapped_IndexEnvironment(env)
+    return env.Close()
+}
+
+//
+// run is the writer goroutine. It stays on one OS thread, as indri only
+// expects a single thread to use the environment.
+//
+func (w *IndexWriter) run(opened chan<- error) {
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+    defer close(w.stopped)
+
+    env, err := w.open()
+    opened <- err
+    if err != nil {
+        return
+    }
+
+    var tick <-chan time.Time
+    if w.opts.CommitInterval > 0 {
+        ticker := time.NewTicker(w.opts.CommitInterval)
+        defer ticker.Stop()
+        tick = ticker.C
+    }
+
+    // writes since the last commit
+    writes := 0
+    // the error of a failed commit, writes fail with it until a Commit or
+    // a tick reopens the environment
+    var failed error
+    commit := func() error {
+        var err error
+        if env != nil {
+            err = closeIndexEnvironment(env)
+        }
+        env = nil
+        if err == nil {
+            env, err = w.open()
+        }
+        if err != nil {
+            failed = fmt.Errorf("index writer commit failed, writes are refused until it is reopened: %w", err)
+            return failed
+        }
+        failed = nil
+        writes = 0
+        w.statsMu.Lock()
+        w.stats.Commits++
+        w.stats.LastCommit = time.Now()
+        w.statsMu.Unlock()
+        if w.opts.Committed != nil {
+            w.opts.Committed()
+        }
+        return nil
+    }
+
+    for {
+        var batch []indexOp
+        select {
+        case op, ok := <-w.ops:
+            if !ok {
+                if env != nil {
+                    w.closeErr = closeIndexEnvironment(env)
+                } else {
+                    w.closeErr = failed
+                }
+                return
+            }
+            batch = append(batch, op)
+        case <-tick:
+            if writes > 0 || failed != nil {
+                commit()
+            }
+            continue
+        }
+    gather:
+        for len(batch) < w.opts.BatchSize {
+            select {
+            case op, ok := <-w.ops:
+                if !ok {
+                    break gather
+                }
+                batch = append(batch, op)
+            default:
+                break gather
+            }
+        }
+
+        var commits []*IndexFuture
+        var operations, errs int64
+        for _, op := range batch {
+            if op.apply == nil {
+                if failed != nil {
+                    // retried at once, so that the writes after it run
+                    op.future.resolve(IndexResult{}, commit())
+                } else {
+                    commits = append(commits, op.future)
+                }
+                continue
+            }
+            operations++
+            var result IndexResult
+            err := failed
+            if err == nil {
+                result, err = op.apply(env)
+                writes++
+            }
+            if err != nil {
+                errs++
+            }
+            op.future.resolve(result, err)
+        }
+        w.statsMu.Lock()
+        w.stats.Operations += operations
+        w.stats.Errors += errs
+        w.stats.Batches++
+        w.statsMu.Unlock()
+
+        if failed == nil && (len(commits) > 0 || w.opts.CommitDocuments > 0 && writes >= w.opts.CommitDocuments) {
+            err := commit()
+            for _, f := range commits {
+                f.resolve(IndexResult{}, err)
+            }
+        } else {
+            for _, f := range commits {
+                f.resolve(IndexResult{}, failed)
+            }
+        }
+    }
+}
+
+// queue adds op to the queue, blocking while it is full
+func (w *IndexWriter) queue(apply func(env IndexEnvironment) (IndexResult, error)) *IndexFuture {
+    f := newIndexFuture()
+    w.mu.RLock()
+    defer w.mu.RUnlock()
+    if w.closed {
+        f.resolve(IndexResult{}, ErrClosed)
+        return f
+    }
+    w.ops <- indexOp{apply: apply, future: f}
+    return f
+}
+
+// AddString queues adding text, of fileClass, with metadata
+func (w *IndexWriter) AddString(text string, fileClass string, metadata map[string]string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        r.Docid, err = env.AddDocument(text, fileClass, metadata)
+        return
+    })
+}
+
+//
+// AddFile queues adding the documents of the file at path, an empty
+// fileClass is chosen from the file extension. No Docid is returned.
+//
+func (w *IndexWriter) AddFile(path string, fileClass string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        if fileClass == "" {
+            err = env.AddFile(path)
+        } else {
+            err = env.AddFile(path, fileClass)
+        }
+        return
+    })
+}
+
+// DeleteDocument queues deleting the document docid
+func (w *IndexWriter) DeleteDocument(docid int) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        if err = env.DeleteDocument(docid); err == nil {
+            r.Deleted = []int{docid}
+        }
+        return
+    })
+}
+
+// DeleteByDocno queues deleting the documents named docno
+func (w *IndexWriter) DeleteByDocno(docno string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        r.Deleted, err = env.DeleteByDocno(docno)
+        return
+    })
+}
+
+//
+// Do queues f, run with the environment on the writer goroutine. env must
+// not be used once f returns, and f must not wait for the writer.
+//
+func (w *IndexWriter) Do(f func(env IndexEnvironment) (IndexResult, error)) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        defer catch(&err)
+        return f(env)
+    })
+}
+
+//
+// Commit queues a commit of the operations queued before it, its future
+// is done once they are visible to QueryEnvironments opened after. After
+// a failed commit it reopens the repository, and the writes queued after
+// it run again.
+//
+func (w *IndexWriter) Commit() *IndexFuture {
+    return w.queue(nil)
+}
+
+// Stats returns a snapshot of the writer statistics
+func (w *IndexWriter) Stats() IndexWriterStats {
+    w.statsMu.Lock()
+    defer w.statsMu.Unlock()
+    stats := w.stats
+    stats.Queued = len(w.ops)
+    return stats
+}
+
+//
+// Close runs the operations queued, closes the repository and returns the
+// error closing it, or that of a failed commit not reopened. Operations
+// queued after fail with ErrClosed.
+//
+func (w *IndexWriter) Close() error {
+    w.mu.Lock()
+    if !w.closed {
+        w.closed = true
+        close(w.ops)
+    }
+    w.mu.Unlock()
+    <-w.stopped
+    return w.closeErr
+}
+
+%}
+
+#endif
@@ -32,6 +32,326 @@ func Wrapped_Buildindex_mymain(argc int, arg0, arg1, arg2, arg3, arg4 string) (e
     return
 }
 
+//
+// BuildContext builds the repository described by cfg the way
+// IndriBuildIndex does, using AddFileContext for each corpus file. When
+// ctx is done the build stops after the document being indexed, the
+// repository is closed with the documents indexed so far and ctx.Err()
+// is returned. status may be nil, a file that fails is then printed and
+// the build goes on with the next file, as IndriBuildIndex does.
+//
+func BuildContext(ctx context.Context, cfg IndexConfig, status IndexStatus) (err error) {
+    if status == nil {
+        printer := NewDirectorIndexStatus(fileErrorPrinter{})
+        defer DeleteDirectorIndexStatus(printer)
+        status = printer
+    }
+    return build(ctx, cfg, status, nil, nil)
+}
+
+// fileErrorPrinter is the IndexStatus of BuildContext without one
+type fileErrorPrinter struct{}
+
+func (fileErrorPrinter) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    if IndexEventKind(code) == FileError {
+        Buildindex_print_event(fmt.Sprintf("Error in %v : %v", documentFile, error))
+    }
+}
+
+//
+// BuildOptions selects corpus files for BuildIndex. A pattern containing
+// a separator is matched against the path relative to the corpus path,
+// any other pattern against the file name, see filepath.Match. With
+// Include set only files matching one of its patterns are indexed, files
+// matching an Exclude pattern never are. Status may be nil.
+//
+type BuildOptions struct {
+    Include []string
+    Exclude []string
+    Status IndexStatus
+}
+
+// BuildFile is a corpus file BuildIndex did not index, and why
+type BuildFile struct {
+    Path string
+    Reason string
+}
+
+//
+// BuildReport is the outcome of BuildIndex. FilesSeen counts every corpus
+// file, FilesSkipped and FilesFailed list those that were not indexed.
+//
+type BuildReport struct {
+    FilesSeen int
+    FilesSkipped []BuildFile
+    FilesFailed []BuildFile
+    DocumentsIndexed int
+    DocumentsSeen int
+    Elapsed time.Duration
+}
+
+//
+// BuildIndex builds the repository described by cfg, as BuildContext
+// does, and reports on each corpus file. A file that fails to parse is
+// reported in FilesFailed and the build goes on with the next file, as
+// IndriBuildIndex does. The report is returned with the error, covering
+// the files reached before it.
+//
+func BuildIndex(ctx context.Context, cfg IndexConfig, opts BuildOptions) (report BuildReport, err error) {
+    start := time.Now()
+    defer func() {
+        report.Elapsed = time.Since(start)
+    }()
+
+    for _, pattern := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+        if _, e := filepath.Match(pattern, ""); e != nil {
+            err = fmt.Errorf("bad file pattern %q: %v", pattern, e)
+            return
+        }
+    }
+
+    r := &buildRecorder{ctx: ctx, report: &report, next: opts.Status}
+    status := NewDirectorIndexStatus(r)
+    defer DeleteDirectorIndexStatus(status)
+
+    filter := func(root, path string) bool {
+        report.FilesSeen++
+        reason := opts.filter(root, path)
+        if reason != "" {
+            report.FilesSkipped = append(report.FilesSkipped, BuildFile{Path: path, Reason: reason})
+        }
+        return reason == ""
+    }
+    counts := func(env IndexEnvironment) {
+        report.DocumentsIndexed, _ = env.DocumentsIndexed()
+        report.DocumentsSeen, _ = env.DocumentsSeen()
+    }
+    err = build(ctx, cfg, status, filter, counts)
+    return
+}
+
+// filter returns why path is not indexed, or "" when it is
+func (opts BuildOptions) filter(root, path string) string {
+    name := filepath.Base(path)
+    rel, e := filepath.Rel(root, path)
+    if e != nil || rel == "." {
+        rel = name
+    }
+    match := func(pattern string) bool {
+        target := name
+        if strings.ContainsRune(pattern, '/') || strings.ContainsRune(pattern, filepath.Separator) {
+            target = filepath.ToSlash(rel)
+            pattern = filepath.ToSlash(pattern)
+        }
+        ok, _ := filepath.Match(pattern, target)
+        return ok
+    }
+    for _, pattern := range opts.Exclude {
+        if match(pattern) {
+            return "excluded by " + pattern
+        }
+    }
+    if len(opts.Include) == 0 {
+        return ""
+    }
+    for _, pattern := range opts.Include {
+        if match(pattern) {
+            return ""
+        }
+    }
+    return "not included"
+}
+
+//
+// buildRecorder is the IndexStatus BuildIndex opens the repository with.
+// It records skipped and failed files and passes every call on to next.
+//
+type buildRecorder struct {
+    ctx context.Context
+    report *BuildReport
+    next IndexStatus
+}
+
+func (r *buildRecorder) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    switch IndexEventKind(code) {
+    case FileSkip:
+        r.report.FilesSkipped = append(r.report.FilesSkipped, BuildFile{Path: documentFile, Reason: "no file class"})
+    case FileError:
+        // a cancelled file is abandoned, not failed
+        if r.ctx.Err() == nil {
+            r.report.FilesFailed = append(r.report.FilesFailed, BuildFile{Path: documentFile, Reason: error})
+        }
+    }
+    if r.next != nil {
+        r.next.Status(code, documentFile, error, documentsIndexed, documentsSeen)
+    }
+}
+
+//
+// build is BuildContext and BuildIndex. filter, when not nil, is asked
+// whether to index each corpus file, and counts, when not nil, is called
+// with the open repository once the corpora are added.
+//
+func build(ctx context.Context, cfg IndexConfig, status IndexStatus, filter corpusFilter, counts func(env IndexEnvironment)) (err error) {
+    defer catch(&err)
+
+    if cfg.Index == "" {
+        return fmt.Errorf("Must specify a index parameter.")
+    }
+    if len(cfg.Corpora) == 0 {
+        return fmt.Errorf("Must specify a corpus parameter.")
+    }
+    if err = ctx.Err(); err != nil {
+        return
+    }
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = Configure(env, cfg); err != nil {
+        return
+    }
+    if err = openRepository(env, cfg.Index, status); err != nil {
+        return
+    }
+    defer func() {
+        if counts != nil {
+            counts(env)
+        }
+        if e := env.Close(); err == nil {
+            err = e
+        }
+    }()
+
+    b := newSpecAugmenter(cfg)
+    defer b.delete()
+
+    for _, corpus := range cfg.Corpora {
+        if err = addCorpus(ctx, env, b, corpus, filter); err != nil {
+            return
+        }
+    }
+    return
+}
+
+//
+// openRepository opens an existing repository, recovering it after an
+// indexing crash, or creates a new one.
+//
+func openRepository(env IndexEnvironment, path string, status IndexStatus) (err error) {
+    args := []interface{}{path}
+    if status != nil {
+        args = append(args, status)
+    }
+    if _, e := os.Stat(filepath.Join(path, "manifest")); e == nil {
+        var recovered bool
+        if recovered, err = Wrapped_Buildindex_recoverRepository(path); err != nil {
+            return
+        }
+        if recovered {
+            if err = env.Open(args...); err == nil {
+                Buildindex_print_event("Opened repository " + path)
+            }
+            return
+        }
+    }
+    // create will remove any cruft.
+    if err = env.Create(args...); err == nil {
+        Buildindex_print_event("Created repository " + path)
+    }
+    return
+}
+
+//
+// specAugmenter adds the configured fields and metadata to file class
+// specifications, as augmentSpec does in IndriBuildIndex.
+//
+type specAugmenter struct {
+    fields, metadata, forward, backward StringVector
+}
+
+func newSpecAugmenter(cfg IndexConfig) *specAugmenter {
+    fields := make([]string, len(cfg.Fields))
+    for i, f := range cfg.Fields {
+        fields[i] = strings.ToLower(f.Name)
+    }
+    metadata := make([]string, len(cfg.Metadata.Fields))
+    for i, m := range cfg.Metadata.Fields {
+        metadata[i] = strings.ToLower(m)
+    }
+    forward, backward := cfg.metadataIndexedFields()
+    return &specAugmenter{
+        fields: newStringVector(fields),
+        metadata: newStringVector(metadata),
+        forward: newStringVector(forward),
+        backward: newStringVector(backward),
+    }
+}
+
+func (b *specAugmenter) delete() {
+    DeleteStringVector(b.fields)
+    DeleteStringVector(b.metadata)
+    DeleteStringVector(b.forward)
+    DeleteStringVector(b.backward)
+}
+
+func (b *specAugmenter) augment(env IndexEnvironment, class string) (err error) {
+    spec, err := env.GetFileClassSpec(class)
+    if err != nil || spec == nil || spec.Swigcptr() == 0 {
+        return
+    }
+    defer Wrapped_deleteFileClassSpec(spec)
+    var changed bool
+    if changed, err = Wrapped_Buildindex_augmentSpec(spec, b.fields, b.metadata, b.forward, b.backward); err != nil || !changed {
+        return
+    }
+    return env.AddFileClass(spec)
+}
+
+// corpusFilter reports whether to index path, a file under the corpus root
+type corpusFilter func(root, path string) bool
+
+func addCorpus(ctx context.Context, env IndexEnvironment, b *specAugmenter, corpus IndexCorpus, filter corpusFilter) (err error) {
+    if corpus.Class != "" {
+        if err = b.augment(env, corpus.Class); err != nil {
+            return
+        }
+    }
+
+    // First record the document root, and then the paths to any annotator inputs
+    if err = env.SetDocumentRoot(corpus.Path); err != nil {
+        return
+    }
+    if err = env.SetAnchorTextPath(corpus.Inlink); err != nil {
+        return
+    }
+    if err = env.SetOffsetAnnotationsPath(corpus.Annotations); err != nil {
+        return
+    }
+    if err = env.SetOffsetMetadataPath(corpus.Metadata); err != nil {
+        return
+    }
+
+    return filepath.Walk(corpus.Path, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if info.IsDir() {
+            return nil
+        }
+        if filter != nil && !filter(corpus.Path, path) {
+            return nil
+        }
+        if corpus.Class != "" {
+            return env.AddFileContext(ctx, path, corpus.Class)
+        }
+        if err = b.augment(env, strings.TrimPrefix(filepath.Ext(path), ".")); err != nil {
+            return err
+        }
+        return env.AddFileContext(ctx, path)
+    })
+}
+
 %}
 
 #endif
@@ -66,10 +66,15 @@
         try {
             $action
         } catch( lemur::api::Exception& e ) {
-            SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+            indri_go_lemur_exception( e );
         }
     }
 %enddef
+// methods declared throw(lemur::api::Exception) catch it inside $action,
+// before setEx does, so they panic with the code too.
+%typemap(throws) lemur::api::Exception %{
+    indri_go_lemur_exception( $1 );
+%}
 #endif
 
 #ifdef SWIGCSHARP
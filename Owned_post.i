@@ -0,0 +1,277 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  go owned wrappers
+//
+// each Owned* type holds one swig wrapper and frees it on Close, or from a
+// finalizer when it is garbage collected without Close. The wrapper is
+// only reachable inside Do, which returns ErrClosed once the owner is
+// closed, so it cannot be used after it is freed. Calls to Do run one at
+// a time, as the wrapped objects are not safe for concurrent use, and
+// Close waits for the call in progress. fn must not call Do or Close on
+// the same owner.
+//
+
+// ErrClosed is returned by Do on a closed Owned* wrapper
+var ErrClosed = errors.New("indri_go: use of closed handle")
+
+type owned struct {
+    mu sync.Mutex
+    free func() error
+}
+
+func (o *owned) do(fn func() error) (err error) {
+    defer catch(&err)
+    o.mu.Lock()
+    defer o.mu.Unlock()
+    if o.free == nil {
+        return ErrClosed
+    }
+    return fn()
+}
+
+func (o *owned) close() (err error) {
+    defer catch(&err)
+    o.mu.Lock()
+    defer o.mu.Unlock()
+    if o.free == nil {
+        return nil
+    }
+    free := o.free
+    o.free = nil
+    return free()
+}
+
+// OwnedParameters owns a Parameters
+type OwnedParameters struct {
+    owned
+    p Parameters
+}
+
+func NewOwnedParameters() *OwnedParameters {
+    p := NewParameters()
+    o := &OwnedParameters{p: p}
+    o.free = func() error {
+        DeleteWrapped_Parameters(p)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedParameters).Close)
+    return o
+}
+
+func (o *OwnedParameters) Do(fn func(p Parameters) error) error {
+    return o.do(func() error { return fn(o.p) })
+}
+
+func (o *OwnedParameters) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+// OwnedStringVector owns a StringVector
+type OwnedStringVector struct {
+    owned
+    v StringVector
+}
+
+func NewOwnedStringVector(values []string) *OwnedStringVector {
+    v := newStringVector(values)
+    o := &OwnedStringVector{v: v}
+    o.free = func() error {
+        DeleteStringVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedStringVector).Close)
+    return o
+}
+
+func (o *OwnedStringVector) Do(fn func(v StringVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedStringVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+// OwnedIntVector owns an IntVector
+type OwnedIntVector struct {
+    owned
+    v IntVector
+}
+
+func NewOwnedIntVector(values []int) *OwnedIntVector {
+    v := newIntVector(values)
+    o := &OwnedIntVector{v: v}
+    o.free = func() error {
+        DeleteIntVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedIntVector).Close)
+    return o
+}
+
+func (o *OwnedIntVector) Do(fn func(v IntVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedIntVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedMetadataPairVector owns a MetadataPairVector. The MetadataPairs
+// added to it are still owned by the caller.
+//
+type OwnedMetadataPairVector struct {
+    owned
+    v Wrapped_MetadataPairVector
+}
+
+func NewOwnedMetadataPairVector() *OwnedMetadataPairVector {
+    v := NewWrapped_MetadataPairVector()
+    o := &OwnedMetadataPairVector{v: v}
+    o.free = func() error {
+        DeleteWrapped_MetadataPairVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedMetadataPairVector).Close)
+    return o
+}
+
+func (o *OwnedMetadataPairVector) Do(fn func(v Wrapped_MetadataPairVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedMetadataPairVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedQueryEnvironment owns a QueryEnvironment, Close closes its indexes
+// and servers before it is freed.
+//
+type OwnedQueryEnvironment struct {
+    owned
+    env QueryEnvironment
+}
+
+func NewOwnedQueryEnvironment() *OwnedQueryEnvironment {
+    env := NewQueryEnvironment()
+    o := &OwnedQueryEnvironment{env: env}
+    o.free = func() error {
+        defer DeleteQueryEnvironment(env)
+        return env.Close()
+    }
+    runtime.SetFinalizer(o, (*OwnedQueryEnvironment).Close)
+    return o
+}
+
+func (o *OwnedQueryEnvironment) Do(fn func(env QueryEnvironment) error) error {
+    return o.do(func() error { return fn(o.env) })
+}
+
+func (o *OwnedQueryEnvironment) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedIndexEnvironment owns an IndexEnvironment, Close closes the
+// repository before it is freed. Closing flushes the repository to disk,
+// so its finalizer closes on a goroutine of its own rather than on the
+// finalizer goroutine.
+//
+// monitor, which may be nil, is the status monitor to give Create or Open
+// from inside Do. The environment holds it and closes it after the
+// repository, so it cannot be freed while the repository still calls it.
+//
+type OwnedIndexEnvironment struct {
+    owned
+    env IndexEnvironment
+    monitor *OwnedStatusMonitor
+}
+
+func NewOwnedIndexEnvironment(monitor *OwnedStatusMonitor) *OwnedIndexEnvironment {
+    env := NewIndexEnvironment()
+    o := &OwnedIndexEnvironment{env: env, monitor: monitor}
+    if monitor != nil {
+        monitor.mu.Lock()
+        monitor.held = true
+        monitor.mu.Unlock()
+        runtime.SetFinalizer(monitor, nil)
+    }
+    o.free = func() error {
+        if monitor != nil {
+            defer monitor.close()
+        }
+        defer DeleteWrapped_IndexEnvironment(env)
+        return env.Close()
+    }
+    runtime.SetFinalizer(o, func(o *OwnedIndexEnvironment) { go o.Close() })
+    return o
+}
+
+func (o *OwnedIndexEnvironment) Do(fn func(env IndexEnvironment) error) error {
+    return o.do(func() error { return fn(o.env) })
+}
+
+func (o *OwnedIndexEnvironment) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedStatusMonitor owns a MyStatusMonitor. Once given to
+// NewOwnedIndexEnvironment it is closed with that environment, and its own
+// Close does nothing.
+//
+type OwnedStatusMonitor struct {
+    owned
+    m MyStatusMonitor
+    held bool
+}
+
+func NewOwnedStatusMonitor() *OwnedStatusMonitor {
+    m := NewMyStatusMonitor()
+    o := &OwnedStatusMonitor{m: m}
+    o.free = func() error {
+        DeleteMyStatusMonitor(m)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedStatusMonitor).Close)
+    return o
+}
+
+func (o *OwnedStatusMonitor) Do(fn func(m MyStatusMonitor) error) error {
+    return o.do(func() error { return fn(o.m) })
+}
+
+func (o *OwnedStatusMonitor) Close() error {
+    o.mu.Lock()
+    held := o.held
+    o.mu.Unlock()
+    if held {
+        return nil
+    }
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+var (
+    _ io.Closer = (*OwnedParameters)(nil)
+    _ io.Closer = (*OwnedStringVector)(nil)
+    _ io.Closer = (*OwnedIntVector)(nil)
+    _ io.Closer = (*OwnedMetadataPairVector)(nil)
+    _ io.Closer = (*OwnedQueryEnvironment)(nil)
+    _ io.Closer = (*OwnedIndexEnvironment)(nil)
+    _ io.Closer = (*OwnedStatusMonitor)(nil)
+)
+
+%}
+
+#endif
@@ -0,0 +1,175 @@
+#ifdef SWIGGO
+
+//
+// read a C++ ParsedDocument vector from go. the vector and the documents it
+// points to are allocated for the caller, so both are freed once copied.
+//
+%{
+extern "C" {
+
+intgo indri_go_parsed_documents_size( std::vector<indri::api::ParsedDocument*>* documents ) {
+  return (intgo) documents->size();
+}
+
+indri::api::ParsedDocument* indri_go_parsed_documents_get( std::vector<indri::api::ParsedDocument*>* documents, intgo i ) {
+  return (*documents)[i];
+}
+
+void indri_go_parsed_documents_delete( std::vector<indri::api::ParsedDocument*>* documents ) {
+  for( size_t i=0; i<documents->size(); i++ )
+    delete (*documents)[i];
+  delete documents;
+}
+
+// text length counts the trailing null, which go does not want
+const char* indri_go_parsed_document_text( indri::api::ParsedDocument* document, intgo* length ) {
+  size_t n = document->textLength;
+  if( n && document->text[n-1] == 0 )
+    n--;
+  *length = (intgo) n;
+  return document->text;
+}
+
+const char* indri_go_parsed_document_content( indri::api::ParsedDocument* document, intgo* length ) {
+  *length = (intgo) document->contentLength;
+  return document->content;
+}
+
+intgo indri_go_parsed_document_terms_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->terms.size();
+}
+
+// stopped terms are null
+const char* indri_go_parsed_document_term( indri::api::ParsedDocument* document, intgo i ) {
+  return document->terms[i];
+}
+
+intgo indri_go_parsed_document_positions_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->positions.size();
+}
+
+// out holds begin, end pairs
+void indri_go_parsed_document_positions( indri::api::ParsedDocument* document, intgo* out ) {
+  for( size_t i=0; i<document->positions.size(); i++ ) {
+    out[2*i] = document->positions[i].begin;
+    out[2*i+1] = document->positions[i].end;
+  }
+}
+
+intgo indri_go_parsed_document_metadata_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->metadata.size();
+}
+
+const char* indri_go_parsed_document_metadata( indri::api::ParsedDocument* document, intgo i, const void** value, intgo* length ) {
+  indri::parse::MetadataPair& pair = document->metadata[i];
+  *value = pair.value;
+  *length = (intgo) pair.valueLength;
+  return pair.key;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+extern swig_intgo indri_go_parsed_documents_size(uintptr_t arg1);
+extern uintptr_t indri_go_parsed_documents_get(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_parsed_documents_delete(uintptr_t arg1);
+extern const char *indri_go_parsed_document_text(uintptr_t arg1, swig_intgo *arg2);
+extern const char *indri_go_parsed_document_content(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_parsed_document_terms_size(uintptr_t arg1);
+extern const char *indri_go_parsed_document_term(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo indri_go_parsed_document_positions_size(uintptr_t arg1);
+extern void indri_go_parsed_document_positions(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_parsed_document_metadata_size(uintptr_t arg1);
+extern const char *indri_go_parsed_document_metadata(uintptr_t arg1, swig_intgo arg2, const void **arg3, swig_intgo *arg4);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend ParsedDocument.i
+//
+
+// TermExtent is a go copy of an indri::parse::TermExtent
+type TermExtent struct {
+    Begin int
+    End int
+}
+
+//
+// Document is a go copy of an indri::api::ParsedDocument. Terms holds an
+// empty string for each stopped term, Positions holds the byte extent of
+// each term in Text.
+//
+type Document struct {
+    Text string
+    Content string
+    Terms []string
+    Positions []TermExtent
+    Metadata map[string][]byte
+}
+
+//
+// copyDocument copies a C++ ParsedDocument into a go Document.
+// metadata values are stored with a trailing null, which is dropped.
+//
+func copyDocument(arg1 uintptr) (_swig_ret Document) {
+    _swig_i_0 := C.uintptr_t(arg1)
+    var n C.swig_intgo
+
+    p := C.indri_go_parsed_document_text(_swig_i_0, &n)
+    _swig_ret.Text = C.GoStringN(p, C.int(n))
+    p = C.indri_go_parsed_document_content(_swig_i_0, &n)
+    _swig_ret.Content = C.GoStringN(p, C.int(n))
+
+    n = C.indri_go_parsed_document_terms_size(_swig_i_0)
+    _swig_ret.Terms = make([]string, int(n))
+    for i := range _swig_ret.Terms {
+        if t := C.indri_go_parsed_document_term(_swig_i_0, C.swig_intgo(i)); t != nil {
+            _swig_ret.Terms[i] = C.GoString(t)
+        }
+    }
+
+    n = C.indri_go_parsed_document_positions_size(_swig_i_0)
+    _swig_ret.Positions = make([]TermExtent, int(n))
+    if n > 0 {
+        extents := make([]C.swig_intgo, 2 * int(n))
+        C.indri_go_parsed_document_positions(_swig_i_0, &extents[0])
+        for i := range _swig_ret.Positions {
+            _swig_ret.Positions[i] = TermExtent{Begin: int(extents[2*i]), End: int(extents[2*i+1])}
+        }
+    }
+
+    n = C.indri_go_parsed_document_metadata_size(_swig_i_0)
+    _swig_ret.Metadata = make(map[string][]byte, int(n))
+    for i := 0; i < int(n); i++ {
+        var value unsafe.Pointer
+        var length C.swig_intgo
+        key := C.indri_go_parsed_document_metadata(_swig_i_0, C.swig_intgo(i), &value, &length)
+        v := C.GoBytes(value, C.int(length))
+        if len(v) > 0 && v[len(v)-1] == 0 {
+            v = v[:len(v)-1]
+        }
+        _swig_ret.Metadata[C.GoString(key)] = v
+    }
+    return
+}
+
+//
+// takeDocuments copies and then frees a C++ ParsedDocument vector, including
+// the documents, returned by value.
+//
+func takeDocuments(arg1 Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) (_swig_ret []Document) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    defer C.indri_go_parsed_documents_delete(_swig_i_0)
+    n := int(C.indri_go_parsed_documents_size(_swig_i_0))
+    _swig_ret = make([]Document, n)
+    for i := range _swig_ret {
+        _swig_ret[i] = copyDocument(uintptr(C.indri_go_parsed_documents_get(_swig_i_0, C.swig_intgo(i))))
+    }
+    return
+}
+
+%}
+
+#endif
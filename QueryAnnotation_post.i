@@ -0,0 +1,154 @@
+#ifdef SWIGGO
+
+//
+// read a C++ QueryAnnotation from go. the annotation is allocated for the
+// caller and owns its query tree, annotations and results, so everything
+// is copied before the annotation is freed.
+//
+%{
+extern "C" {
+
+intgo indri_go_annotation_node_children_size( indri::api::QueryAnnotationNode* node ) {
+  return (intgo) node->children.size();
+}
+
+void indri_go_annotation_node_children( indri::api::QueryAnnotationNode* node, indri::api::QueryAnnotationNode** out ) {
+  for( size_t i=0; i<node->children.size(); i++ )
+    out[i] = node->children[i];
+}
+
+intgo indri_go_annotations_size( std::map< std::string, std::vector<indri::api::ScoredExtentResult> >* annotations ) {
+  return (intgo) annotations->size();
+}
+
+// the keys point into annotations, copy them before delete
+void indri_go_annotations_entries( std::map< std::string, std::vector<indri::api::ScoredExtentResult> >* annotations, _gostring_* keys, std::vector<indri::api::ScoredExtentResult>** values ) {
+  std::map< std::string, std::vector<indri::api::ScoredExtentResult> >::iterator iter;
+  size_t i = 0;
+  for( iter = annotations->begin(); iter != annotations->end(); iter++, i++ ) {
+    keys[i].p = (char*) iter->first.data();
+    keys[i].n = iter->first.length();
+    values[i] = &iter->second;
+  }
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+extern swig_intgo indri_go_annotation_node_children_size(uintptr_t arg1);
+extern void indri_go_annotation_node_children(uintptr_t arg1, uintptr_t *arg2);
+extern swig_intgo indri_go_annotations_size(uintptr_t arg1);
+extern void indri_go_annotations_entries(uintptr_t arg1, _gostring_ *arg2, uintptr_t *arg3);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend QueryAnnotation.i and QueryAnnotationNode.i
+//
+
+// ScoredExtent is an extent matched by a query node
+type ScoredExtent = ScoredResult
+
+//
+// AnnotationNode is a go copy of an indri::api::QueryAnnotationNode. Name
+// is the key of the node's matches in Annotation.Matches, Type is the
+// query node class, e.g. IndexTerm or WeightedSumNode.
+//
+type AnnotationNode struct {
+    Name string
+    Type string
+    QueryText string
+    Children []*AnnotationNode
+}
+
+//
+// Walk calls fn for n and then for each of its descendants, depth first.
+// fn returns false to skip the children of a node.
+//
+func (n *AnnotationNode) Walk(fn func(node *AnnotationNode) bool) {
+    if n == nil || !fn(n) {
+        return
+    }
+    for _, c := range n.Children {
+        c.Walk(fn)
+    }
+}
+
+//
+// Annotation is a go copy of an indri::api::QueryAnnotation. Matches maps
+// the Name of each query node to the extents it matched in the result
+// documents.
+//
+type Annotation struct {
+    QueryTree *AnnotationNode
+    Matches map[string][]ScoredExtent
+    Results []ScoredResult
+}
+
+//
+// NodeMatches returns the extents matched by node in document.
+//
+func (a Annotation) NodeMatches(node *AnnotationNode, document int) (_swig_ret []ScoredExtent) {
+    for _, m := range a.Matches[node.Name] {
+        if m.Document == document {
+            _swig_ret = append(_swig_ret, m)
+        }
+    }
+    return
+}
+
+func copyAnnotationNode(arg1 QueryAnnotationNode) *AnnotationNode {
+    if arg1 == nil || arg1.Swigcptr() == 0 {
+        return nil
+    }
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    node := &AnnotationNode{
+        Name: arg1.GetName(),
+        Type: arg1.GetXtype(),
+        QueryText: arg1.GetQueryText(),
+    }
+    n := int(C.indri_go_annotation_node_children_size(_swig_i_0))
+    node.Children = make([]*AnnotationNode, n)
+    if n > 0 {
+        children := make([]C.uintptr_t, n)
+        C.indri_go_annotation_node_children(_swig_i_0, &children[0])
+        for i, c := range children {
+            node.Children[i] = copyAnnotationNode(SwigcptrQueryAnnotationNode(c))
+        }
+    }
+    return node
+}
+
+func copyAnnotations(arg1 Std_map_Sl_std_string_Sc_std_vector_Sl_indri_api_ScoredExtentResult_Sg__Sg_) (_swig_ret map[string][]ScoredExtent) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    n := int(C.indri_go_annotations_size(_swig_i_0))
+    _swig_ret = make(map[string][]ScoredExtent, n)
+    if n > 0 {
+        keys := make([]C._gostring_, n)
+        values := make([]C.uintptr_t, n)
+        C.indri_go_annotations_entries(_swig_i_0, &keys[0], &values[0])
+        for i := range keys {
+            key := C.GoStringN(keys[i].p, C.int(keys[i].n))
+            _swig_ret[key] = copyScoredResults(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(values[i]))
+        }
+    }
+    return
+}
+
+//
+// takeAnnotation copies and then frees a C++ QueryAnnotation returned by
+// runAnnotatedQuery.
+//
+func takeAnnotation(arg1 QueryAnnotation) (_swig_ret Annotation) {
+    defer DeleteQueryAnnotation(arg1)
+    _swig_ret.QueryTree = copyAnnotationNode(arg1.GetQueryTree())
+    _swig_ret.Matches = copyAnnotations(arg1.GetAnnotations())
+    _swig_ret.Results = copyScoredResults(arg1.GetResults())
+    return
+}
+
+%}
+
+#endif
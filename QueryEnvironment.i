@@ -36,6 +36,16 @@ namespace indri {
 
     setEx(QueryEnvironment::documentsdocids);
     setEx(QueryEnvironment::documentMetadatadocids);
+
+    setEx(QueryEnvironment::setFormulationParameters);
+    setEx(QueryEnvironment::reformulateQuery);
+    setEx(QueryEnvironment::stemTerm);
+    setEx(QueryEnvironment::termCountUnique);
+    setEx(QueryEnvironment::stemCount);
+    setEx(QueryEnvironment::stemFieldCount);
+    setEx(QueryEnvironment::documentStemCount);
+    setEx(QueryEnvironment::onetermCount);
+    setEx(QueryEnvironment::onedocumentCount);
 #endif
 
     class QueryEnvironment {
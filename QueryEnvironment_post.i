@@ -0,0 +1,614 @@
+#ifdef SWIGGO
+
+%{
+//
+// the repositories QueryEnvironment opens for its indexes, in the order
+// added, are private too, see indri_go_repository_of.
+//
+struct indri_go_repositories_tag {
+  typedef std::vector<indri::collection::Repository*> indri::api::QueryEnvironment::*type;
+  friend type indri_go_repository_member( indri_go_repositories_tag );
+};
+
+template struct indri_go_repository_of<indri_go_repositories_tag, &indri::api::QueryEnvironment::_repositories>;
+
+extern "C" {
+
+//
+// 1 when docid is a document of the index-th repository of env that is not
+// deleted, 0 for a deleted document or an id the repository never had.
+//
+intgo indri_go_query_environment_document_live( indri::api::QueryEnvironment* env, intgo index, intgo docid ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    if( index < 0 || index >= (intgo) repositories.size() )
+      return 0;
+    indri::collection::Repository* repository = repositories[index];
+    return repository->collection()->exists( (lemur::api::DOCID_T) docid ) && !repository->deletedList().isDeleted( (lemur::api::DOCID_T) docid );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+extern swig_intgo indri_go_query_environment_document_live(uintptr_t arg1, swig_intgo arg2, swig_intgo arg3);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend QueryEnvironment.i
+//
+type QueryEnvironment interface {
+    Wrapped_QueryEnvironment
+
+    AddServer(arg2 string) (err error)
+    AddIndex(arg2 string) (err error)
+    RemoveServer(arg2 string) (err error)
+    RemoveIndex(arg2 string) (err error)
+    Close() (err error)
+    SetMemory(arg2 int64) (err error)
+    SetScoringRules(arg2 []string) (err error)
+    SetStopwords(arg2 []string) (err error)
+    RunQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+    RunQueryRequest(arg2 QueryRequest) (_swig_ret QueryResults, err error)
+    RunAnnotatedQuery(a ...interface{}) (_swig_ret Annotation, err error)
+    RunQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret []ScoredResult, err error)
+    RunAnnotatedQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret Annotation, err error)
+    Documents(a ...interface{}) (_swig_ret []Document, err error)
+    DocumentMetadata(a ...interface{}) (_swig_ret []string, err error)
+    DocumentIDsFromMetadata(arg2 string, arg3 []string) (_swig_ret []int, err error)
+    DocumentsFromMetadata(arg2 string, arg3 []string) (_swig_ret []Document, err error)
+    TermCount(a ...interface{}) (_swig_ret int64, err error)
+    TermFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error)
+    FieldList() (_swig_ret []string, err error)
+    DocumentCount(a ...interface{}) (_swig_ret int64, err error)
+    DocumentVectors(arg2 []int) (_swig_ret []DocumentVector, err error)
+    ExpressionCount(a ...interface{}) (_swig_ret float64, err error)
+    DocumentExpressionCount(a ...interface{}) (_swig_ret float64, err error)
+    ExpressionList(a ...interface{}) (_swig_ret []ScoredResult, err error)
+    DocumentLength(arg2 int) (_swig_ret int, err error)
+    SetFormulationParameters(arg2 Wrapped_Parameters) (err error)
+    ReformulateQuery(arg2 string) (_swig_ret string, err error)
+    StemTerm(arg2 string) (_swig_ret string, err error)
+    TermCountUnique() (_swig_ret int64, err error)
+    StemCount(arg2 string) (_swig_ret int64, err error)
+    StemFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error)
+    DocumentStemCount(arg2 string) (_swig_ret int64, err error)
+    Documentsdocids(arg2 []int) (_swig_ret []Document, err error)
+    DocumentMetadatadocids(arg2 []int, arg3 string) (_swig_ret []string, err error)
+    OnetermCount(arg2 string) (_swig_ret int64, err error)
+    OnedocumentCount(arg2 string) (_swig_ret int64, err error)
+    Search(arg2 string, arg3 int, arg4 int) (_swig_ret SearchPage, err error)
+    SearchCursor(arg2 string) (_swig_ret SearchPage, err error)
+    Generation() uint64
+    DocumentExists(arg2 int) (_swig_ret bool, err error)
+}
+
+//
+// queryEnvironment overrides the methods adding and removing indexes and
+// servers to remember them, as indri does not give them back, see
+// IndexStats, and the generation of each index as it was opened, see
+// SearchCursor.
+//
+type queryEnvironment struct {
+    SwigcptrWrapped_QueryEnvironment
+
+    mu sync.Mutex
+    indexes []string
+    servers []string
+    generations map[string]uint64
+}
+
+func NewQueryEnvironment() QueryEnvironment {
+    return &queryEnvironment{SwigcptrWrapped_QueryEnvironment: SwigcptrWrapped_QueryEnvironment(C._wrap_new_Wrapped_QueryEnvironment_indri_go_add17ee78870902e())}
+}
+
+func DeleteQueryEnvironment(arg1 QueryEnvironment) {
+    DeleteWrapped_QueryEnvironment(arg1)
+}
+
+func (e *queryEnvironment) AddServer(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_addServer(arg2)
+    e.mu.Lock()
+    e.servers = append(e.servers, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) AddIndex(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_addIndex(arg2)
+    generation := repositoryGeneration(arg2)
+    e.mu.Lock()
+    e.indexes = append(e.indexes, arg2)
+    if e.generations == nil {
+        e.generations = make(map[string]uint64)
+    }
+    e.generations[arg2] = generation
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) RemoveServer(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_removeServer(arg2)
+    e.mu.Lock()
+    e.servers = removeString(e.servers, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) RemoveIndex(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_removeIndex(arg2)
+    e.mu.Lock()
+    e.indexes = removeString(e.indexes, arg2)
+    delete(e.generations, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) Close() (err error) {
+    defer catch(&err)
+    e.Wrapped_close()
+    e.mu.Lock()
+    e.indexes, e.servers, e.generations = nil, nil, nil
+    e.mu.Unlock()
+    return
+}
+
+//
+// DocumentExists reports whether arg2 is the id of a document of e that is
+// not deleted. Ids are only tied to a repository when e has a single index
+// and no server, otherwise it reports whether the document can be
+// retrieved, deleted or not.
+//
+func (e *queryEnvironment) DocumentExists(arg2 int) (_swig_ret bool, err error) {
+    defer catch(&err)
+    indexes, servers := e.sources()
+    if len(indexes) == 1 && len(servers) == 0 {
+        _swig_ret = C.indri_go_query_environment_document_live(C.uintptr_t(e.Swigcptr()), 0, C.swig_intgo(arg2)) != 0
+        return
+    }
+    documents, err := e.Documentsdocids([]int{arg2})
+    _swig_ret = err == nil && len(documents) > 0
+    return
+}
+
+// sources returns copies of the indexes and servers added to e
+func (e *queryEnvironment) sources() (indexes []string, servers []string) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    return append([]string(nil), e.indexes...), append([]string(nil), e.servers...)
+}
+
+func removeString(values []string, value string) []string {
+    for i, v := range values {
+        if v == value {
+            return append(values[:i:i], values[i+1:]...)
+        }
+    }
+    return values
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetMemory(arg2 int64) (err error) {
+    defer catch(&err)
+    e.Wrapped_setMemory(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetScoringRules(arg2 []string) (err error) {
+    defer catch(&err)
+    rules := newStringVector(arg2)
+    defer DeleteStringVector(rules)
+    e.Wrapped_setScoringRules(rules)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetStopwords(arg2 []string) (err error) {
+    defer catch(&err)
+    stopwords := newStringVector(arg2)
+    defer DeleteStringVector(stopwords)
+    e.Wrapped_setStopwords(stopwords)
+    return
+}
+
+//
+// RunQuery runs query against the open indexes and returns at most
+// resultsRequested results. An optional document set restricts the
+// query to the listed documents:
+//
+//   RunQuery(query string, resultsRequested int)
+//   RunQuery(query string, documentSet []int, resultsRequested int)
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        _swig_ret = takeScoredResults(e.Wrapped_runQuery(a[0].(string), a[1].(int)))
+        return
+    }
+    if argc == 3 {
+        return e.RunQuerydocset(a[0].(string), a[1].([]int), a[2].(int))
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// RunQueryRequest runs a query request and returns the requested page of
+// results with their snippets and metadata.
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunQueryRequest(arg2 QueryRequest) (_swig_ret QueryResults, err error) {
+    defer catch(&err)
+    request := newQueryRequest(arg2)
+    defer C.indri_go_query_request_delete(C.uintptr_t(request))
+    _swig_ret = takeQueryResults(uintptr(C.indri_go_query_request_run(C.uintptr_t(e), C.uintptr_t(request))))
+    return
+}
+
+//
+// RunAnnotatedQuery takes the same arguments as RunQuery and also returns
+// the parsed query tree and the extents matched by each of its nodes.
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunAnnotatedQuery(a ...interface{}) (_swig_ret Annotation, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        _swig_ret = takeAnnotation(e.Wrapped_runAnnotatedQuery(a[0].(string), a[1].(int)))
+        return
+    }
+    if argc == 3 {
+        return e.RunAnnotatedQuerydocset(a[0].(string), a[1].([]int), a[2].(int))
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) RunQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    docset := newIntVector(arg3)
+    defer DeleteIntVector(docset)
+    //
+    // runQuerydocset is the same C++ call as runQuery with a document set,
+    // the lemur DOCID_T vector is an opaque type in go so we use the int
+    // vector overload.
+    //
+    _swig_ret = takeScoredResults(e.Wrapped_runQuery(arg2, docset, arg4))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) RunAnnotatedQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret Annotation, err error) {
+    defer catch(&err)
+    docset := newIntVector(arg3)
+    defer DeleteIntVector(docset)
+    _swig_ret = takeAnnotation(e.Wrapped_runAnnotatedQuery(arg2, docset, arg4))
+    return
+}
+
+//
+// Documents fetches the parsed documents for a list of document ids, or
+// for the documents of a result list:
+//
+//   Documents(documentIDs []int)
+//   Documents(results []ScoredResult)
+//
+func (e SwigcptrWrapped_QueryEnvironment) Documents(a ...interface{}) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        switch arg2 := a[0].(type) {
+        case []int:
+            ids := newIntVector(arg2)
+            defer DeleteIntVector(ids)
+            _swig_ret = takeDocuments(e.Wrapped_documents(ids))
+            return
+        case []ScoredResult:
+            results := newScoredResultVector(arg2)
+            defer deleteScoredResultVector(results)
+            _swig_ret = takeDocuments(e.Wrapped_documents(results))
+            return
+        }
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// DocumentMetadata fetches one metadata field for a list of document ids,
+// or for the documents of a result list:
+//
+//   DocumentMetadata(documentIDs []int, attributeName string)
+//   DocumentMetadata(results []ScoredResult, attributeName string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) DocumentMetadata(a ...interface{}) (_swig_ret []string, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        switch arg2 := a[0].(type) {
+        case []int:
+            ids := newIntVector(arg2)
+            defer DeleteIntVector(ids)
+            _swig_ret = takeStrings(e.Wrapped_documentMetadata(ids, a[1].(string)))
+            return
+        case []ScoredResult:
+            results := newScoredResultVector(arg2)
+            defer deleteScoredResultVector(results)
+            _swig_ret = takeStrings(e.Wrapped_documentMetadata(results, a[1].(string)))
+            return
+        }
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentIDsFromMetadata(arg2 string, arg3 []string) (_swig_ret []int, err error) {
+    defer catch(&err)
+    values := newStringVector(arg3)
+    defer DeleteStringVector(values)
+    _swig_ret = takeInts(e.Wrapped_documentIDsFromMetadata(arg2, values))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentsFromMetadata(arg2 string, arg3 []string) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    values := newStringVector(arg3)
+    defer DeleteStringVector(values)
+    _swig_ret = takeDocuments(e.Wrapped_documentsFromMetadata(arg2, values))
+    return
+}
+
+//
+// TermCount returns the total number of terms in the collection, or the
+// number of occurrences of a term:
+//
+//   TermCount()
+//   TermCount(term string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) TermCount(a ...interface{}) (_swig_ret int64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 0 {
+        _swig_ret = e.Wrapped_termCount()
+        return
+    }
+    if argc == 1 {
+        _swig_ret = e.Wrapped_termCount(a[0].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) TermFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_termFieldCount(arg2, arg3)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) FieldList() (_swig_ret []string, err error) {
+    defer catch(&err)
+    _swig_ret = takeStrings(e.Wrapped_fieldList())
+    return
+}
+
+//
+// DocumentCount returns the number of documents in the collection, or the
+// number of documents containing a term:
+//
+//   DocumentCount()
+//   DocumentCount(term string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) DocumentCount(a ...interface{}) (_swig_ret int64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 0 {
+        _swig_ret = e.Wrapped_documentCount()
+        return
+    }
+    if argc == 1 {
+        _swig_ret = e.Wrapped_documentCount(a[0].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentVectors(arg2 []int) (_swig_ret []DocumentVector, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeDocumentVectors(e.Wrapped_documentVectors(ids))
+    return
+}
+
+//
+// ExpressionCount returns the number of matches of a query expression:
+//
+//   ExpressionCount(expression string)
+//   ExpressionCount(expression string, queryType string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) ExpressionCount(a ...interface{}) (_swig_ret float64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = e.Wrapped_expressionCount(a[0].(string))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = e.Wrapped_expressionCount(a[0].(string), a[1].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+// see ExpressionCount
+func (e SwigcptrWrapped_QueryEnvironment) DocumentExpressionCount(a ...interface{}) (_swig_ret float64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = e.Wrapped_documentExpressionCount(a[0].(string))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = e.Wrapped_documentExpressionCount(a[0].(string), a[1].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// ExpressionList returns the matches of a query expression:
+//
+//   ExpressionList(expression string)
+//   ExpressionList(expression string, queryType string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) ExpressionList(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = takeScoredResults(e.Wrapped_expressionList(a[0].(string)))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = takeScoredResults(e.Wrapped_expressionList(a[0].(string), a[1].(string)))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentLength(arg2 int) (_swig_ret int, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_documentLength(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetFormulationParameters(arg2 Wrapped_Parameters) (err error) {
+    defer catch(&err)
+    e.Wrapped_setFormulationParameters(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) ReformulateQuery(arg2 string) (_swig_ret string, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_reformulateQuery(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemTerm(arg2 string) (_swig_ret string, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemTerm(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) TermCountUnique() (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_termCountUnique()
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemFieldCount(arg2, arg3)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentStemCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_documentStemCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) Documentsdocids(arg2 []int) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeDocuments(e.Wrapped_documentsdocids(docidVector(ids)))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentMetadatadocids(arg2 []int, arg3 string) (_swig_ret []string, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeStrings(e.Wrapped_documentMetadatadocids(docidVector(ids), arg3))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) OnetermCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_onetermCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) OnedocumentCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_onedocumentCount(arg2)
+    return
+}
+
+//
+// newIntVector copies a go int slice into a new C++ int vector, the caller
+// must DeleteIntVector it when done.
+//
+func newIntVector(arg1 []int) IntVector {
+    v := NewIntVector()
+    v.Reserve(int64(len(arg1)))
+    for _, i := range arg1 {
+        v.Add(i)
+    }
+    return v
+}
+
+//
+// docidVector views an int vector as a lemur DOCID_T vector, DOCID_T is a
+// typedef of int so the two C++ types share a layout.
+//
+func docidVector(arg1 IntVector) Std_vector_Sl_lemur_api_DOCID_T_Sg_ {
+    return SwigcptrStd_vector_Sl_lemur_api_DOCID_T_Sg_(arg1.Swigcptr())
+}
+
+// takeInts copies and then frees a C++ int vector returned by value.
+func takeInts(arg1 IntVector) (_swig_ret []int) {
+    defer DeleteIntVector(arg1)
+    n := int(arg1.Size())
+    _swig_ret = make([]int, n)
+    for i := 0; i < n; i++ {
+        _swig_ret[i] = arg1.Get(i)
+    }
+    return
+}
+
+//
+// newStringVector copies a go string slice into a new C++ string vector, the
+// caller must DeleteStringVector it when done.
+//
+func newStringVector(arg1 []string) StringVector {
+    v := NewStringVector()
+    v.Reserve(int64(len(arg1)))
+    for _, s := range arg1 {
+        v.Add(s)
+    }
+    return v
+}
+
+// takeStrings copies and then frees a C++ string vector returned by value.
+func takeStrings(arg1 StringVector) (_swig_ret []string) {
+    defer DeleteStringVector(arg1)
+    n := int(arg1.Size())
+    _swig_ret = make([]string, n)
+    for i := 0; i < n; i++ {
+        _swig_ret[i] = arg1.Get(i)
+    }
+    return
+}
+
+%}
+
+#endif
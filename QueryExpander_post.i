@@ -0,0 +1,58 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  extend QueryExpander.i
+//
+type RMExpander interface {
+    Wrapped_RMExpander
+
+    RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+}
+
+func NewRMExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) RMExpander {
+    return (RMExpander)(SwigcptrWrapped_RMExpander(C._wrap_new_Wrapped_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(arg1.Swigcptr()), C.uintptr_t(arg2.Swigcptr()))))
+}
+
+func DeleteRMExpander(arg1 RMExpander) {
+    DeleteWrapped_RMExpander(arg1)
+}
+
+//
+// RunExpandedQuery runs the original query, expands it from the top
+// results and runs the expanded query:
+//
+//   RunExpandedQuery(originalQuery string, resultsRequested int)
+//   RunExpandedQuery(originalQuery string, resultsRequested int, verbose bool)
+//
+func (e SwigcptrWrapped_RMExpander) RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    _swig_ret = takeScoredResults(e.Wrapped_runExpandedQuery(a...))
+    return
+}
+
+type PonteExpander interface {
+    Wrapped_PonteExpander
+
+    RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+}
+
+func NewPonteExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) PonteExpander {
+    return (PonteExpander)(SwigcptrWrapped_PonteExpander(C._wrap_new_Wrapped_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(arg1.Swigcptr()), C.uintptr_t(arg2.Swigcptr()))))
+}
+
+func DeletePonteExpander(arg1 PonteExpander) {
+    DeleteWrapped_PonteExpander(arg1)
+}
+
+// see RMExpander RunExpandedQuery
+func (e SwigcptrWrapped_PonteExpander) RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    _swig_ret = takeScoredResults(e.Wrapped_runExpandedQuery(a...))
+    return
+}
+
+%}
+
+#endif
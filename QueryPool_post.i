@@ -0,0 +1,312 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  pool of QueryEnvironments for concurrent queries
+//
+
+//
+// QueryPoolStats is a snapshot of a QueryPool. WaitTime and HoldTime are
+// totals over all Acquires, see AverageWait and AverageHold.
+//
+type QueryPoolStats struct {
+    Size int
+    InUse int
+    Waiters int
+    Acquires int64
+    Errors int64
+    Reopens int64
+    WaitTime time.Duration
+    HoldTime time.Duration
+}
+
+// AverageWait is the mean time Acquire waited for an environment
+func (s QueryPoolStats) AverageWait() time.Duration {
+    if s.Acquires == 0 {
+        return 0
+    }
+    return s.WaitTime / time.Duration(s.Acquires)
+}
+
+// AverageHold is the mean time an environment was held before Release
+func (s QueryPoolStats) AverageHold() time.Duration {
+    released := s.Acquires - int64(s.InUse)
+    if released <= 0 {
+        return 0
+    }
+    return s.HoldTime / time.Duration(released)
+}
+
+//
+// QueryPool holds size QueryEnvironments over the same indexes and servers.
+// A QueryEnvironment is used by one goroutine at a time, between Acquire
+// and Release.
+//
+type QueryPool struct {
+    indexes []string
+    servers []string
+
+    // idle holds size slots, a nil slot is an environment to reopen
+    idle chan QueryEnvironment
+    done chan struct{}
+
+    mu sync.Mutex
+    closed bool
+    acquired map[uintptr]time.Time
+    stats QueryPoolStats
+
+    // generation counts the Refreshes, generations holds the generation
+    // each open environment was opened in
+    generation int64
+    generations map[uintptr]int64
+}
+
+//
+// NewQueryPool opens size QueryEnvironments, each with every index and
+// server added. It fails if any of them cannot be opened.
+//
+func NewQueryPool(size int, indexes []string, servers []string) (*QueryPool, error) {
+    if size < 1 {
+        return nil, fmt.Errorf("query pool size %v is less than 1", size)
+    }
+    p := &QueryPool{
+        indexes: indexes,
+        servers: servers,
+        idle: make(chan QueryEnvironment, size),
+        done: make(chan struct{}),
+        acquired: make(map[uintptr]time.Time),
+        generations: make(map[uintptr]int64),
+    }
+    p.stats.Size = size
+    for i := 0; i < size; i++ {
+        env, err := p.open()
+        if err != nil {
+            p.Close()
+            return nil, err
+        }
+        p.idle <- env
+    }
+    return p, nil
+}
+
+func (p *QueryPool) open() (env QueryEnvironment, err error) {
+    p.mu.Lock()
+    generation := p.generation
+    p.mu.Unlock()
+
+    env = NewQueryEnvironment()
+    for _, index := range p.indexes {
+        if err = env.AddIndex(index); err != nil {
+            break
+        }
+    }
+    for _, server := range p.servers {
+        if err != nil {
+            break
+        }
+        err = env.AddServer(server)
+    }
+    if err != nil {
+        closeQueryEnvironment(env)
+        env = nil
+        return
+    }
+    p.mu.Lock()
+    p.generations[env.Swigcptr()] = generation
+    p.mu.Unlock()
+    return
+}
+
+func closeQueryEnvironment(env QueryEnvironment) {
+    env.Close()
+    DeleteQueryEnvironment(env)
+}
+
+//
+// Acquire waits for an idle QueryEnvironment. It returns ctx.Err() if ctx
+// is done first, and ErrClosed once the pool is closed. An environment
+// that failed to reopen is reopened here, if that fails again the error
+// is returned.
+//
+func (p *QueryPool) Acquire(ctx context.Context) (env QueryEnvironment, err error) {
+    start := time.Now()
+    p.mu.Lock()
+    if p.closed {
+        p.mu.Unlock()
+        return nil, ErrClosed
+    }
+    p.stats.Waiters++
+    p.mu.Unlock()
+
+    var slot bool
+    select {
+    case env = <-p.idle:
+        slot = true
+    case <-p.done:
+        err = ErrClosed
+    case <-ctx.Done():
+        err = ctx.Err()
+    }
+
+    if slot && env == nil {
+        if env, err = p.open(); err != nil {
+            p.idle <- nil
+        } else {
+            p.mu.Lock()
+            p.stats.Reopens++
+            p.mu.Unlock()
+        }
+    }
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.stats.Waiters--
+    if err == nil && p.closed {
+        closeQueryEnvironment(env)
+        env, err = nil, ErrClosed
+    }
+    if err != nil {
+        return
+    }
+    now := time.Now()
+    p.acquired[env.Swigcptr()] = now
+    p.stats.InUse++
+    p.stats.Acquires++
+    p.stats.WaitTime += now.Sub(start)
+    return
+}
+
+//
+// Release returns env to the pool. A non nil queryErr is the error env
+// returned while it was held. When it may have left env broken, an i/o
+// error or a repository not found, env is closed and reopened so that a
+// broken connection is not reused. Other errors, such as a parse error of
+// the query itself, keep env. An environment opened before the last
+// Refresh is reopened too. Releasing a nil environment or one not acquired
+// from the pool is an error.
+//
+func (p *QueryPool) Release(env QueryEnvironment, queryErr error) error {
+    if env == nil {
+        return fmt.Errorf("QueryPool.Release of a nil environment")
+    }
+    broken := brokenEnvironment(queryErr)
+    p.mu.Lock()
+    acquired, ok := p.acquired[env.Swigcptr()]
+    if !ok {
+        p.mu.Unlock()
+        return fmt.Errorf("QueryPool.Release of an environment not acquired from the pool")
+    }
+    delete(p.acquired, env.Swigcptr())
+    p.stats.InUse--
+    p.stats.HoldTime += time.Since(acquired)
+    if queryErr != nil {
+        p.stats.Errors++
+    }
+    broken = broken || p.generations[env.Swigcptr()] != p.generation
+    closed := p.closed
+    if closed || broken {
+        delete(p.generations, env.Swigcptr())
+    }
+    p.mu.Unlock()
+
+    if closed || broken {
+        closeQueryEnvironment(env)
+        env = nil
+    }
+    if broken && !closed {
+        var err error
+        if env, err = p.open(); err == nil {
+            p.mu.Lock()
+            p.stats.Reopens++
+            p.mu.Unlock()
+        }
+    }
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        if env != nil {
+            closeQueryEnvironment(env)
+        }
+        return nil
+    }
+    // never blocks, there are only size slots
+    p.idle <- env
+    return nil
+}
+
+//
+// brokenEnvironment reports whether err, returned by a QueryEnvironment,
+// may have left it unusable: a lemur i/o error, such as a lost server
+// connection, or a repository that is no longer found.
+//
+func brokenEnvironment(err error) bool {
+    var le *LemurError
+    if !errors.As(err, &le) {
+        return false
+    }
+    return errors.Is(le.Kind, ErrIO) || errors.Is(le.Kind, ErrRepositoryNotFound)
+}
+
+//
+// Refresh has every environment of the pool reopened, so that the queries
+// run after it see the documents committed to its indexes since. Idle
+// environments are closed now and reopened by the next Acquire, held ones
+// when they are released.
+//
+func (p *QueryPool) Refresh() {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return
+    }
+    p.generation++
+    // never blocks, a slot is taken out before one is put back
+    for n := len(p.idle); n > 0; n-- {
+        select {
+        case env := <-p.idle:
+            if env != nil {
+                delete(p.generations, env.Swigcptr())
+                closeQueryEnvironment(env)
+            }
+            p.idle <- nil
+        default:
+        }
+    }
+}
+
+// Stats returns a snapshot of the pool statistics
+func (p *QueryPool) Stats() QueryPoolStats {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.stats
+}
+
+//
+// Close closes the idle environments and fails waiting Acquires.
+// Environments still held are closed when they are released.
+//
+func (p *QueryPool) Close() error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return nil
+    }
+    p.closed = true
+    close(p.done)
+    for {
+        select {
+        case env := <-p.idle:
+            if env != nil {
+                closeQueryEnvironment(env)
+            }
+        default:
+            return nil
+        }
+    }
+}
+
+%}
+
+#endif
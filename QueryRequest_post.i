@@ -0,0 +1,227 @@
+#ifdef SWIGGO
+
+//
+// build a C++ QueryRequest from go, run it and read the QueryResults back.
+// the go QueryRequest.i and QueryResults.i define no typemaps, the request
+// and the results are copied field by field here instead.
+//
+%{
+extern "C" {
+
+typedef struct indri_go_query_result {
+  _gostring_ snippet;
+  _gostring_ documentName;
+  intgo docid;
+  double score;
+  intgo begin;
+  intgo end;
+} indri_go_query_result;
+
+indri::api::QueryRequest* indri_go_query_request_new( _gostring_ query, intgo resultsRequested, intgo startNum, intgo options ) {
+  indri::api::QueryRequest* request = new indri::api::QueryRequest();
+  request->query.assign( query.p, query.n );
+  request->resultsRequested = resultsRequested;
+  request->startNum = startNum;
+  request->options = (indri::api::QueryRequest::Options) options;
+  return request;
+}
+
+void indri_go_query_request_add_formulator( indri::api::QueryRequest* request, _gostring_ formulator ) {
+  request->formulators.push_back( std::string( formulator.p, formulator.n ) );
+}
+
+void indri_go_query_request_add_metadata( indri::api::QueryRequest* request, _gostring_ field ) {
+  request->metadata.push_back( std::string( field.p, field.n ) );
+}
+
+void indri_go_query_request_delete( indri::api::QueryRequest* request ) {
+  delete request;
+}
+
+indri::api::QueryResults* indri_go_query_request_run( indri::api::QueryEnvironment* env, indri::api::QueryRequest* request ) {
+  indri::api::QueryResults* results = new indri::api::QueryResults();
+  try {
+    *results = env->runQuery( *request );
+  } catch( lemur::api::Exception& e ) {
+    delete results;
+    indri_go_lemur_exception( e );
+    return 0;
+  }
+  return results;
+}
+
+void indri_go_query_results_times( indri::api::QueryResults* results, double* parseTime, double* executeTime, double* documentsTime, intgo* estimatedMatches ) {
+  *parseTime = results->parseTime;
+  *executeTime = results->executeTime;
+  *documentsTime = results->documentsTime;
+  *estimatedMatches = results->estimatedMatches;
+}
+
+intgo indri_go_query_results_size( indri::api::QueryResults* results ) {
+  return (intgo) results->results.size();
+}
+
+// the strings in out point into results, copy them before delete
+void indri_go_query_results_get( indri::api::QueryResults* results, intgo i, indri_go_query_result* out ) {
+  indri::api::QueryResult& r = results->results[i];
+  out->snippet.p = (char*) r.snippet.data();
+  out->snippet.n = r.snippet.length();
+  out->documentName.p = (char*) r.documentName.data();
+  out->documentName.n = r.documentName.length();
+  out->docid = r.docid;
+  out->score = r.score;
+  out->begin = r.begin;
+  out->end = r.end;
+}
+
+intgo indri_go_query_results_metadata_size( indri::api::QueryResults* results, intgo i ) {
+  return (intgo) results->results[i].metadata.size();
+}
+
+void indri_go_query_results_metadata( indri::api::QueryResults* results, intgo i, intgo j, _gostring_* key, _gostring_* value ) {
+  indri::api::MetadataPair& pair = results->results[i].metadata[j];
+  key->p = (char*) pair.key.data();
+  key->n = pair.key.length();
+  value->p = (char*) pair.value.data();
+  value->n = pair.value.length();
+}
+
+void indri_go_query_results_delete( indri::api::QueryResults* results ) {
+  delete results;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+typedef struct indri_go_query_result {
+  _gostring_ snippet;
+  _gostring_ documentName;
+  intgo docid;
+  double score;
+  intgo begin;
+  intgo end;
+} indri_go_query_result;
+extern uintptr_t indri_go_query_request_new(_gostring_ arg1, swig_intgo arg2, swig_intgo arg3, swig_intgo arg4);
+extern void indri_go_query_request_add_formulator(uintptr_t arg1, _gostring_ arg2);
+extern void indri_go_query_request_add_metadata(uintptr_t arg1, _gostring_ arg2);
+extern void indri_go_query_request_delete(uintptr_t arg1);
+extern uintptr_t indri_go_query_request_run(uintptr_t arg1, uintptr_t arg2);
+extern void indri_go_query_results_times(uintptr_t arg1, double *arg2, double *arg3, double *arg4, swig_intgo *arg5);
+extern swig_intgo indri_go_query_results_size(uintptr_t arg1);
+extern void indri_go_query_results_get(uintptr_t arg1, swig_intgo arg2, indri_go_query_result *arg3);
+extern swig_intgo indri_go_query_results_metadata_size(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_query_results_metadata(uintptr_t arg1, swig_intgo arg2, swig_intgo arg3, _gostring_ *arg4, _gostring_ *arg5);
+extern void indri_go_query_results_delete(uintptr_t arg1);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend QueryRequest.i and QueryResults.i
+//
+
+// QueryRequestOptions mirrors indri::api::QueryRequest::Options
+type QueryRequestOptions int
+
+const (
+    HTMLSnippet QueryRequestOptions = 1
+    TextSnippet QueryRequestOptions = 2
+)
+
+//
+// QueryRequest is a go copy of an indri::api::QueryRequest. Metadata lists
+// the metadata fields returned with each result, StartNum is the rank of
+// the first result returned.
+//
+type QueryRequest struct {
+    Query string
+    Formulators []string
+    Metadata []string
+    ResultsRequested int
+    StartNum int
+    Options QueryRequestOptions
+}
+
+// QueryResult is a go copy of an indri::api::QueryResult
+type QueryResult struct {
+    Snippet string
+    DocumentName string
+    Docid int
+    Score float64
+    Begin int
+    End int
+    Metadata map[string]string
+}
+
+// QueryResults is a go copy of an indri::api::QueryResults
+type QueryResults struct {
+    ParseTime float64
+    ExecuteTime float64
+    DocumentsTime float64
+    EstimatedMatches int
+    Results []QueryResult
+}
+
+// gostring passes a go string to C without a copy, as swig does
+func gostring(s string) C._gostring_ {
+    return *(*C._gostring_)(unsafe.Pointer(&s))
+}
+
+func newQueryRequest(arg1 QueryRequest) uintptr {
+    request := C.indri_go_query_request_new(gostring(arg1.Query), C.swig_intgo(arg1.ResultsRequested), C.swig_intgo(arg1.StartNum), C.swig_intgo(arg1.Options))
+    for _, f := range arg1.Formulators {
+        C.indri_go_query_request_add_formulator(request, gostring(f))
+    }
+    for _, m := range arg1.Metadata {
+        C.indri_go_query_request_add_metadata(request, gostring(m))
+    }
+    if Swig_escape_always_false {
+        Swig_escape_val = arg1
+    }
+    return uintptr(request)
+}
+
+//
+// takeQueryResults copies and then frees C++ query results.
+//
+func takeQueryResults(arg1 uintptr) (_swig_ret QueryResults) {
+    _swig_i_0 := C.uintptr_t(arg1)
+    defer C.indri_go_query_results_delete(_swig_i_0)
+
+    var parseTime, executeTime, documentsTime C.double
+    var estimatedMatches C.swig_intgo
+    C.indri_go_query_results_times(_swig_i_0, &parseTime, &executeTime, &documentsTime, &estimatedMatches)
+    _swig_ret.ParseTime = float64(parseTime)
+    _swig_ret.ExecuteTime = float64(executeTime)
+    _swig_ret.DocumentsTime = float64(documentsTime)
+    _swig_ret.EstimatedMatches = int(estimatedMatches)
+
+    n := int(C.indri_go_query_results_size(_swig_i_0))
+    _swig_ret.Results = make([]QueryResult, n)
+    for i := range _swig_ret.Results {
+        var r C.indri_go_query_result
+        C.indri_go_query_results_get(_swig_i_0, C.swig_intgo(i), &r)
+        m := int(C.indri_go_query_results_metadata_size(_swig_i_0, C.swig_intgo(i)))
+        metadata := make(map[string]string, m)
+        for j := 0; j < m; j++ {
+            var key, value C._gostring_
+            C.indri_go_query_results_metadata(_swig_i_0, C.swig_intgo(i), C.swig_intgo(j), &key, &value)
+            metadata[C.GoStringN(key.p, C.int(key.n))] = C.GoStringN(value.p, C.int(value.n))
+        }
+        _swig_ret.Results[i] = QueryResult{
+            Snippet: C.GoStringN(r.snippet.p, C.int(r.snippet.n)),
+            DocumentName: C.GoStringN(r.documentName.p, C.int(r.documentName.n)),
+            Docid: int(r.docid),
+            Score: float64(r.score),
+            Begin: int(r.begin),
+            End: int(r.end),
+            Metadata: metadata,
+        }
+    }
+    return
+}
+
+%}
+
+#endif
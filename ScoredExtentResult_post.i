@@ -0,0 +1,154 @@
+#ifdef SWIGGO
+
+//
+// copy a C++ ScoredExtentResult vector into a go slice in one cgo call,
+// instead of walking the opaque swig vector pointer an element at a time.
+//
+%{
+extern "C" {
+
+typedef struct indri_go_scored_result {
+  double score;
+  intgo document;
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_scored_result;
+
+intgo indri_go_scored_results_size( std::vector<indri::api::ScoredExtentResult>* results ) {
+  return (intgo) results->size();
+}
+
+void indri_go_scored_results_copy( std::vector<indri::api::ScoredExtentResult>* results, indri_go_scored_result* out ) {
+  for( size_t i=0; i<results->size(); i++ ) {
+    const indri::api::ScoredExtentResult& r = (*results)[i];
+    out[i].score = r.score;
+    out[i].document = r.document;
+    out[i].begin = r.begin;
+    out[i].end = r.end;
+    out[i].number = r.number;
+    out[i].ordinal = r.ordinal;
+    out[i].parentOrdinal = r.parentOrdinal;
+  }
+}
+
+std::vector<indri::api::ScoredExtentResult>* indri_go_scored_results_new( const indri_go_scored_result* in, intgo n ) {
+  std::vector<indri::api::ScoredExtentResult>* results = new std::vector<indri::api::ScoredExtentResult>( n );
+  for( intgo i=0; i<n; i++ ) {
+    indri::api::ScoredExtentResult& r = (*results)[i];
+    r.score = in[i].score;
+    r.document = in[i].document;
+    r.begin = in[i].begin;
+    r.end = in[i].end;
+    r.number = in[i].number;
+    r.ordinal = in[i].ordinal;
+    r.parentOrdinal = in[i].parentOrdinal;
+  }
+  return results;
+}
+
+void indri_go_scored_results_delete( std::vector<indri::api::ScoredExtentResult>* results ) {
+  delete results;
+}
+
+}
+%}
+
+%insert(cgo_comment_typedefs) %{
+typedef struct indri_go_scored_result {
+  double score;
+  intgo document;
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_scored_result;
+extern swig_intgo indri_go_scored_results_size(uintptr_t arg1);
+extern void indri_go_scored_results_copy(uintptr_t arg1, indri_go_scored_result *arg2);
+extern uintptr_t indri_go_scored_results_new(indri_go_scored_result *arg1, swig_intgo arg2);
+extern void indri_go_scored_results_delete(uintptr_t arg1);
+%}
+
+%insert(go_wrapper) %{
+
+//
+//  extend ScoredExtentResult
+//
+
+// ScoredResult is a go copy of an indri::api::ScoredExtentResult
+type ScoredResult struct {
+    Score float64
+    Document int
+    Begin int
+    End int
+    Number int64
+    Ordinal int
+    ParentOrdinal int
+}
+
+//
+// copyScoredResults copies the C++ result vector into a go slice. the vector
+// itself is left alone, use takeScoredResults for vectors returned by value.
+//
+func copyScoredResults(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret []ScoredResult) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    n := int(C.indri_go_scored_results_size(_swig_i_0))
+    _swig_ret = make([]ScoredResult, n)
+    if n == 0 {
+        return
+    }
+    r := make([]C.indri_go_scored_result, n)
+    C.indri_go_scored_results_copy(_swig_i_0, &r[0])
+    for i := range r {
+        _swig_ret[i] = ScoredResult{
+            Score: float64(r[i].score),
+            Document: int(r[i].document),
+            Begin: int(r[i].begin),
+            End: int(r[i].end),
+            Number: int64(r[i].number),
+            Ordinal: int(r[i].ordinal),
+            ParentOrdinal: int(r[i].parentOrdinal),
+        }
+    }
+    return
+}
+
+//
+// newScoredResultVector copies a go slice into a new C++ result vector, the
+// caller must deleteScoredResultVector it when done.
+//
+func newScoredResultVector(arg1 []ScoredResult) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+    var r []C.indri_go_scored_result = make([]C.indri_go_scored_result, len(arg1) + 1)
+    for i, sr := range arg1 {
+        r[i] = C.indri_go_scored_result{
+            score: C.double(sr.Score),
+            document: C.swig_intgo(sr.Document),
+            begin: C.swig_intgo(sr.Begin),
+            end: C.swig_intgo(sr.End),
+            number: C.longlong(sr.Number),
+            ordinal: C.swig_intgo(sr.Ordinal),
+            parentOrdinal: C.swig_intgo(sr.ParentOrdinal),
+        }
+    }
+    return SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C.indri_go_scored_results_new(&r[0], C.swig_intgo(len(arg1))))
+}
+
+func deleteScoredResultVector(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+    C.indri_go_scored_results_delete(C.uintptr_t(arg1.Swigcptr()))
+}
+
+//
+// takeScoredResults copies and then frees a result vector that swig
+// allocated to hold a C++ return by value.
+//
+func takeScoredResults(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) []ScoredResult {
+    defer deleteScoredResultVector(arg1)
+    return copyScoredResults(arg1)
+}
+
+%}
+
+#endif
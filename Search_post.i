@@ -0,0 +1,243 @@
+#ifdef SWIGGO
+
+%insert(go_wrapper) %{
+
+//
+//  paged search
+//
+
+var (
+    // ErrInvalidCursor is returned by SearchCursor for a token it did not make
+    ErrInvalidCursor = errors.New("indri_go: invalid search cursor")
+    // ErrStaleCursor is returned by SearchCursor once the collection changed
+    ErrStaleCursor = errors.New("indri_go: stale search cursor")
+)
+
+//
+// SearchPage is a page of ranked results. Total is the number of matching
+// documents, exact when TotalExact and otherwise an estimate. Next and Prev
+// are cursor tokens for the neighbouring pages, empty when there is none.
+//
+type SearchPage struct {
+    Results []QueryResult
+    Offset int
+    Limit int
+    Total int
+    TotalExact bool
+    Next string
+    Prev string
+}
+
+//
+// searchCursor is the content of a cursor token, the page to run and the
+// document count and generation of the collection it was made for.
+//
+type searchCursor struct {
+    Query string `json:"q"`
+    Offset int `json:"o"`
+    Limit int `json:"l"`
+    Documents int64 `json:"n"`
+    Generation uint64 `json:"g,omitempty"`
+}
+
+//
+// searchCursorKey signs the cursor tokens of this process, so that a
+// client cannot rewrite the offset or limit of a page it was given. Tokens
+// are only valid in the process that made them.
+//
+var searchCursorKey = func() []byte {
+    key := make([]byte, 32)
+    if _, err := rand.Read(key); err != nil {
+        panic(fmt.Sprintf("indri_go: no random search cursor key: %v", err))
+    }
+    return key
+}()
+
+func searchCursorMAC(payload []byte) []byte {
+    mac := hmac.New(sha256.New, searchCursorKey)
+    mac.Write(payload)
+    return mac.Sum(nil)
+}
+
+// token is the json of c and its signature, each base64 encoded and joined by a '.'
+func (c searchCursor) token() string {
+    b, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(b) + "." + base64.RawURLEncoding.EncodeToString(searchCursorMAC(b))
+}
+
+func parseSearchCursor(token string) (c searchCursor, err error) {
+    var b, sig []byte
+    payload, signature := token, ""
+    if i := strings.IndexByte(token, '.'); i >= 0 {
+        payload, signature = token[:i], token[i+1:]
+    }
+    b, err = base64.RawURLEncoding.DecodeString(payload)
+    if err == nil {
+        sig, err = base64.RawURLEncoding.DecodeString(signature)
+    }
+    if err == nil && !hmac.Equal(sig, searchCursorMAC(b)) {
+        err = ErrInvalidCursor
+    }
+    if err == nil {
+        err = json.Unmarshal(b, &c)
+    }
+    if err != nil || c.Query == "" || c.Offset < 0 || c.Limit < 1 {
+        err = ErrInvalidCursor
+    }
+    return
+}
+
+//
+// Search returns the limit results of query ranked after the first offset.
+// One extra result is requested to tell whether another page follows; when
+// none does, and the page is not past the last result, Total is exact.
+// Otherwise it is the largest of the estimatedMatches of indri and the
+// number of documents holding any extent of query, see query.Extents.
+//
+func (e *queryEnvironment) Search(arg2 string, arg3 int, arg4 int) (_swig_ret SearchPage, err error) {
+    defer catch(&err)
+    documents, err := e.DocumentCount()
+    if err != nil {
+        return
+    }
+    return e.search(searchCursor{Query: arg2, Offset: arg3, Limit: arg4, Documents: documents, Generation: e.Generation()})
+}
+
+//
+// SearchCursor returns the page of a Next or Prev token of a SearchPage.
+// Tokens are signed, one that was altered or made by another process
+// returns ErrInvalidCursor. A token made before documents were added to or removed from the collection
+// returns ErrStaleCursor, since ranks may have moved. The document count
+// and the generations of the indexes, as they were opened, must both match.
+//
+func (e *queryEnvironment) SearchCursor(arg2 string) (_swig_ret SearchPage, err error) {
+    defer catch(&err)
+    c, err := parseSearchCursor(arg2)
+    if err != nil {
+        return
+    }
+    documents, err := e.DocumentCount()
+    if err != nil {
+        return
+    }
+    if documents != c.Documents || e.Generation() != c.Generation {
+        err = ErrStaleCursor
+        return
+    }
+    return e.search(c)
+}
+
+//
+// Generation identifies the collection of e as its indexes were opened,
+// combining the generations of the indexes, in their order, and the
+// servers. It changes once documents are added to or deleted from an
+// index and the index is opened again. Servers are identified by name only.
+//
+func (e *queryEnvironment) Generation() uint64 {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    h := fnv.New64a()
+    for _, index := range e.indexes {
+        fmt.Fprintf(h, "%v\x00%v\x00", index, e.generations[index])
+    }
+    for _, server := range e.servers {
+        fmt.Fprintf(h, "%v\x00", server)
+    }
+    return h.Sum64()
+}
+
+//
+// repositoryGeneration fingerprints the state of the repository at path:
+// the names of its index directories, which indri numbers anew on every
+// flush and merge, and its deleted list. Adding documents makes a new
+// index directory and deleting them changes the deleted list, so any
+// change a reader opening the repository would see changes it.
+//
+func repositoryGeneration(path string) uint64 {
+    h := fnv.New64a()
+    if infos, err := ioutil.ReadDir(filepath.Join(path, "index")); err == nil {
+        for _, info := range infos {
+            fmt.Fprintf(h, "%v\x00", info.Name())
+        }
+    }
+    if b, err := ioutil.ReadFile(filepath.Join(path, "deleted")); err == nil {
+        h.Write(b)
+    }
+    return h.Sum64()
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) search(c searchCursor) (page SearchPage, err error) {
+    if c.Offset < 0 {
+        err = fmt.Errorf("negative search offset %v", c.Offset)
+        return
+    }
+    if c.Limit < 1 {
+        err = fmt.Errorf("search limit %v is less than 1", c.Limit)
+        return
+    }
+    results, err := e.RunQueryRequest(QueryRequest{Query: c.Query, ResultsRequested: c.Limit + 1, StartNum: c.Offset})
+    if err != nil {
+        return
+    }
+    page = SearchPage{Results: results.Results, Offset: c.Offset, Limit: c.Limit}
+    more := len(page.Results) > c.Limit
+    if more {
+        page.Results = page.Results[:c.Limit]
+    }
+    // past the last result the offset says nothing of the total
+    past := len(page.Results) == 0 && c.Offset > 0
+    if !past {
+        page.Total = c.Offset + len(page.Results)
+    }
+    if !more && !past {
+        page.TotalExact = true
+    } else {
+        if results.EstimatedMatches > page.Total {
+            page.Total = results.EstimatedMatches
+        }
+        if n, e := e.extentDocumentCount(c.Query); e == nil && n > page.Total {
+            page.Total = n
+        }
+    }
+    if more {
+        next := c
+        next.Offset += c.Limit
+        page.Next = next.token()
+    }
+    if c.Offset > 0 {
+        prev := c
+        prev.Offset -= c.Limit
+        if prev.Offset < 0 {
+            prev.Offset = 0
+        }
+        page.Prev = prev.token()
+    }
+    return
+}
+
+//
+// extentDocumentCount counts the documents holding any extent of text, an
+// upper bound of the documents a #combine of them ranks.
+//
+func (e SwigcptrWrapped_QueryEnvironment) extentDocumentCount(text string) (n int, err error) {
+    node, err := query.Parse(text)
+    if err != nil {
+        return
+    }
+    extents := query.Extents(node)
+    if len(extents) == 0 {
+        err = fmt.Errorf("query %q has no extents", text)
+        return
+    }
+    s, err := query.Render(query.Syn(extents...))
+    if err != nil {
+        return
+    }
+    count, err := e.DocumentExpressionCount(s)
+    n = int(count)
+    return
+}
+
+%}
+
+#endif
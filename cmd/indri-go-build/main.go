@@ -0,0 +1,314 @@
+//
+// indri-go-build builds an Indri repository, as IndriBuildIndex does, from
+// a parameter file and command line parameters.
+//
+//   indri-go-build data/params.xml -memory=100M -stemmer.name=krovetz
+//   indri-go-build -index=/data/index -corpus.path=/data/docs -corpus.class=trectext
+//
+// Parameters are the IndexConfig.Set keys, given after the file they
+// override it. A corpus.path given on the command line replaces the
+// corpora of the file, and the corpus keys after it apply to that corpus.
+// The options of indri-go-build itself are
+//
+//   -report=FILE   write a JSON build report to FILE, - for stdout
+//   -include=PAT   only index corpus files matching PAT, repeatable
+//   -exclude=PAT   never index corpus files matching PAT, repeatable
+//   -quiet         only print errors and the final counts
+//
+// The exit status tells what failed:
+//
+//   0   the repository was built
+//   1   any other error
+//   2   bad usage or parameters
+//   3   the repository is locked by another process
+//   4   an i/o error, or the repository could not be found
+//   5   the repository was built but some corpus files failed
+//   130 the build was interrupted
+//
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "os"
+    "os/signal"
+    "strings"
+    "syscall"
+    "time"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+const (
+    exitOK = 0
+    exitError = 1
+    exitUsage = 2
+    exitLocked = 3
+    exitIO = 4
+    exitFilesFailed = 5
+    exitInterrupted = 130
+)
+
+// options are the parsed command line
+type options struct {
+    paramFile string
+    overrides [][2]string
+    report string
+    include []string
+    exclude []string
+    quiet bool
+    help bool
+}
+
+//
+// parseArgs parses args, IndriBuildIndex style: -key=value parameters and
+// at most one parameter file.
+//
+func parseArgs(args []string) (opts options, err error) {
+    for _, arg := range args {
+        if !strings.HasPrefix(arg, "-") {
+            if opts.paramFile != "" {
+                return opts, fmt.Errorf("more than one parameter file: %v and %v", opts.paramFile, arg)
+            }
+            opts.paramFile = arg
+            continue
+        }
+        key, value := strings.TrimLeft(arg, "-"), ""
+        hasValue := false
+        if i := strings.IndexByte(key, '='); i >= 0 {
+            key, value, hasValue = key[:i], key[i+1:], true
+        }
+        switch key {
+        case "help", "h":
+            opts.help = true
+        case "quiet":
+            opts.quiet = true
+        case "report", "include", "exclude":
+            if !hasValue || value == "" {
+                return opts, fmt.Errorf("-%v needs a value, -%v=...", key, key)
+            }
+            switch key {
+            case "report":
+                opts.report = value
+            case "include":
+                opts.include = append(opts.include, value)
+            case "exclude":
+                opts.exclude = append(opts.exclude, value)
+            }
+        default:
+            if key == "" || !hasValue {
+                return opts, fmt.Errorf("parameter %q is not -key=value", arg)
+            }
+            opts.overrides = append(opts.overrides, [2]string{key, value})
+        }
+    }
+    return
+}
+
+//
+// config loads the parameter file of opts, or the defaults without one,
+// and applies the command line parameters.
+//
+func (opts options) config() (cfg indri_go.IndexConfig, err error) {
+    cfg = indri_go.NewIndexConfig()
+    if opts.paramFile != "" {
+        if cfg, err = indri_go.LoadIndexConfig(opts.paramFile); err != nil {
+            return
+        }
+    }
+    corpora := false
+    for _, kv := range opts.overrides {
+        if (kv[0] == "corpus" || kv[0] == "corpus.path") && !corpora {
+            cfg.Corpora, corpora = nil, true
+        }
+        if err = cfg.Set(kv[0], kv[1]); err != nil {
+            return
+        }
+    }
+    if cfg.Index == "" {
+        err = fmt.Errorf("Must specify a index parameter.")
+    } else if len(cfg.Corpora) == 0 {
+        err = fmt.Errorf("Must specify a corpus parameter.")
+    }
+    return
+}
+
+//
+// exitStatus returns the exit status of a build that returned report and
+// err, with ctx the context it ran with.
+//
+func exitStatus(ctx context.Context, report indri_go.BuildReport, err error) int {
+    var configErr *indri_go.IndexConfigError
+    switch {
+    case err == nil && len(report.FilesFailed) > 0:
+        return exitFilesFailed
+    case err == nil:
+        return exitOK
+    case ctx.Err() != nil || errors.Is(err, context.Canceled):
+        return exitInterrupted
+    case errors.As(err, &configErr), errors.Is(err, indri_go.ErrBadParameter), errors.Is(err, indri_go.ErrParse):
+        return exitUsage
+    case errors.Is(err, indri_go.ErrRepositoryLocked):
+        return exitLocked
+    case errors.Is(err, indri_go.ErrIO), errors.Is(err, indri_go.ErrRepositoryNotFound):
+        return exitIO
+    }
+    return exitError
+}
+
+//
+// progress is the IndexStatus printing the build as it goes. Document
+// counts are printed at most once a second.
+//
+type progress struct {
+    out io.Writer
+    quiet bool
+    last time.Time
+}
+
+func (p *progress) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    switch indri_go.IndexEventKind(code) {
+    case indri_go.FileOpen:
+        if !p.quiet {
+            fmt.Fprintf(p.out, "Opened %v\n", documentFile)
+        }
+    case indri_go.FileSkip:
+        if !p.quiet {
+            fmt.Fprintf(p.out, "Skipped %v\n", documentFile)
+        }
+    case indri_go.FileError:
+        fmt.Fprintf(p.out, "Error in %v : %v\n", documentFile, error)
+    case indri_go.DocumentCount:
+        if !p.quiet && time.Since(p.last) >= time.Second {
+            p.last = time.Now()
+            fmt.Fprintf(p.out, "Documents parsed: %v Documents indexed: %v\n", documentsSeen, documentsIndexed)
+        }
+    }
+}
+
+// buildFile is a BuildFile of the JSON report
+type buildFile struct {
+    Path string `json:"path"`
+    Reason string `json:"reason"`
+}
+
+// report is the JSON build report
+type report struct {
+    Index string `json:"index"`
+    Elapsed float64 `json:"elapsed"`
+    FilesSeen int `json:"filesSeen"`
+    FilesSkipped []buildFile `json:"filesSkipped"`
+    FilesFailed []buildFile `json:"filesFailed"`
+    DocumentsIndexed int `json:"documentsIndexed"`
+    DocumentsSeen int `json:"documentsSeen"`
+    Error string `json:"error,omitempty"`
+    ExitCode int `json:"exitCode"`
+}
+
+func newReport(index string, r indri_go.BuildReport, err error, code int) report {
+    files := func(fs []indri_go.BuildFile) []buildFile {
+        out := make([]buildFile, 0, len(fs))
+        for _, f := range fs {
+            out = append(out, buildFile{Path: f.Path, Reason: f.Reason})
+        }
+        return out
+    }
+    rep := report{
+        Index: index,
+        Elapsed: r.Elapsed.Seconds(),
+        FilesSeen: r.FilesSeen,
+        FilesSkipped: files(r.FilesSkipped),
+        FilesFailed: files(r.FilesFailed),
+        DocumentsIndexed: r.DocumentsIndexed,
+        DocumentsSeen: r.DocumentsSeen,
+        ExitCode: code,
+    }
+    if err != nil {
+        rep.Error = err.Error()
+    }
+    return rep
+}
+
+// writeReport writes rep as JSON to path, - for stdout
+func writeReport(path string, rep report) error {
+    b, err := json.MarshalIndent(rep, "", "  ")
+    if err != nil {
+        return err
+    }
+    b = append(b, '\n')
+    if path == "-" {
+        _, err = os.Stdout.Write(b)
+        return err
+    }
+    return ioutil.WriteFile(path, b, 0644)
+}
+
+func usage(w io.Writer) {
+    fmt.Fprintf(w, "usage: %v [params.xml] [-key=value ...] [-report=FILE] [-include=PAT] [-exclude=PAT] [-quiet]\n", os.Args[0])
+}
+
+func main() {
+    os.Exit(build(os.Args[1:]))
+}
+
+// build builds the repository of args and returns the exit status
+func build(args []string) int {
+    opts, err := parseArgs(args)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "indri-go-build: %v\n", err)
+        usage(os.Stderr)
+        return exitUsage
+    }
+    if opts.help {
+        usage(os.Stdout)
+        return exitOK
+    }
+    cfg, err := opts.config()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "indri-go-build: %v\n", err)
+        return exitUsage
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    stop := make(chan os.Signal, 1)
+    signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+    defer signal.Stop(stop)
+    go func() {
+        select {
+        case <-stop:
+            fmt.Fprintln(os.Stderr, "indri-go-build: interrupted, closing the repository")
+            cancel()
+        case <-ctx.Done():
+        }
+    }()
+
+    status := indri_go.NewDirectorIndexStatus(&progress{out: os.Stderr, quiet: opts.quiet})
+    defer indri_go.DeleteDirectorIndexStatus(status)
+
+    r, err := indri_go.BuildIndex(ctx, cfg, indri_go.BuildOptions{
+        Include: opts.include,
+        Exclude: opts.exclude,
+        Status: status,
+    })
+    code := exitStatus(ctx, r, err)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "indri-go-build: %v\n", err)
+    }
+    fmt.Fprintf(os.Stderr, "Documents parsed: %v Documents indexed: %v in %v, %v files failed\n",
+        r.DocumentsSeen, r.DocumentsIndexed, r.Elapsed.Round(time.Millisecond), len(r.FilesFailed))
+
+    if opts.report != "" {
+        if e := writeReport(opts.report, newReport(cfg.Index, r, err, code)); e != nil {
+            fmt.Fprintf(os.Stderr, "indri-go-build: writing report: %v\n", e)
+            if code == exitOK {
+                code = exitIO
+            }
+        }
+    }
+    return code
+}
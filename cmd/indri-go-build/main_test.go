@@ -0,0 +1,104 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+    "testing"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+/**
+ * Test parameters and options are parsed IndriBuildIndex style.
+**/
+func TestParseArgs(t *testing.T) {
+    err := testParseArgs()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test build outcomes map to their exit status.
+**/
+func TestExitStatus(t *testing.T) {
+    err := testExitStatus()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testParseArgs() (err error) {
+    opts, err := parseArgs([]string{"params.xml", "-memory=100M", "-corpus.path=docs", "-corpus.class=trectext",
+        "-report=-", "-include=*.txt", "-exclude=tmp/*", "-quiet"})
+    if err != nil {
+        return
+    }
+    expected := options{
+        paramFile: "params.xml",
+        overrides: [][2]string{{"memory", "100M"}, {"corpus.path", "docs"}, {"corpus.class", "trectext"}},
+        report: "-",
+        include: []string{"*.txt"},
+        exclude: []string{"tmp/*"},
+        quiet: true,
+    }
+    if !reflect.DeepEqual(opts, expected) {
+        return fmt.Errorf("parsed %+v, expected %+v", opts, expected)
+    }
+
+    for _, args := range [][]string{
+        {"a.xml", "b.xml"},
+        {"-memory"},
+        {"-=x"},
+        {"-report"},
+    } {
+        if _, e := parseArgs(args); e == nil {
+            return fmt.Errorf("parseArgs(%q) expected an error", args)
+        }
+    }
+
+    if _, e := (options{overrides: [][2]string{{"memory", "100M"}}}).config(); e == nil {
+        return fmt.Errorf("config without an index expected an error")
+    }
+    cfg, err := options{overrides: [][2]string{{"index", "repo"}, {"corpus.path", "docs"}}}.config()
+    if err != nil {
+        return
+    }
+    if cfg.Index != "repo" || len(cfg.Corpora) != 1 || cfg.Corpora[0].Path != "docs" {
+        return fmt.Errorf("config made %+v", cfg)
+    }
+    return
+}
+
+func testExitStatus() (err error) {
+    ctx := context.Background()
+    failed := indri_go.BuildReport{FilesFailed: []indri_go.BuildFile{{Path: "bad.txt", Reason: "parse"}}}
+    cancelled, cancel := context.WithCancel(ctx)
+    cancel()
+
+    cases := []struct {
+        ctx context.Context
+        report indri_go.BuildReport
+        err error
+        status int
+    }{
+        {ctx, indri_go.BuildReport{}, nil, exitOK},
+        {ctx, failed, nil, exitFilesFailed},
+        {cancelled, indri_go.BuildReport{}, context.Canceled, exitInterrupted},
+        {ctx, indri_go.BuildReport{}, &indri_go.IndexConfigError{Problems: []string{"x"}}, exitUsage},
+        {ctx, indri_go.BuildReport{}, &indri_go.LemurError{Kind: indri_go.ErrRepositoryLocked}, exitLocked},
+        {ctx, indri_go.BuildReport{}, &indri_go.LemurError{Kind: indri_go.ErrIO}, exitIO},
+        {ctx, indri_go.BuildReport{}, fmt.Errorf("other"), exitError},
+    }
+    for _, c := range cases {
+        if status := exitStatus(c.ctx, c.report, c.err); status != c.status {
+            return fmt.Errorf("exitStatus of %v is %v, expected %v", c.err, status, c.status)
+        }
+    }
+    return
+}
@@ -0,0 +1,290 @@
+//
+// indri-go-query runs queries against Indri repositories and servers, as
+// IndriRunQuery does, from parameter files and command line parameters.
+//
+//   indri-go-query -index=/data/index -count=10 -query='#combine(pizza mall)'
+//   indri-go-query queries.xml -trecFormat -runID=baseline > run.txt
+//   indri-go-query -index=/data/index -queryFile=topics.tsv -fbDocs=10 -fbTerms=20
+//
+// Parameter files hold IndriRunQuery <parameters>, with queries as
+// <query><number>1</number><text>...</text></query>. The parameters are
+//
+//   -index=PATH, -server=HOST:PORT   repeatable, what to query
+//   -query=TEXT                      repeatable, a query in the indri language
+//   -queryFile=FILE                  a query per line, number<TAB>text or text
+//   -count=N                         results per query, 1000
+//   -rule=method:dirichlet,mu:2500   repeatable, scoring rules
+//   -stopper.word=W, -memory=SIZE
+//   -fbDocs=N, -fbTerms=N, -fbMu=F, -fbOrigWeight=F
+//                                    relevance model expansion from the top
+//                                    fbDocs documents, off by default
+//   -trecFormat, -runID=NAME         TREC run output, runID indri
+//   -printDocuments, -printSnippets, -printQuery
+//
+// Results are printed as "score docno begin end", tab separated, or with
+// -trecFormat as "number Q0 docno rank score runID". Snippets come from
+// the stored documents. With -fbDocs they are the text of the extent of
+// each result, cut at 50 terms.
+//
+// The exit status is 0 when every query ran, 2 for bad usage or
+// parameters, and 1 when the repositories cannot be opened or a query
+// failed. A failed query is reported and the others still run.
+//
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+const (
+    exitOK = 0
+    exitError = 1
+    exitUsage = 2
+)
+
+// hit is a result to print
+type hit struct {
+    Docid int
+    Docno string
+    Score float64
+    Begin int
+    End int
+    Snippet string
+    Text string
+}
+
+// runner runs queries with an open QueryEnvironment
+type runner struct {
+    cfg config
+    env indri_go.QueryEnvironment
+    expander indri_go.RMExpander
+}
+
+// run returns the results of query text
+func (r *runner) run(text string) (hits []hit, err error) {
+    if r.expander != nil {
+        var results []indri_go.ScoredResult
+        if results, err = r.expander.RunExpandedQuery(text, r.cfg.Count); err != nil {
+            return
+        }
+        docids := make([]int, len(results))
+        for i, result := range results {
+            docids[i] = result.Document
+        }
+        var docnos []string
+        if docnos, err = r.env.DocumentMetadatadocids(docids, "docno"); err != nil {
+            return
+        }
+        for i, result := range results {
+            hits = append(hits, hit{Docid: result.Document, Docno: docnos[i], Score: result.Score, Begin: result.Begin, End: result.End})
+        }
+    } else {
+        request := indri_go.QueryRequest{Query: text, ResultsRequested: r.cfg.Count}
+        if r.cfg.PrintSnippets {
+            request.Options = indri_go.TextSnippet
+        }
+        var results indri_go.QueryResults
+        if results, err = r.env.RunQueryRequest(request); err != nil {
+            return
+        }
+        for _, result := range results.Results {
+            hits = append(hits, hit{Docid: result.Docid, Docno: result.DocumentName, Score: result.Score,
+                Begin: result.Begin, End: result.End, Snippet: result.Snippet})
+        }
+    }
+
+    // the expanded query runs without a QueryRequest, so without snippets
+    expandedSnippets := r.cfg.PrintSnippets && r.expander != nil
+    if (r.cfg.PrintDocuments || expandedSnippets) && len(hits) > 0 {
+        docids := make([]int, len(hits))
+        for i, h := range hits {
+            docids[i] = h.Docid
+        }
+        var documents []indri_go.Document
+        if documents, err = r.env.Documentsdocids(docids); err != nil {
+            return
+        }
+        for i := range hits {
+            if r.cfg.PrintDocuments {
+                hits[i].Text = documents[i].Text
+            }
+            if expandedSnippets {
+                hits[i].Snippet = extentSnippet(documents[i], hits[i].Begin, hits[i].End)
+            }
+        }
+    }
+    return
+}
+
+// snippetTerms is the most terms of a snippet made by extentSnippet
+const snippetTerms = 50
+
+//
+// extentSnippet is the text of the terms begin to end of doc, at most
+// snippetTerms of them, with runs of white space joined into one space. A
+// cut extent ends in "...".
+//
+func extentSnippet(doc indri_go.Document, begin, end int) string {
+    if begin < 0 {
+        begin = 0
+    }
+    if end > len(doc.Positions) {
+        end = len(doc.Positions)
+    }
+    if begin >= end {
+        return ""
+    }
+    cut := end - begin > snippetTerms
+    if cut {
+        end = begin + snippetTerms
+    }
+    from, to := doc.Positions[begin].Begin, doc.Positions[end-1].End
+    if to > len(doc.Text) {
+        to = len(doc.Text)
+    }
+    if from >= to {
+        return ""
+    }
+    s := strings.Join(strings.Fields(doc.Text[from:to]), " ")
+    if cut {
+        s += "..."
+    }
+    return s
+}
+
+//
+// printResults prints the hits of q in the IndriRunQuery formats, the
+// score as C++ streams print a double.
+//
+func printResults(w io.Writer, cfg config, q querySpec, hits []hit) {
+    if cfg.PrintQuery {
+        fmt.Fprintf(w, "# query: %v\n", q.Text)
+    }
+    for i, h := range hits {
+        if cfg.TrecFormat {
+            fmt.Fprintf(w, "%v Q0 %v %v %.6g %v\n", q.Number, h.Docno, i+1, h.Score, cfg.RunID)
+        } else {
+            fmt.Fprintf(w, "%.6g\t%v\t%v\t%v\n", h.Score, h.Docno, h.Begin, h.End)
+        }
+        if cfg.PrintDocuments {
+            fmt.Fprintln(w, h.Text)
+        }
+        if cfg.PrintSnippets {
+            fmt.Fprintln(w, strings.TrimRight(h.Snippet, "\n"))
+        }
+    }
+}
+
+//
+// open opens the indexes and servers of cfg and sets up its scoring,
+// the returned release frees them.
+//
+func open(cfg config) (r *runner, release func(), err error) {
+    env := indri_go.NewQueryEnvironment()
+    r = &runner{cfg: cfg, env: env}
+    var expanderParameters indri_go.Parameters
+    release = func() {
+        if r.expander != nil {
+            indri_go.DeleteRMExpander(r.expander)
+        }
+        if expanderParameters != nil {
+            indri_go.DeleteWrapped_Parameters(expanderParameters)
+        }
+        env.Close()
+        indri_go.DeleteQueryEnvironment(env)
+    }
+    defer func() {
+        if err != nil {
+            release()
+        }
+    }()
+
+    if cfg.Memory > 0 {
+        if err = env.SetMemory(cfg.Memory); err != nil {
+            return
+        }
+    }
+    for _, index := range cfg.Indexes {
+        if err = env.AddIndex(index); err != nil {
+            err = fmt.Errorf("index %v: %v", index, err)
+            return
+        }
+    }
+    for _, server := range cfg.Servers {
+        if err = env.AddServer(server); err != nil {
+            err = fmt.Errorf("server %v: %v", server, err)
+            return
+        }
+    }
+    if len(cfg.Rules) > 0 {
+        if err = env.SetScoringRules(cfg.Rules); err != nil {
+            return
+        }
+    }
+    if len(cfg.Stopwords) > 0 {
+        if err = env.SetStopwords(cfg.Stopwords); err != nil {
+            return
+        }
+    }
+    if cfg.FbDocs > 0 {
+        expanderParameters = indri_go.NewParameters()
+        expanderParameters.Set_int("fbDocs", cfg.FbDocs)
+        expanderParameters.Set_int("fbTerms", cfg.FbTerms)
+        expanderParameters.Set_double("fbMu", cfg.FbMu)
+        expanderParameters.Set_double("fbOrigWeight", cfg.FbOrigWeight)
+        r.expander = indri_go.NewRMExpander(env, expanderParameters)
+    }
+    return
+}
+
+func usage(w io.Writer) {
+    fmt.Fprintf(w, "usage: %v [parameters.xml ...] [-index=PATH] [-server=HOST:PORT] [-query=TEXT] [-queryFile=FILE] [-key=value ...]\n", os.Args[0])
+}
+
+func main() {
+    os.Exit(query(os.Args[1:]))
+}
+
+// query runs the queries of args and returns the exit status
+func query(args []string) int {
+    cfg, help, err := parseArgs(args)
+    if err == nil && help {
+        usage(os.Stdout)
+        return exitOK
+    }
+    if err == nil {
+        err = cfg.check()
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "indri-go-query: %v\n", err)
+        usage(os.Stderr)
+        return exitUsage
+    }
+
+    r, release, err := open(cfg)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "indri-go-query: %v\n", err)
+        return exitError
+    }
+    defer release()
+
+    out := bufio.NewWriter(os.Stdout)
+    defer out.Flush()
+    code := exitOK
+    for _, q := range cfg.Queries {
+        hits, err := r.run(q.Text)
+        if err != nil {
+            fmt.Fprintf(os.Stderr, "indri-go-query: query %v: %v\n", q.Number, err)
+            code = exitError
+            continue
+        }
+        printResults(out, cfg, q, hits)
+    }
+    return code
+}
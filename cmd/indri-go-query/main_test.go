@@ -0,0 +1,207 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+/**
+ * Test queries and parameters are read from files and the command line.
+**/
+func TestParseArgs(t *testing.T) {
+    err := testParseArgs()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test results are printed in the IndriRunQuery formats.
+**/
+func TestPrintResults(t *testing.T) {
+    err := testPrintResults()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test snippets are made from the text of a result extent.
+**/
+func TestExtentSnippet(t *testing.T) {
+    err := testExtentSnippet()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+const testParameters = `<parameters>
+    <index>/data/index</index>
+    <count>50</count>
+    <rule>method:dirichlet,mu:2500</rule>
+    <trecFormat>true</trecFormat>
+    <query>
+        <number>301</number>
+        <text>#combine(international organized crime)</text>
+    </query>
+    <query>pizza</query>
+</parameters>`
+
+func testParseArgs() (err error) {
+    dir, err := ioutil.TempDir("", "test-query-args")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    parameterFile := filepath.Join(dir, "queries.xml")
+    if err = ioutil.WriteFile(parameterFile, []byte(testParameters), 0644); err != nil {
+        return
+    }
+    queryFile := filepath.Join(dir, "topics.tsv")
+    if err = ioutil.WriteFile(queryFile, []byte("# topics\n401\tforeign minorities\n\nfood court\n#\n#combine(a b)\n402\t#od1(burlington mall)\n"), 0644); err != nil {
+        return
+    }
+
+    cfg, help, err := parseArgs([]string{parameterFile, "-server=localhost:16743", "-count=10",
+        "-queryFile=" + queryFile, "-query=#band(a b)", "-printSnippets", "-trecFormat=false", "-fbTerms=20"})
+    if err != nil {
+        return
+    }
+    expected := newConfig()
+    expected.Indexes = []string{"/data/index"}
+    expected.Servers = []string{"localhost:16743"}
+    expected.Count = 10
+    expected.Rules = []string{"method:dirichlet,mu:2500"}
+    expected.PrintSnippets = true
+    expected.FbTerms = 20
+    expected.Queries = []querySpec{
+        {"301", "#combine(international organized crime)"},
+        {"1", "pizza"},
+        {"401", "foreign minorities"},
+        {"3", "food court"},
+        {"4", "#combine(a b)"},
+        {"402", "#od1(burlington mall)"},
+        {"6", "#band(a b)"},
+    }
+    if help || !reflect.DeepEqual(cfg, expected) {
+        return fmt.Errorf("parsed %+v, expected %+v", cfg, expected)
+    }
+    if err = cfg.check(); err != nil {
+        return
+    }
+
+    for _, args := range [][]string{
+        {"-count=many"},
+        {"-count=0"},
+        {"-fbDocs=-1"},
+        {"-count"},
+        {"-nosuch=1"},
+        {"-query="},
+        {"-printQuery=maybe"},
+        {filepath.Join(dir, "missing.xml")},
+        {"-queryFile=" + filepath.Join(dir, "missing.tsv")},
+    } {
+        if _, _, e := parseArgs(args); e == nil {
+            return fmt.Errorf("parseArgs(%q) expected an error", args)
+        }
+    }
+    for _, args := range [][]string{
+        {"-query=pizza"},
+        {"-index=/data/index"},
+    } {
+        cfg, _, e := parseArgs(args)
+        if e != nil {
+            return e
+        }
+        if cfg.check() == nil {
+            return fmt.Errorf("check of %q expected an error", args)
+        }
+    }
+
+    // snippets of expanded queries are made from the result extents
+    cfg, _, err := parseArgs([]string{"-index=/data/index", "-query=pizza", "-fbDocs=10", "-printSnippets"})
+    if err != nil {
+        return
+    }
+    if err = cfg.check(); err != nil {
+        return
+    }
+    return
+}
+
+func testPrintResults() (err error) {
+    hits := []hit{
+        {Docid: 2, Docno: "q2", Score: -4.123456789, Begin: 0, End: 6, Snippet: "the food court serves pizza\n"},
+        {Docid: 1, Docno: "q1", Score: -5.5, Begin: 0, End: 9},
+    }
+    q := querySpec{Number: "301", Text: "pizza"}
+
+    cases := []struct {
+        cfg config
+        expected string
+    }{
+        {config{}, "-4.12346\tq2\t0\t6\n-5.5\tq1\t0\t9\n"},
+        {config{TrecFormat: true, RunID: "indri"}, "301 Q0 q2 1 -4.12346 indri\n301 Q0 q1 2 -5.5 indri\n"},
+        {config{TrecFormat: true, RunID: "rm3", PrintQuery: true, PrintSnippets: true},
+            "# query: pizza\n301 Q0 q2 1 -4.12346 rm3\nthe food court serves pizza\n301 Q0 q1 2 -5.5 rm3\n\n"},
+    }
+    for _, c := range cases {
+        var b bytes.Buffer
+        printResults(&b, c.cfg, q, hits)
+        if b.String() != c.expected {
+            return fmt.Errorf("printed %q, expected %q", b.String(), c.expected)
+        }
+    }
+    return
+}
+
+func testExtentSnippet() (err error) {
+    doc := indri_go.Document{Text: "<TEXT>the food\n  court serves pizza</TEXT>"}
+    for _, term := range []string{"the", "food", "court", "serves", "pizza"} {
+        var from int
+        if n := len(doc.Positions); n > 0 {
+            from = doc.Positions[n-1].End
+        }
+        begin := from + strings.Index(doc.Text[from:], term)
+        doc.Positions = append(doc.Positions, indri_go.TermExtent{Begin: begin, End: begin + len(term)})
+    }
+
+    long := indri_go.Document{}
+    for i := 0; i < snippetTerms + 10; i++ {
+        long.Positions = append(long.Positions, indri_go.TermExtent{Begin: len(long.Text), End: len(long.Text) + 1})
+        long.Text += "a "
+    }
+
+    cases := []struct {
+        doc indri_go.Document
+        begin, end int
+        expected string
+    }{
+        {doc, 0, 5, "the food court serves pizza"},
+        {doc, 1, 3, "food court"},
+        {doc, 3, 99, "serves pizza"},
+        {doc, 4, 4, ""},
+        {indri_go.Document{}, 0, 9, ""},
+        {long, 0, len(long.Positions), strings.TrimSpace(strings.Repeat("a ", snippetTerms)) + "..."},
+    }
+    for _, c := range cases {
+        if s := extentSnippet(c.doc, c.begin, c.end); s != c.expected {
+            return fmt.Errorf("extentSnippet(%v, %v) = %q, expected %q", c.begin, c.end, s, c.expected)
+        }
+    }
+    return
+}
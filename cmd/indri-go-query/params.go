@@ -0,0 +1,317 @@
+package main
+
+import (
+    "bufio"
+    "encoding/xml"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "strconv"
+    "strings"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+// DefaultCount is the IndriRunQuery default number of results of a query
+const DefaultCount = 1000
+
+// querySpec is a query to run, Number names it in the output
+type querySpec struct {
+    Number string
+    Text string
+}
+
+//
+// config holds the IndriRunQuery parameters indri-go-query reads. Lists
+// are appended to by every parameter file and command line parameter,
+// other values are set by the last one.
+//
+type config struct {
+    Indexes []string
+    Servers []string
+    Count int
+    Rules []string
+    Stopwords []string
+    Memory int64
+    TrecFormat bool
+    RunID string
+    PrintDocuments bool
+    PrintSnippets bool
+    PrintQuery bool
+    FbDocs int
+    FbTerms int
+    FbMu float64
+    FbOrigWeight float64
+    Queries []querySpec
+}
+
+// newConfig returns a config with the IndriRunQuery defaults
+func newConfig() config {
+    return config{
+        Count: DefaultCount,
+        RunID: "indri",
+        FbTerms: 10,
+        FbOrigWeight: 0.5,
+    }
+}
+
+// boolParameters may be given without a value, -trecFormat is -trecFormat=true
+var boolParameters = map[string]bool{
+    "trecFormat": true,
+    "printDocuments": true,
+    "printSnippets": true,
+    "printQuery": true,
+}
+
+//
+// set applies an IndriRunQuery parameter, -count=10 is set("count", "10").
+// query adds a query, numbered once every parameter is read, and
+// queryFile adds the queries of a file, see loadQueryFile.
+//
+func (cfg *config) set(key string, value string) (err error) {
+    value = strings.TrimSpace(value)
+    // the boolean spellings of indri::api::Parameters
+    parseBool := func(def bool) bool {
+        switch strings.ToLower(value) {
+        case "1", "true", "yes", "y", "on":
+            return true
+        case "0", "false", "no", "n", "off":
+            return false
+        }
+        err = fmt.Errorf("invalid boolean %q", value)
+        return def
+    }
+    parseInt := func(def, least int) int {
+        var n int
+        if n, err = strconv.Atoi(value); err != nil || n < least {
+            err = fmt.Errorf("invalid integer %q", value)
+            return def
+        }
+        return n
+    }
+    parseFloat := func(def float64) float64 {
+        var f float64
+        if f, err = strconv.ParseFloat(value, 64); err != nil {
+            err = fmt.Errorf("invalid number %q", value)
+            return def
+        }
+        return f
+    }
+    switch key {
+    case "index":
+        cfg.Indexes = append(cfg.Indexes, value)
+    case "server":
+        cfg.Servers = append(cfg.Servers, value)
+    case "count":
+        cfg.Count = parseInt(cfg.Count, 1)
+    case "rule":
+        cfg.Rules = append(cfg.Rules, value)
+    case "stopper.word":
+        cfg.Stopwords = append(cfg.Stopwords, value)
+    case "memory":
+        cfg.Memory, err = indri_go.ParseMemorySize(value)
+    case "trecFormat":
+        cfg.TrecFormat = parseBool(cfg.TrecFormat)
+    case "runID":
+        cfg.RunID = value
+    case "printDocuments":
+        cfg.PrintDocuments = parseBool(cfg.PrintDocuments)
+    case "printSnippets":
+        cfg.PrintSnippets = parseBool(cfg.PrintSnippets)
+    case "printQuery":
+        cfg.PrintQuery = parseBool(cfg.PrintQuery)
+    case "fbDocs":
+        cfg.FbDocs = parseInt(cfg.FbDocs, 0)
+    case "fbTerms":
+        cfg.FbTerms = parseInt(cfg.FbTerms, 0)
+    case "fbMu":
+        cfg.FbMu = parseFloat(cfg.FbMu)
+    case "fbOrigWeight":
+        cfg.FbOrigWeight = parseFloat(cfg.FbOrigWeight)
+    case "query":
+        if value == "" {
+            err = fmt.Errorf("empty query")
+        } else {
+            cfg.Queries = append(cfg.Queries, querySpec{Text: value})
+        }
+    case "queryFile":
+        err = cfg.loadQueryFile(value)
+    default:
+        err = fmt.Errorf("unknown parameter %q", key)
+    }
+    if err != nil {
+        err = fmt.Errorf("%v: %v", key, err)
+    }
+    return
+}
+
+// xmlQuery reads both <query>text</query> and <query><number/><text/></query>
+type xmlQuery struct {
+    Chardata string `xml:",chardata"`
+    Number string `xml:"number"`
+    Text string `xml:"text"`
+}
+
+func (q xmlQuery) spec() querySpec {
+    if q.Text != "" {
+        return querySpec{Number: strings.TrimSpace(q.Number), Text: strings.TrimSpace(q.Text)}
+    }
+    return querySpec{Number: strings.TrimSpace(q.Number), Text: strings.TrimSpace(q.Chardata)}
+}
+
+type xmlParameters struct {
+    XMLName xml.Name `xml:"parameters"`
+    Indexes []string `xml:"index"`
+    Servers []string `xml:"server"`
+    Count string `xml:"count"`
+    Rules []string `xml:"rule"`
+    Stopwords []string `xml:"stopper>word"`
+    Memory string `xml:"memory"`
+    TrecFormat string `xml:"trecFormat"`
+    RunID string `xml:"runID"`
+    PrintDocuments string `xml:"printDocuments"`
+    PrintSnippets string `xml:"printSnippets"`
+    PrintQuery string `xml:"printQuery"`
+    FbDocs string `xml:"fbDocs"`
+    FbTerms string `xml:"fbTerms"`
+    FbMu string `xml:"fbMu"`
+    FbOrigWeight string `xml:"fbOrigWeight"`
+    Queries []xmlQuery `xml:"query"`
+}
+
+//
+// loadParameters reads an IndriRunQuery parameter file into cfg. Elements
+// it does not know, such as the corpus of an IndriBuildIndex file, are
+// ignored.
+//
+func (cfg *config) loadParameters(path string) (err error) {
+    b, err := ioutil.ReadFile(path)
+    if err != nil {
+        return
+    }
+    var x xmlParameters
+    if err = xml.Unmarshal(b, &x); err != nil {
+        return fmt.Errorf("failed to parse %v: %v", path, err)
+    }
+
+    var values [][2]string
+    for _, v := range x.Indexes {
+        values = append(values, [2]string{"index", v})
+    }
+    for _, v := range x.Servers {
+        values = append(values, [2]string{"server", v})
+    }
+    for _, v := range x.Rules {
+        values = append(values, [2]string{"rule", v})
+    }
+    for _, v := range x.Stopwords {
+        values = append(values, [2]string{"stopper.word", v})
+    }
+    for _, kv := range [][2]string{
+        {"count", x.Count},
+        {"memory", x.Memory},
+        {"trecFormat", x.TrecFormat},
+        {"runID", x.RunID},
+        {"printDocuments", x.PrintDocuments},
+        {"printSnippets", x.PrintSnippets},
+        {"printQuery", x.PrintQuery},
+        {"fbDocs", x.FbDocs},
+        {"fbTerms", x.FbTerms},
+        {"fbMu", x.FbMu},
+        {"fbOrigWeight", x.FbOrigWeight},
+    } {
+        if strings.TrimSpace(kv[1]) != "" {
+            values = append(values, kv)
+        }
+    }
+    for _, kv := range values {
+        if err = cfg.set(kv[0], kv[1]); err != nil {
+            return fmt.Errorf("%v: %v", path, err)
+        }
+    }
+    for i, q := range x.Queries {
+        spec := q.spec()
+        if spec.Text == "" {
+            return fmt.Errorf("%v: query %v is empty", path, i+1)
+        }
+        cfg.Queries = append(cfg.Queries, spec)
+    }
+    return
+}
+
+//
+// loadQueryFile adds a query for each line of the file at path. A line
+// may name its query, number and text separated by a tab. Blank lines and
+// comments, a # alone or followed by a space, are skipped, so that queries
+// may start with an operator such as #combine.
+//
+func (cfg *config) loadQueryFile(path string) (err error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return
+    }
+    defer f.Close()
+    scanner := bufio.NewScanner(f)
+    scanner.Buffer(nil, 1<<20)
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || line == "#" || strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "#\t") {
+            continue
+        }
+        spec := querySpec{Text: line}
+        if i := strings.IndexByte(line, '\t'); i >= 0 {
+            spec.Number, spec.Text = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+        }
+        cfg.Queries = append(cfg.Queries, spec)
+    }
+    return scanner.Err()
+}
+
+//
+// parseArgs reads args, IndriRunQuery style: parameter files and
+// -key=value parameters, applied in order. Queries without a number are
+// numbered by their position, from 0 as IndriRunQuery does.
+//
+func parseArgs(args []string) (cfg config, help bool, err error) {
+    cfg = newConfig()
+    for _, arg := range args {
+        if !strings.HasPrefix(arg, "-") {
+            if err = cfg.loadParameters(arg); err != nil {
+                return
+            }
+            continue
+        }
+        key, value := strings.TrimLeft(arg, "-"), ""
+        if i := strings.IndexByte(key, '='); i >= 0 {
+            key, value = key[:i], key[i+1:]
+        } else if key == "help" || key == "h" {
+            help = true
+            continue
+        } else if boolParameters[key] {
+            value = "true"
+        } else {
+            err = fmt.Errorf("parameter %q is not -key=value", arg)
+            return
+        }
+        if err = cfg.set(key, value); err != nil {
+            return
+        }
+    }
+    for i := range cfg.Queries {
+        if cfg.Queries[i].Number == "" {
+            cfg.Queries[i].Number = strconv.Itoa(i)
+        }
+    }
+    return
+}
+
+// check returns what is missing in cfg
+func (cfg config) check() error {
+    switch {
+    case len(cfg.Indexes) == 0 && len(cfg.Servers) == 0:
+        return fmt.Errorf("Must specify a server or index to query against.")
+    case len(cfg.Queries) == 0:
+        return fmt.Errorf("Must specify a query.")
+    }
+    return nil
+}
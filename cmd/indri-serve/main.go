@@ -0,0 +1,141 @@
+//
+// indri-serve serves Indri repositories and servers as a JSON http API,
+// see package server for the endpoints.
+//
+//   indri-serve -index /data/index-a -index /data/index-b -addr :8080
+//
+// With -write the /index endpoints write to a repository, created when it
+// does not exist, and committed every -commit-interval. The repository is
+// searched along with the -index ones, and searches see the documents
+// written once they are committed.
+//
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "log"
+    "net/http"
+    "os"
+    "os/signal"
+    "strings"
+    "syscall"
+    "time"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+    "github.com/dms3-fs/go-idx-indri/server"
+)
+
+// listFlag is a flag given any number of times
+type listFlag []string
+
+func (l *listFlag) String() string {
+    return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+    *l = append(*l, s)
+    return nil
+}
+
+func main() {
+    os.Exit(serve())
+}
+
+// serve runs the server until it is interrupted, and returns the exit status
+func serve() int {
+    var indexes, servers listFlag
+    flag.Var(&indexes, "index", "path of a repository to serve, repeatable")
+    flag.Var(&servers, "server", "host:port of an indrid server to serve, repeatable")
+    addr := flag.String("addr", ":8080", "address to listen on")
+    pool := flag.Int("pool", 4, "number of query environments, the concurrent requests served")
+    timeout := flag.Duration("timeout", server.DefaultTimeout, "time limit of a request")
+    maxLimit := flag.Int("max-limit", server.DefaultMaxLimit, "largest limit of a search")
+    write := flag.String("write", "", "path of a repository to write with the /index endpoints")
+    commitInterval := flag.Duration("commit-interval", time.Minute, "time between commits of the documents written")
+    flag.Usage = func() {
+        fmt.Fprintf(flag.CommandLine.Output(), "usage: %v -index path [-index path] [-server host:port] [options]\n", os.Args[0])
+        flag.PrintDefaults()
+    }
+    flag.Parse()
+    if len(indexes) == 0 && len(servers) == 0 && *write == "" {
+        flag.Usage()
+        return 2
+    }
+
+    mux := http.NewServeMux()
+
+    // the writer comes first, to create its repository before the pool
+    // opens it. Its commits refresh the pool, handed over once it is open.
+    pools := make(chan *indri_go.QueryPool, 1)
+    if *write != "" {
+        cfg := indri_go.NewIndexConfig()
+        cfg.Index = *write
+        committed := func() {
+            select {
+            case p := <-pools:
+                p.Refresh()
+                pools <- p
+            default:
+            }
+        }
+        w, err := indri_go.NewIndexWriter(cfg, indri_go.IndexWriterOptions{CommitInterval: *commitInterval, Committed: committed})
+        if err != nil {
+            log.Printf("indri-serve: %v", err)
+            return 1
+        }
+        defer func() {
+            if err := w.Close(); err != nil {
+                log.Printf("indri-serve: closing %v: %v", *write, err)
+            }
+        }()
+        mux.Handle("/index/", server.NewIndexHandler(w, server.IndexOptions{Timeout: *timeout}))
+
+        served := false
+        for _, index := range indexes {
+            served = served || index == *write
+        }
+        if !served {
+            indexes = append(indexes, *write)
+        }
+    }
+    if len(indexes) > 0 || len(servers) > 0 {
+        p, err := indri_go.NewQueryPool(*pool, indexes, servers)
+        if err != nil {
+            log.Printf("indri-serve: %v", err)
+            return 1
+        }
+        defer p.Close()
+        pools <- p
+        mux.Handle("/", server.NewHandler(p, server.Options{Timeout: *timeout, MaxLimit: *maxLimit}))
+    }
+
+    srv := &http.Server{
+        Addr: *addr,
+        Handler: mux,
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+
+    // the pool is closed once requests in flight are done
+    stopped := make(chan struct{})
+    stop := make(chan os.Signal, 1)
+    signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+    go func() {
+        defer close(stopped)
+        <-stop
+        ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+        defer cancel()
+        if err := srv.Shutdown(ctx); err != nil {
+            log.Printf("indri-serve: shutdown: %v", err)
+        }
+    }()
+
+    log.Printf("indri-serve: serving indexes %v servers %v on %v", indexes, servers, *addr)
+    if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+        log.Printf("indri-serve: %v", err)
+        return 1
+    }
+    <-stopped
+    return 0
+}
@@ -0,0 +1,148 @@
+package indri_go
+
+import (
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "testing"
+)
+
+/**
+ * Test recovered panics become LemurErrors and PanicErrors.
+**/
+func TestPanicError(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testPanicError()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test errors returned by wrapped calls support errors.Is and errors.As.
+**/
+func TestLemurError(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testLemurError()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testPanicError() (err error) {
+
+    cases := []struct {
+        r interface{}
+        code string
+        location string
+        message string
+        kind error
+    }{
+        {"lemur exception LEMUR_PARSE_ERROR: ../src/QueryEnvironment.cpp(120): Couldn't understand this query",
+            "LEMUR_PARSE_ERROR", "../src/QueryEnvironment.cpp(120)", "Couldn't understand this query", ErrParse},
+        {"lemur exception LEMUR_IO_ERROR: ../src/Parameters.cpp(510): Couldn't open parameter file 'x/manifest' for reading.",
+            "LEMUR_IO_ERROR", "../src/Parameters.cpp(510)", "Couldn't open parameter file 'x/manifest' for reading.", ErrRepositoryNotFound},
+        {"lemur exception LEMUR_IO_ERROR: ../src/File.cpp(40): read failed",
+            "LEMUR_IO_ERROR", "../src/File.cpp(40)", "read failed", ErrIO},
+        {"lemur exception LEMUR_BAD_PARAMETER_ERROR: bad value: 3",
+            "LEMUR_BAD_PARAMETER_ERROR", "", "bad value: 3", ErrBadParameter},
+        {"lemur exception LEMUR_RUNTIME_ERROR: x.cpp(1): File class 'zzz' wasn't recognized.",
+            "LEMUR_RUNTIME_ERROR", "x.cpp(1)", "File class 'zzz' wasn't recognized.", ErrUnknownFileClass},
+        {"lemur exception LEMUR_IO_ERROR: x.cpp(1): repository is locked",
+            "LEMUR_IO_ERROR", "x.cpp(1)", "repository is locked", ErrRepositoryLocked},
+        {"lemur exception LEMUR_IO_ERROR: x.cpp(1): short read of block 12",
+            "LEMUR_IO_ERROR", "x.cpp(1)", "short read of block 12", ErrIO},
+        {"lemur exception LEMUR_RUNTIME_ERROR: x.cpp(1): failed to unlock mutex",
+            "LEMUR_RUNTIME_ERROR", "x.cpp(1)", "failed to unlock mutex", nil},
+        {"lemur exception LEMUR_IO_ERROR: ../src/Parameters.cpp(77): Couldn't parse parameter file 'x/manifest', it is not valid XML.",
+            "LEMUR_IO_ERROR", "../src/Parameters.cpp(77)", "Couldn't parse parameter file 'x/manifest', it is not valid XML.", ErrIO},
+        {"lemur exception LEMUR_RUNTIME_ERROR: x.cpp(1): indexing cancelled",
+            "LEMUR_RUNTIME_ERROR", "x.cpp(1)", "indexing cancelled", nil},
+    }
+    stack := []string{"frame"}
+    for _, c := range cases {
+        e := newPanicError(c.r, stack)
+        var le *LemurError
+        if !errors.As(e, &le) {
+            return fmt.Errorf("%q: expected a *LemurError, got %T", c.r, e)
+        }
+        if le.Code != c.code || le.Location != c.location || le.Message != c.message || le.Kind != c.kind {
+            return fmt.Errorf("%q: unexpected %+v", c.r, le)
+        }
+        if c.kind != nil && !errors.Is(e, c.kind) {
+            return fmt.Errorf("%q: errors.Is(%v) is false", c.r, c.kind)
+        }
+        if len(le.Stack) != 1 {
+            return fmt.Errorf("%q: stack %v", c.r, le.Stack)
+        }
+    }
+
+    // any other panic is a PanicError, unwrapping to a panicked error
+    e := newPanicError("C++ std::out_of_range exception thrown", stack)
+    var pe *PanicError
+    if !errors.As(e, &pe) || e.Error() != "C++ std::out_of_range exception thrown" {
+        return fmt.Errorf("unexpected %T %v", e, e)
+    }
+    if e = newPanicError(ErrClosed, stack); !errors.Is(e, ErrClosed) {
+        return fmt.Errorf("errors.Is(%v, ErrClosed) is false", e)
+    }
+
+    // catch keeps the stack apart from the message
+    e = func() (err error) {
+        defer catch(&err)
+        panic("boom")
+    }()
+    if !errors.As(e, &pe) || e.Error() != "boom" || len(pe.Stack) == 0 {
+        return fmt.Errorf("catch returned %T %v", e, e)
+    }
+    return
+}
+
+func testLemurError() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    e := qe.AddIndex(dir + "/missing")
+    if !errors.Is(e, ErrRepositoryNotFound) {
+        err = fmt.Errorf("AddIndex of a missing repository returned %v", e)
+        return
+    }
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    _, e = qe.RunQuery("#combine(pizza", 10)
+    var le *LemurError
+    if !errors.Is(e, ErrParse) || !errors.As(e, &le) {
+        err = fmt.Errorf("RunQuery of a bad query returned %T %v", e, e)
+        return
+    }
+    if le.Code != "LEMUR_PARSE_ERROR" || le.Message == "" || len(le.Stack) == 0 {
+        err = fmt.Errorf("unexpected parse error %+v", le)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
@@ -0,0 +1,610 @@
+package indri_go
+
+import (
+    "context"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+/**
+ * Test an invalid config reports all of its problems at once.
+**/
+func TestIndexConfigValidate(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexConfigValidate()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test a typed config builds a repository like TestBuildIndexGO does.
+**/
+func TestIndexConfigConfigure(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexConfigConfigure()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test data/params.xml loads into IndexConfig and survives a round trip.
+**/
+func TestIndexConfigLoad(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexConfigLoad()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test memory sizes and malformed parameters.
+**/
+func TestIndexConfigParse(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexConfigParse()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test IndriBuildIndex command line parameters applied with Set.
+**/
+func TestIndexConfigSet(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexConfigSet()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test BuildContext builds a corpus and stops when its context is done.
+**/
+func TestBuildContext(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testBuildContext()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test BuildIndex reports the files it skips and fails.
+**/
+func TestBuildIndex(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testBuildIndex()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+// blogIndexConfig mirrors the configuration built by hand in testBuildIndexGO
+func blogIndexConfig() IndexConfig {
+    cfg := NewIndexConfig()
+    cfg.Memory = 64*1024*1024
+    cfg.Stemmer = "Krovetz"
+    cfg.Stopwords = []string{"a", "an", "the", "as"}
+    metadata := []string{"odmver", "schver", "kind", "basetime", "maxareas", "maxcats", "offset", "app", "docno", "docver"}
+    cfg.Metadata = IndexMetadata{Fields: metadata, Forward: metadata, Backward: metadata}
+    for _, name := range []string{"blog", "about", "address", "affiliation", "author", "brand", "citation",
+        "description", "email", "headline", "keywords", "language", "name", "telephone"} {
+        cfg.Fields = append(cfg.Fields, IndexField{Name: name})
+    }
+    cfg.Fields = append(cfg.Fields, IndexField{Name: "version", Numeric: true})
+    return cfg
+}
+
+func testIndexConfigValidate() (err error) {
+
+    defer catch(&err)
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    cfg := blogIndexConfig()
+    cfg.Memory = -1
+    cfg.Stemmer = "snowball"
+    cfg.Fields = append(cfg.Fields, IndexField{Name: "Blog"}, IndexField{Name: "kind", Parser: "NumericFieldAnnotator"})
+    cfg.Metadata.Forward = append(cfg.Metadata.Forward, "")
+    cfg.Corpora = []IndexCorpus{{Class: "html"}, {Path: "data", Class: "no-such-class"}}
+
+    e := Configure(env, cfg)
+    cerr, ok := e.(*IndexConfigError)
+    if !ok {
+        err = fmt.Errorf("Configure returned %v, expected an *IndexConfigError", e)
+        return
+    }
+    if len(cerr.Problems) != 7 {
+        err = fmt.Errorf("expected 7 problems, got %v: %v", len(cerr.Problems), cerr)
+        return
+    }
+
+    // the default config is valid
+    if err = NewIndexConfig().Validate(nil); err != nil {
+        return
+    }
+    return
+}
+
+func testIndexConfigConfigure() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath := filepath.Join(dir, "index-config")
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    cfg := blogIndexConfig()
+    cfg.Corpora = []IndexCorpus{{Path: "data/blog.html", Class: "html"}}
+    if err = Configure(env, cfg); err != nil {
+        return
+    }
+    if err = env.Create(repositoryPath); err != nil {
+        return
+    }
+
+    fp, err := filepath.Abs("data/blog.html")
+    if err != nil {
+        env.Close()
+        return
+    }
+    if err = env.AddFile(fp, "html"); err != nil {
+        env.Close()
+        return
+    }
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    fields, err := qe.FieldList()
+    if err != nil {
+        return
+    }
+    found := false
+    for _, f := range fields {
+        found = found || f == "version"
+    }
+    if !found {
+        err = fmt.Errorf("configured field version not in field list %v", fields)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testIndexConfigLoad() (err error) {
+
+    defer catch(&err)
+
+    cfg, err := LoadIndexConfig("data/params.xml")
+    if err != nil {
+        return
+    }
+
+    if cfg.Index != "/tmp/testrepo" || cfg.Memory != 100*1024*1024 || cfg.Stemmer != "krovetz" || !cfg.Normalize || !cfg.StoreDocs {
+        err = fmt.Errorf("unexpected config %+v", cfg)
+        return
+    }
+    if !reflect.DeepEqual(cfg.Stopwords, []string{"a", "an", "the", "as"}) {
+        err = fmt.Errorf("unexpected stopwords %v", cfg.Stopwords)
+        return
+    }
+    want := IndexCorpus{Path: "/tmp/testcorpus", Class: "html", Metadata: "/tmp/testmetadata"}
+    if len(cfg.Corpora) != 1 || cfg.Corpora[0] != want {
+        err = fmt.Errorf("unexpected corpora %+v", cfg.Corpora)
+        return
+    }
+    if len(cfg.Fields) != 15 || cfg.Fields[0].Name != "blog" || cfg.Fields[14].Name != "version" {
+        err = fmt.Errorf("unexpected fields %+v", cfg.Fields)
+        return
+    }
+    if len(cfg.Metadata.Fields) != 10 || len(cfg.Metadata.Forward) != 10 || len(cfg.Metadata.Backward) != 10 {
+        err = fmt.Errorf("unexpected metadata %+v", cfg.Metadata)
+        return
+    }
+
+    s, err := cfg.Marshal()
+    if err != nil {
+        return
+    }
+    again, err := ParseIndexConfig(s)
+    if err != nil {
+        return
+    }
+    if !reflect.DeepEqual(cfg, again) {
+        err = fmt.Errorf("round trip changed config\n%+v\n%+v", cfg, again)
+        return
+    }
+
+    // the marshalled xml is read by the C++ Parameters too
+    var p Parameters = NewParameters()
+    defer DeleteWrapped_Parameters(p)
+    if err = p.MyLoad(s); err != nil {
+        return
+    }
+    if v := p.Get_string("index", ""); v != cfg.Index {
+        err = fmt.Errorf("parameters index %q, expected %q", v, cfg.Index)
+        return
+    }
+    if v := p.Get_INT64("memory", 0); v != cfg.Memory {
+        err = fmt.Errorf("parameters memory %v, expected %v", v, cfg.Memory)
+        return
+    }
+    if v := p.Get_string("corpus.class", ""); v != "html" {
+        err = fmt.Errorf("parameters corpus.class %q, expected html", v)
+        return
+    }
+    return
+}
+
+func testIndexConfigSet() (err error) {
+
+    defer catch(&err)
+
+    cfg := NewIndexConfig()
+    for _, kv := range [][2]string{
+        {"index", "repo"},
+        {"memory", "100M"},
+        {"stemmer.name", "krovetz"},
+        {"normalize", "false"},
+        {"corpus.path", "a"},
+        {"corpus.class", "trectext"},
+        {"corpus.path", "b"},
+        {"corpus.metadata", "b.meta"},
+        {"field.name", "year"},
+        {"field.numeric", "true"},
+        {"stopper.word", "the"},
+        {"metadata.forward", "url"},
+    } {
+        if err = cfg.Set(kv[0], kv[1]); err != nil {
+            return
+        }
+    }
+    expected := IndexConfig{
+        Index: "repo",
+        Memory: 100*1024*1024,
+        Stemmer: "krovetz",
+        StoreDocs: true,
+        Stopwords: []string{"the"},
+        Fields: []IndexField{{Name: "year", Numeric: true}},
+        Metadata: IndexMetadata{Forward: []string{"url"}},
+        Corpora: []IndexCorpus{{Path: "a", Class: "trectext"}, {Path: "b", Metadata: "b.meta"}},
+    }
+    if !reflect.DeepEqual(cfg, expected) {
+        err = fmt.Errorf("Set made %+v, expected %+v", cfg, expected)
+        return
+    }
+
+    for _, kv := range [][2]string{
+        {"memory", "lots"},
+        {"normalize", "maybe"},
+        {"nosuch", "x"},
+    } {
+        if e := cfg.Set(kv[0], kv[1]); e == nil {
+            err = fmt.Errorf("Set(%q, %q) expected an error", kv[0], kv[1])
+            return
+        }
+    }
+    empty := NewIndexConfig()
+    if e := empty.Set("corpus.class", "html"); e == nil {
+        err = fmt.Errorf("corpus.class before corpus.path expected an error")
+        return
+    }
+    for _, key := range []string{"field.numeric", "field.ordinal", "field.parental"} {
+        if e := empty.Set(key, "true"); e == nil || !strings.Contains(e.Error(), "field.name") {
+            err = fmt.Errorf("%v before field.name returned %v, expected an error", key, e)
+            return
+        }
+    }
+    return
+}
+
+func testIndexConfigParse() (err error) {
+
+    defer catch(&err)
+
+    for s, want := range map[string]int64{
+        "512": 512,
+        "4K": 4*1024,
+        "100m": 100*1024*1024,
+        " 2G ": 2*1024*1024*1024,
+    } {
+        v, e := ParseMemorySize(s)
+        if e != nil || v != want {
+            err = fmt.Errorf("ParseMemorySize(%q) returned %v, %v, expected %v", s, v, e, want)
+            return
+        }
+        if v, _ = ParseMemorySize(FormatMemorySize(want)); v != want {
+            err = fmt.Errorf("FormatMemorySize(%v) does not parse back", want)
+            return
+        }
+    }
+    if _, e := ParseMemorySize("lots"); e == nil {
+        err = fmt.Errorf("ParseMemorySize expected an error")
+        return
+    }
+    if _, e := ParseMemorySize("1.5G"); e == nil || !strings.Contains(e.Error(), `"1.5G"`) {
+        err = fmt.Errorf("ParseMemorySize(\"1.5G\") returned %v, expected an error quoting the input", e)
+        return
+    }
+    if v, e := ParseMemorySize("99999999999G"); e == nil || !strings.Contains(e.Error(), `"99999999999G"`) {
+        err = fmt.Errorf("ParseMemorySize(\"99999999999G\") returned %v, %v, expected an overflow error", v, e)
+        return
+    }
+
+    zero := NewIndexConfig()
+    zero.Index = "repo"
+    zero.Memory = 0
+    s, err := zero.Marshal()
+    if err != nil {
+        return
+    }
+    if again, e := ParseIndexConfig(s); e != nil || again.Memory != 0 {
+        err = fmt.Errorf("memory 0 read back as %v, %v", again.Memory, e)
+        return
+    }
+
+    cfg, err := ParseIndexConfig(`<parameters><index>repo</index></parameters>`)
+    if err != nil {
+        return
+    }
+    if !reflect.DeepEqual(cfg, IndexConfig{Index: "repo", Memory: DefaultIndexMemory, Normalize: true, StoreDocs: true}) {
+        err = fmt.Errorf("missing parameters did not default: %+v", cfg)
+        return
+    }
+
+    cfg, err = ParseIndexConfig(`<parameters><field><name>n</name><numeric>yes</numeric></field><normalize>false</normalize></parameters>`)
+    if err != nil {
+        return
+    }
+    if cfg.Normalize || len(cfg.Fields) != 1 || !cfg.Fields[0].Numeric {
+        err = fmt.Errorf("unexpected config %+v", cfg)
+        return
+    }
+
+    for _, bad := range []string{bad_params_4, bad_params_5, `<parameters><memory>1x</memory></parameters>`, `<parameters><storeDocs>maybe</storeDocs></parameters>`} {
+        if _, e := ParseIndexConfig(bad); e == nil {
+            err = fmt.Errorf("ParseIndexConfig(%q) expected an error", bad)
+            return
+        }
+    }
+    return
+}
+
+// writeTestCorpus writes n trectext files of one document each into dir
+func writeTestCorpus(dir string, n int) (err error) {
+    if err = os.MkdirAll(dir, 0775); err != nil {
+        return
+    }
+    for i := 0; i < n; i++ {
+        doc := fmt.Sprintf("<DOC>\n<DOCNO>c%v</DOCNO>\n<TEXT>\ndocument %v about pizza\n</TEXT>\n</DOC>\n", i, i)
+        if err = ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("c%03d.trec", i)), []byte(doc), 0664); err != nil {
+            return
+        }
+    }
+    return
+}
+
+func testBuildContext() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    corpusPath := filepath.Join(dir, "corpus")
+    if err = writeTestCorpus(corpusPath, 20); err != nil {
+        return
+    }
+
+    cfg := NewIndexConfig()
+    cfg.Memory = 64*1024*1024
+    cfg.Index = filepath.Join(dir, "index-full")
+    cfg.Corpora = []IndexCorpus{{Path: corpusPath, Class: "trectext"}}
+    if err = BuildContext(context.Background(), cfg, nil); err != nil {
+        return
+    }
+    if count, e := repositoryDocumentCount(cfg.Index); e != nil || count != 20 {
+        err = fmt.Errorf("full build has %v documents, %v", count, e)
+        return
+    }
+
+    // without a status a file that fails is printed and the build goes on
+    if err = os.Symlink(filepath.Join(dir, "missing.trec"), filepath.Join(corpusPath, "c000-missing.trec")); err != nil {
+        return
+    }
+    cfg.Index = filepath.Join(dir, "index-file-error")
+    if err = BuildContext(context.Background(), cfg, nil); err != nil {
+        err = fmt.Errorf("build with a missing file returned %v, expected it to go on", err)
+        return
+    }
+    if count, e := repositoryDocumentCount(cfg.Index); e != nil || count != 20 {
+        err = fmt.Errorf("build with a missing file has %v documents, %v", count, e)
+        return
+    }
+    if err = os.Remove(filepath.Join(corpusPath, "c000-missing.trec")); err != nil {
+        return
+    }
+
+    // cancel from the status callback once five documents are indexed
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    status := NewDirectorIndexStatus(&cancelAfter{documents: 5, cancel: cancel})
+    defer DeleteDirectorIndexStatus(status)
+    cfg.Index = filepath.Join(dir, "index-cancelled")
+    if e := BuildContext(ctx, cfg, status); e != context.Canceled {
+        err = fmt.Errorf("cancelled build returned %v, expected %v", e, context.Canceled)
+        return
+    }
+    count, err := repositoryDocumentCount(cfg.Index)
+    if err != nil {
+        return
+    }
+    if count != 5 {
+        err = fmt.Errorf("cancelled build has %v documents, expected 5", count)
+        return
+    }
+
+    // an already cancelled context does not build at all
+    cfg.Index = filepath.Join(dir, "index-never")
+    if e := BuildContext(ctx, cfg, nil); e != context.Canceled {
+        err = fmt.Errorf("build with a done context returned %v", e)
+        return
+    }
+    return
+}
+
+// cancelAfter is an IndexStatus calling cancel once enough documents are indexed
+type cancelAfter struct {
+    documents int
+    cancel context.CancelFunc
+}
+
+func (c *cancelAfter) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    if IndexEventKind(code) == DocumentCount && documentsIndexed >= c.documents {
+        c.cancel()
+    }
+}
+
+// repositoryDocumentCount opens a repository and counts its documents
+func repositoryDocumentCount(repositoryPath string) (count int64, err error) {
+
+    defer catch(&err)
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    if count, err = qe.DocumentCount(); err != nil {
+        return
+    }
+    err = qe.Close()
+    return
+}
+
+func testBuildIndex() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    corpusPath := filepath.Join(dir, "corpus")
+    if err = writeTestCorpus(corpusPath, 10); err != nil {
+        return
+    }
+    if err = writeTestCorpus(filepath.Join(corpusPath, "drafts"), 1); err != nil {
+        return
+    }
+    if err = ioutil.WriteFile(filepath.Join(corpusPath, "notes.txt"), []byte("notes"), 0664); err != nil {
+        return
+    }
+    missing := filepath.Join(corpusPath, "missing.trec")
+    if err = os.Symlink(filepath.Join(dir, "nowhere"), missing); err != nil {
+        return
+    }
+    otherPath := filepath.Join(dir, "other")
+    if err = os.MkdirAll(otherPath, 0775); err != nil {
+        return
+    }
+    unknown := filepath.Join(otherPath, "x.zzz")
+    if err = ioutil.WriteFile(unknown, []byte("unknown"), 0664); err != nil {
+        return
+    }
+
+    cfg := NewIndexConfig()
+    cfg.Memory = 64*1024*1024
+    cfg.Index = filepath.Join(dir, "index")
+    cfg.Corpora = []IndexCorpus{
+        {Path: corpusPath, Class: "trectext"},
+        {Path: otherPath},
+    }
+
+    if _, e := BuildIndex(context.Background(), cfg, BuildOptions{Include: []string{"["}}); e == nil {
+        err = fmt.Errorf("BuildIndex with a bad pattern expected an error")
+        return
+    }
+
+    opts := BuildOptions{Exclude: []string{"*.txt", "drafts/*"}}
+    report, err := BuildIndex(context.Background(), cfg, opts)
+    if err != nil {
+        return
+    }
+    if report.FilesSeen != 14 || report.DocumentsIndexed != 10 || report.Elapsed <= 0 {
+        err = fmt.Errorf("unexpected build report %+v", report)
+        return
+    }
+    skipped := map[string]string{}
+    for _, f := range report.FilesSkipped {
+        skipped[f.Path] = f.Reason
+    }
+    expected := map[string]string{
+        filepath.Join(corpusPath, "notes.txt"): "excluded by *.txt",
+        filepath.Join(corpusPath, "drafts", "c000.trec"): "excluded by drafts/*",
+        unknown: "no file class",
+    }
+    if !reflect.DeepEqual(skipped, expected) {
+        err = fmt.Errorf("skipped %v, expected %v", skipped, expected)
+        return
+    }
+    if len(report.FilesFailed) != 1 || report.FilesFailed[0].Path != missing || report.FilesFailed[0].Reason == "" {
+        err = fmt.Errorf("unexpected failed files %+v", report.FilesFailed)
+        return
+    }
+    if count, e := repositoryDocumentCount(cfg.Index); e != nil || count != 10 {
+        err = fmt.Errorf("build has %v documents, %v", count, e)
+        return
+    }
+
+    // include only some files of a new repository
+    cfg.Index = filepath.Join(dir, "index-included")
+    cfg.Corpora = cfg.Corpora[:1]
+    opts = BuildOptions{Include: []string{"c00[0-4].trec"}, Exclude: []string{"drafts/*"}}
+    if report, err = BuildIndex(context.Background(), cfg, opts); err != nil {
+        return
+    }
+    if report.DocumentsIndexed != 5 || len(report.FilesFailed) != 0 || len(report.FilesSkipped) != 8 {
+        err = fmt.Errorf("unexpected included build report %+v", report)
+        return
+    }
+    return
+}
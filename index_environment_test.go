@@ -9,6 +9,7 @@ import (
     "path/filepath"
     "strings"
     "testing"
+    "time"
 
     util "github.com/dms3-fs/go-fs-util"
 )
@@ -41,6 +42,7 @@ type IndexEnvironment interface {
 	AddFile(a ...interface{})
 	AddString(arg2 string, arg3 string, arg4 Std_vector_Sl_indri_parse_MetadataPair_Sg_) (_swig_ret int)
 	AddParsedDocument(arg2 ParsedDocument) (_swig_ret int)
+	AddDocument(arg2 string, arg3 string, arg4 map[string]string) (_swig_ret int)
 	DocumentsIndexed() (_swig_ret int)
 	DocumentsSeen() (_swig_ret int)
 }
@@ -79,6 +81,50 @@ func TestIndexEnvReadWrite(t *testing.T) {
     }
 }
 
+/**
+ * Test documents added with go metadata can be read back by metadata.
+**/
+func TestIndexEnvAddDocument(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexEnvAddDocument()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test UpsertDocument replaces and DeleteByDocno deletes by docno.
+**/
+func TestIndexEnvUpsertDocument(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexEnvUpsertDocument()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test indexing progress is streamed as IndexEvents on a channel.
+**/
+func TestIndexStatusChannel(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexStatusChannel()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test deleting an IndexStatusChannel nobody reads does not block.
+**/
+func TestIndexStatusChannelAbandoned(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexStatusChannelAbandoned()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
 //
 // the next three tests demonstrate index repository initialization
 // using variant flavors of logic a la IndriBuildIndex.cpp
@@ -1653,3 +1699,332 @@ func testDocumentAddDeleteMetadata(env IndexEnvironment, corpusFileClass string)
 
     return
 }
+
+func testIndexEnvAddDocument() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath := filepath.Join(dir, "index-add")
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = env.SetMemory(int64(64*1024*1024)); err != nil {
+        return
+    }
+    fields := newStringVector([]string{"kind"})
+    defer DeleteStringVector(fields)
+    if err = env.SetMetadataIndexedFields(fields, fields); err != nil {
+        return
+    }
+    if err = env.Create(repositoryPath); err != nil {
+        return
+    }
+
+    metadata := map[string]string{
+        "odmver": "test odm version 0.1",
+        "schver": "test sch version 0.1",
+        "kind": "blogtest",
+        "docno": "blog-001",
+    }
+    docid, err := env.AddDocument("<text>the food court at burlington mall</text>", "trectext", metadata)
+    if err != nil {
+        err = fmt.Errorf("env.AddDocument error %v", err)
+        env.Close()
+        return
+    }
+    if docid <= 0 {
+        err = fmt.Errorf("env.AddDocument returned docid %v", docid)
+        env.Close()
+        return
+    }
+
+    // no metadata at all
+    if _, err = env.AddDocument("<text>parking is free</text>", "trectext", nil); err != nil {
+        err = fmt.Errorf("env.AddDocument without metadata error %v", err)
+        env.Close()
+        return
+    }
+
+    _, err = env.AddDocument("<text>pizza</text>", "no-such-class", metadata)
+    if err == nil {
+        err = fmt.Errorf("env.AddDocument expected an unknown file class error")
+        env.Close()
+        return
+    }
+
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    for field, value := range metadata {
+        values, e := qe.DocumentMetadata([]int{docid}, field)
+        if e != nil || len(values) != 1 || values[0] != value {
+            err = fmt.Errorf("metadata %v: expected %q, got %q, %v", field, value, values, e)
+            return
+        }
+    }
+
+    ids, err := qe.DocumentIDsFromMetadata("kind", []string{"blogtest"})
+    if err != nil || len(ids) != 1 || ids[0] != docid {
+        err = fmt.Errorf("qe.DocumentIDsFromMetadata returned %v, %v", ids, err)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testIndexStatusChannel() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    status, events := NewIndexStatusChannel(16)
+
+    // drain events while indexing
+    done := make(chan []IndexEvent)
+    go func() {
+        var received []IndexEvent
+        for e := range events {
+            received = append(received, e)
+        }
+        done <- received
+    }()
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = env.SetMemory(int64(64*1024*1024)); err != nil {
+        DeleteIndexStatusChannel(status)
+        return
+    }
+    if err = env.Create(filepath.Join(dir, "index-status"), status); err != nil {
+        DeleteIndexStatusChannel(status)
+        return
+    }
+
+    fp, err := filepath.Abs("data/blog.html")
+    if err == nil {
+        err = env.AddFile(fp, "html")
+    }
+    missing := filepath.Join(dir, "missing.html")
+    if err == nil {
+        // a missing file is reported as a FileError event, not an error
+        env.AddFile(missing, "html")
+    }
+    if e := env.Close(); err == nil {
+        err = e
+    }
+    DeleteIndexStatusChannel(status)
+    received := <-done
+    if err != nil {
+        return
+    }
+
+    kinds := make(map[IndexEventKind][]IndexEvent)
+    for _, e := range received {
+        kinds[e.Kind] = append(kinds[e.Kind], e)
+    }
+    if len(kinds[FileOpen]) == 0 || kinds[FileOpen][0].Path != fp {
+        err = fmt.Errorf("expected a FileOpen event for %v, got %+v", fp, received)
+        return
+    }
+    if len(kinds[FileClose]) == 0 || kinds[FileClose][0].Path != fp {
+        err = fmt.Errorf("expected a FileClose event for %v, got %+v", fp, received)
+        return
+    }
+    if len(kinds[FileError]) == 0 || kinds[FileError][0].Path != missing || kinds[FileError][0].Error == "" {
+        err = fmt.Errorf("expected a FileError event for %v, got %+v", missing, received)
+        return
+    }
+    if n := len(kinds[DocumentCount]); n == 0 || kinds[DocumentCount][n-1].DocumentsIndexed < 1 {
+        err = fmt.Errorf("expected DocumentCount events, got %+v", received)
+        return
+    }
+    return
+}
+
+func testIndexStatusChannelAbandoned() (err error) {
+
+    defer catch(&err)
+
+    // nothing reads events, the file event blocks until the delete
+    status, _ := NewIndexStatusChannel(0)
+    sent := make(chan struct{})
+    go func() {
+        defer close(sent)
+        status.Status(int(FileOpen), "abandoned.html", "", 0, 0)
+    }()
+    time.Sleep(50 * time.Millisecond)
+
+    deleted := make(chan struct{})
+    go func() {
+        defer close(deleted)
+        DeleteIndexStatusChannel(status)
+    }()
+    select {
+    case <-deleted:
+    case <-time.After(5 * time.Second):
+        return fmt.Errorf("DeleteIndexStatusChannel blocked on an unread channel")
+    }
+    <-sent
+    return
+}
+
+func testIndexEnvUpsertDocument() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath := filepath.Join(dir, "index-upsert")
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = env.SetMemory(int64(64*1024*1024)); err != nil {
+        return
+    }
+    fields := newStringVector([]string{"docno"})
+    defer DeleteStringVector(fields)
+    if err = env.SetMetadataIndexedFields(fields, fields); err != nil {
+        return
+    }
+    if err = env.Create(repositoryPath); err != nil {
+        return
+    }
+    closed := false
+    defer func() {
+        if !closed {
+            env.Close()
+        }
+    }()
+
+    first, replaced, err := env.UpsertDocument("blog-001", "<text>the food court at burlington mall</text>", "trectext", nil)
+    if err != nil {
+        return
+    }
+    if len(replaced) != 0 {
+        err = fmt.Errorf("first upsert replaced %v", replaced)
+        return
+    }
+    other, _, err := env.UpsertDocument("blog-002", "<text>parking is free</text>", "trectext", map[string]string{"kind": "blogtest"})
+    if err != nil {
+        return
+    }
+
+    second, replaced, err := env.UpsertDocument("blog-001", "<text>pizza at burlington mall</text>", "trectext", nil)
+    if err != nil {
+        return
+    }
+    if second == first || len(replaced) != 1 || replaced[0] != first {
+        err = fmt.Errorf("second upsert returned %v replacing %v, first was %v", second, replaced, first)
+        return
+    }
+
+    deleted, err := env.DeleteByDocno("no-such-docno")
+    if err != nil || len(deleted) != 0 {
+        err = fmt.Errorf("DeleteByDocno of a missing docno returned %v, %v", deleted, err)
+        return
+    }
+    if deleted, err = env.DeleteByDocno("blog-002"); err != nil {
+        return
+    }
+    if len(deleted) != 1 || deleted[0] != other {
+        err = fmt.Errorf("DeleteByDocno returned %v, expected [%v]", deleted, other)
+        return
+    }
+
+    // each upsert replaces only the live version, a deleted docno stays deleted
+    var versions []int
+    for i, text := range []string{"<text>subs at the mall</text>", "<text>subs and salads</text>", "<text>subs to go</text>"} {
+        docid, replaced, e := env.UpsertDocument("blog-003", text, "trectext", nil)
+        if e != nil {
+            err = e
+            return
+        }
+        if (i == 0 && len(replaced) != 0) || (i > 0 && (len(replaced) != 1 || replaced[0] != versions[i-1])) {
+            err = fmt.Errorf("upsert %v replaced %v, versions %v", i+1, replaced, versions)
+            return
+        }
+        versions = append(versions, docid)
+    }
+    if deleted, err = env.DeleteByDocno("blog-003"); err != nil {
+        return
+    }
+    if len(deleted) != 1 || deleted[0] != versions[2] {
+        err = fmt.Errorf("DeleteByDocno returned %v, expected [%v]", deleted, versions[2])
+        return
+    }
+    if deleted, err = env.DeleteByDocno("blog-003"); err != nil {
+        return
+    }
+    if len(deleted) != 0 {
+        err = fmt.Errorf("second DeleteByDocno returned %v", deleted)
+        return
+    }
+    closed = true
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    // only the replacement matches the text of either version
+    for _, query := range []string{"food", "pizza", "burlington"} {
+        results, e := qe.RunQuery(query, 10)
+        if e != nil {
+            err = e
+            return
+        }
+        expected := 1
+        if query == "food" {
+            expected = 0
+        }
+        if len(results) != expected || (expected == 1 && results[0].Document != second) {
+            err = fmt.Errorf("query %q returned %+v", query, results)
+            return
+        }
+    }
+    for _, query := range []string{"parking", "subs"} {
+        results, e := qe.RunQuery(query, 10)
+        if e != nil || len(results) != 0 {
+            err = fmt.Errorf("deleted document found for %q %+v, %v", query, results, e)
+            return
+        }
+    }
+    return
+}
@@ -0,0 +1,169 @@
+package indri_go
+
+import (
+    "context"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+/**
+ * Test IndexStats describes a small repository.
+**/
+func TestIndexStats(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexStats()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+var indexStatsDocuments = []string{
+    "<DOC>\n<DOCNO>s1</DOCNO>\n<TEXT>\n<TITLE>pizza night</TITLE> one two three\n</TEXT>\n</DOC>\n",
+    "<DOC>\n<DOCNO>s2</DOCNO>\n<TEXT>\n<TITLE>parking</TITLE> free\n</TEXT>\n</DOC>\n",
+    "<DOC>\n<DOCNO>s3</DOCNO>\n<TEXT>\nweekends\n</TEXT>\n</DOC>\n",
+}
+
+func testIndexStats() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    corpusPath := filepath.Join(dir, "corpus")
+    if err = os.MkdirAll(corpusPath, 0775); err != nil {
+        return
+    }
+    for i, doc := range indexStatsDocuments {
+        if err = ioutil.WriteFile(filepath.Join(corpusPath, fmt.Sprintf("s%v.trec", i)), []byte(doc), 0664); err != nil {
+            return
+        }
+    }
+
+    cfg := NewIndexConfig()
+    cfg.Memory = 64*1024*1024
+    cfg.Index = filepath.Join(dir, "index-stats")
+    cfg.Fields = []IndexField{{Name: "title"}}
+    cfg.Corpora = []IndexCorpus{{Path: corpusPath, Class: "trectext"}}
+    if err = BuildContext(context.Background(), cfg, nil); err != nil {
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(cfg.Index); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    stats, err := IndexStats(qe)
+    if err != nil {
+        return
+    }
+    if stats.Documents != 3 || stats.Terms != 8 || stats.UniqueTerms != 8 {
+        err = fmt.Errorf("unexpected counts %+v", stats)
+        return
+    }
+    if stats.AverageDocumentLength < 2.66 || stats.AverageDocumentLength > 2.67 {
+        err = fmt.Errorf("unexpected average document length %v", stats.AverageDocumentLength)
+        return
+    }
+
+    var title *FieldStats
+    for i := range stats.Fields {
+        if stats.Fields[i].Name == "title" {
+            title = &stats.Fields[i]
+        }
+    }
+    if title == nil || title.Extents != 2 || title.Terms != 3 {
+        err = fmt.Errorf("unexpected fields %+v", stats.Fields)
+        return
+    }
+
+    lengths := []LengthBucket{
+        {Min: 0, Max: 0, Documents: 0},
+        {Min: 1, Max: 1, Documents: 1},
+        {Min: 2, Max: 3, Documents: 1},
+        {Min: 4, Max: 7, Documents: 1},
+    }
+    if !reflect.DeepEqual(stats.DocumentLengths, lengths) {
+        err = fmt.Errorf("document lengths %+v, expected %+v", stats.DocumentLengths, lengths)
+        return
+    }
+
+    // the environment keeps track of its indexes as they are removed and added again
+    if err = qe.RemoveIndex(cfg.Index); err != nil {
+        return
+    }
+    if err = qe.AddIndex(cfg.Index); err != nil {
+        return
+    }
+    again, err := IndexStats(qe)
+    if err != nil {
+        return
+    }
+    if !reflect.DeepEqual(again.DocumentLengths, lengths) {
+        err = fmt.Errorf("document lengths after re-adding the index %+v, expected %+v", again.DocumentLengths, lengths)
+        return
+    }
+
+    // any other QueryEnvironment does not keep track of its indexes
+    wrapped := struct{ QueryEnvironment }{qe}
+    if _, e := IndexStats(wrapped); e == nil {
+        err = fmt.Errorf("IndexStats of a wrapped environment expected an error")
+        return
+    }
+
+    docno := false
+    for _, name := range stats.MetadataFields {
+        docno = docno || name == "docno"
+    }
+    if !docno {
+        err = fmt.Errorf("metadata fields %v do not include docno", stats.MetadataFields)
+        return
+    }
+
+    // deleted documents are left out, up to the last id
+    env := NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+    if err = env.Open(cfg.Index); err != nil {
+        return
+    }
+    if err = env.DeleteDocument(3); err != nil {
+        env.Close()
+        return
+    }
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    var deleted QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(deleted)
+    if err = deleted.AddIndex(cfg.Index); err != nil {
+        return
+    }
+    defer deleted.Close()
+    after, err := IndexStats(deleted)
+    if err != nil {
+        return
+    }
+    lengths[1].Documents = 0
+    if !reflect.DeepEqual(after.DocumentLengths, lengths) {
+        err = fmt.Errorf("document lengths after deleting s3 %+v, expected %+v", after.DocumentLengths, lengths)
+        return
+    }
+    return
+}
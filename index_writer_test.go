@@ -0,0 +1,282 @@
+package indri_go
+
+import (
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+)
+
+/**
+ * Test IndexWriter futures and commits.
+**/
+func TestIndexWriter(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexWriter()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test IndexWriter serializes writes from many goroutines.
+**/
+func TestIndexWriterConcurrent(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexWriterConcurrent()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test IndexWriter refuses writes after a failed commit until it is reopened.
+**/
+func TestIndexWriterCommitFailure(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testIndexWriterCommitFailure()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+// countDocuments opens a QueryEnvironment on repositoryPath and counts query matches
+func countDocuments(repositoryPath string, query string) (n int, err error) {
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+    results, err := qe.RunQuery(query, 100)
+    return len(results), err
+}
+
+func testIndexWriter() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    cfg := NewIndexConfig()
+    cfg.Index = filepath.Join(dir, "index-w")
+    cfg.Memory = 64 * 1024 * 1024
+
+    w, err := NewIndexWriter(cfg, IndexWriterOptions{BatchSize: 2})
+    if err != nil {
+        return
+    }
+    defer w.Close()
+
+    var futures []*IndexFuture
+    for i, text := range []string{"pizza at the mall", "pizza in the food court", "free parking"} {
+        doc := fmt.Sprintf("<DOC><DOCNO>w%v</DOCNO><TEXT>%v</TEXT></DOC>", i+1, text)
+        futures = append(futures, w.AddString(doc, "trectext", map[string]string{"title": text}))
+    }
+    docids := make(map[int]bool)
+    for _, f := range futures {
+        r, e := f.Wait()
+        if e != nil {
+            return e
+        }
+        docids[r.Docid] = true
+    }
+    if len(docids) != 3 {
+        return fmt.Errorf("3 documents were added with ids %v", docids)
+    }
+
+    if _, err = w.Commit().Wait(); err != nil {
+        return
+    }
+    if n, e := countDocuments(cfg.Index, "pizza"); e != nil || n != 2 {
+        return fmt.Errorf("committed repository matched pizza %v times, %v, expected 2", n, e)
+    }
+
+    r, err := w.DeleteByDocno("w1").Wait()
+    if err != nil {
+        return
+    }
+    if len(r.Deleted) != 1 {
+        return fmt.Errorf("DeleteByDocno deleted %v, expected 1 document", r.Deleted)
+    }
+    seen, err := w.Do(func(env IndexEnvironment) (r IndexResult, err error) {
+        r.Docid, err = env.DocumentsSeen()
+        return
+    }).Wait()
+    if err != nil {
+        return
+    }
+    if seen.Docid != 0 {
+        return fmt.Errorf("reopened environment has seen %v documents, expected 0", seen.Docid)
+    }
+    if _, err = w.Do(func(env IndexEnvironment) (IndexResult, error) {
+        panic("writer panic")
+    }).Wait(); err == nil {
+        return fmt.Errorf("Do of a panic returned no error")
+    }
+
+    stats := w.Stats()
+    if stats.Operations != 6 || stats.Errors != 1 || stats.Commits != 1 {
+        return fmt.Errorf("stats %+v, expected 6 operations, 1 error and 1 commit", stats)
+    }
+
+    if err = w.Close(); err != nil {
+        return
+    }
+    if n, e := countDocuments(cfg.Index, "pizza"); e != nil || n != 1 {
+        return fmt.Errorf("closed repository matched pizza %v times, %v, expected 1", n, e)
+    }
+    if _, e := w.AddString("<DOC><DOCNO>late</DOCNO></DOC>", "trectext", nil).Wait(); !errors.Is(e, ErrClosed) {
+        return fmt.Errorf("AddString after Close returned %v, expected ErrClosed", e)
+    }
+    return
+}
+
+func testIndexWriterConcurrent() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    cfg := NewIndexConfig()
+    cfg.Index = filepath.Join(dir, "index-c")
+    cfg.Memory = 64 * 1024 * 1024
+
+    w, err := NewIndexWriter(cfg, IndexWriterOptions{QueueSize: 4, BatchSize: 3, CommitDocuments: 10})
+    if err != nil {
+        return
+    }
+
+    const goroutines, documents = 8, 5
+    errs := make(chan error, goroutines*documents)
+    var wg sync.WaitGroup
+    for g := 0; g < goroutines; g++ {
+        wg.Add(1)
+        go func(g int) {
+            defer wg.Done()
+            for i := 0; i < documents; i++ {
+                doc := fmt.Sprintf("<DOC><DOCNO>c%v-%v</DOCNO><TEXT>pizza %v</TEXT></DOC>", g, i, i)
+                if _, e := w.AddString(doc, "trectext", nil).Wait(); e != nil {
+                    errs <- e
+                }
+            }
+        }(g)
+    }
+    wg.Wait()
+    close(errs)
+    for e := range errs {
+        w.Close()
+        return e
+    }
+
+    stats := w.Stats()
+    if stats.Commits < 1 || stats.Commits > goroutines*documents/10 {
+        w.Close()
+        return fmt.Errorf("stats %+v, expected a commit every 10 documents", stats)
+    }
+    if err = w.Close(); err != nil {
+        return
+    }
+    if n, e := countDocuments(cfg.Index, "pizza"); e != nil || n != goroutines*documents {
+        return fmt.Errorf("repository matched pizza %v times, %v, expected %v", n, e, goroutines*documents)
+    }
+    return
+}
+
+func testIndexWriterCommitFailure() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    cfg := NewIndexConfig()
+    cfg.Index = filepath.Join(dir, "index-f")
+    cfg.Memory = 64 * 1024 * 1024
+
+    w, err := NewIndexWriter(cfg, IndexWriterOptions{CommitInterval: 20 * time.Millisecond})
+    if err != nil {
+        return
+    }
+    defer w.Close()
+
+    if _, err = w.AddString("<DOC><DOCNO>f1</DOCNO><TEXT>pizza</TEXT></DOC>", "trectext", nil).Wait(); err != nil {
+        return
+    }
+
+    // the repository cannot be reopened under a file, so the periodic commit fails
+    file := filepath.Join(dir, "file")
+    if err = ioutil.WriteFile(file, []byte("not a directory"), 0644); err != nil {
+        return
+    }
+    if _, err = w.Do(func(env IndexEnvironment) (IndexResult, error) {
+        w.cfg.Index = filepath.Join(file, "index-f")
+        return IndexResult{}, nil
+    }).Wait(); err != nil {
+        return
+    }
+    var failed error
+    for deadline := time.Now().Add(5 * time.Second); failed == nil && time.Now().Before(deadline); {
+        _, failed = w.AddString("<DOC><DOCNO>f2</DOCNO><TEXT>pasta</TEXT></DOC>", "trectext", nil).Wait()
+        time.Sleep(10 * time.Millisecond)
+    }
+    if failed == nil || !strings.Contains(failed.Error(), "commit failed") {
+        return fmt.Errorf("AddString after a failed commit returned %v", failed)
+    }
+    if _, e := w.AddString("<DOC><DOCNO>f3</DOCNO><TEXT>salad</TEXT></DOC>", "trectext", nil).Wait(); e == nil {
+        return fmt.Errorf("AddString was accepted before the writer was reopened")
+    }
+    if _, e := w.Commit().Wait(); e == nil {
+        return fmt.Errorf("Commit reopened the repository under a file")
+    }
+
+    // once the file is a directory a tick reopens the writer, creating the
+    // repository there, without a Commit
+    if err = os.Remove(file); err != nil {
+        return
+    }
+    if err = os.Mkdir(file, 0755); err != nil {
+        return
+    }
+    var reopened error = fmt.Errorf("not tried")
+    for deadline := time.Now().Add(5 * time.Second); reopened != nil && time.Now().Before(deadline); {
+        _, reopened = w.AddString("<DOC><DOCNO>f4</DOCNO><TEXT>pizza soup</TEXT></DOC>", "trectext", nil).Wait()
+        time.Sleep(10 * time.Millisecond)
+    }
+    if reopened != nil {
+        return fmt.Errorf("AddString after the commit could succeed returned %v", reopened)
+    }
+    if err = w.Close(); err != nil {
+        return
+    }
+    if n, e := countDocuments(cfg.Index, "pizza"); e != nil || n != 1 {
+        return fmt.Errorf("first repository matched pizza %v times, %v, expected 1", n, e)
+    }
+    if n, e := countDocuments(filepath.Join(file, "index-f"), "pizza"); e != nil || n != 1 {
+        return fmt.Errorf("reopened repository matched pizza %v times, %v, expected 1", n, e)
+    }
+    return
+}
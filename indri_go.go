@@ -193,6 +193,79 @@ typedef _gostring_ swig_type_158;
 typedef _gostring_ swig_type_159;
 typedef _gostring_ swig_type_160;
 typedef _gostring_ swig_type_161;
+extern uintptr_t indri_go_cancel_status_new(uintptr_t arg1);
+extern void indri_go_cancel_status_check(uintptr_t arg1, uintptr_t arg2);
+extern void indri_go_cancel_status_cancel(uintptr_t arg1);
+extern void indri_go_cancel_status_delete(uintptr_t arg1);
+extern swig_intgo indri_go_index_environment_add_document(uintptr_t arg1, _gostring_ arg2, _gostring_ arg3, _gostring_ arg4, swig_intgo *arg5, swig_intgo arg6);
+extern uintptr_t indri_go_index_environment_document_ids(uintptr_t arg1, _gostring_ arg2, _gostring_ arg3);
+extern swig_intgo indri_go_index_environment_document_live(uintptr_t arg1, swig_intgo arg2);
+typedef struct indri_go_scored_result {
+  double score;
+  intgo document;
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_scored_result;
+extern swig_intgo indri_go_scored_results_size(uintptr_t arg1);
+extern void indri_go_scored_results_copy(uintptr_t arg1, indri_go_scored_result *arg2);
+extern uintptr_t indri_go_scored_results_new(indri_go_scored_result *arg1, swig_intgo arg2);
+extern void indri_go_scored_results_delete(uintptr_t arg1);
+extern swig_intgo indri_go_parsed_documents_size(uintptr_t arg1);
+extern uintptr_t indri_go_parsed_documents_get(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_parsed_documents_delete(uintptr_t arg1);
+extern const char *indri_go_parsed_document_text(uintptr_t arg1, swig_intgo *arg2);
+extern const char *indri_go_parsed_document_content(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_parsed_document_terms_size(uintptr_t arg1);
+extern const char *indri_go_parsed_document_term(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo indri_go_parsed_document_positions_size(uintptr_t arg1);
+extern void indri_go_parsed_document_positions(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_parsed_document_metadata_size(uintptr_t arg1);
+extern const char *indri_go_parsed_document_metadata(uintptr_t arg1, swig_intgo arg2, const void **arg3, swig_intgo *arg4);
+typedef struct indri_go_document_field {
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_document_field;
+extern swig_intgo indri_go_document_vectors_size(uintptr_t arg1);
+extern uintptr_t indri_go_document_vectors_get(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_document_vectors_delete(uintptr_t arg1);
+extern swig_intgo indri_go_document_vector_stems_size(uintptr_t arg1);
+extern const char *indri_go_document_vector_stem(uintptr_t arg1, swig_intgo arg2, swig_intgo *arg3);
+extern swig_intgo indri_go_document_vector_positions_size(uintptr_t arg1);
+extern void indri_go_document_vector_positions(uintptr_t arg1, swig_intgo *arg2);
+extern swig_intgo indri_go_document_vector_fields_size(uintptr_t arg1);
+extern const char *indri_go_document_vector_field(uintptr_t arg1, swig_intgo arg2, indri_go_document_field *arg3);
+typedef struct indri_go_query_result {
+  _gostring_ snippet;
+  _gostring_ documentName;
+  intgo docid;
+  double score;
+  intgo begin;
+  intgo end;
+} indri_go_query_result;
+extern uintptr_t indri_go_query_request_new(_gostring_ arg1, swig_intgo arg2, swig_intgo arg3, swig_intgo arg4);
+extern void indri_go_query_request_add_formulator(uintptr_t arg1, _gostring_ arg2);
+extern void indri_go_query_request_add_metadata(uintptr_t arg1, _gostring_ arg2);
+extern void indri_go_query_request_delete(uintptr_t arg1);
+extern uintptr_t indri_go_query_request_run(uintptr_t arg1, uintptr_t arg2);
+extern void indri_go_query_results_times(uintptr_t arg1, double *arg2, double *arg3, double *arg4, swig_intgo *arg5);
+extern swig_intgo indri_go_query_results_size(uintptr_t arg1);
+extern void indri_go_query_results_get(uintptr_t arg1, swig_intgo arg2, indri_go_query_result *arg3);
+extern swig_intgo indri_go_query_results_metadata_size(uintptr_t arg1, swig_intgo arg2);
+extern void indri_go_query_results_metadata(uintptr_t arg1, swig_intgo arg2, swig_intgo arg3, _gostring_ *arg4, _gostring_ *arg5);
+extern void indri_go_query_results_delete(uintptr_t arg1);
+extern swig_intgo indri_go_annotation_node_children_size(uintptr_t arg1);
+extern void indri_go_annotation_node_children(uintptr_t arg1, uintptr_t *arg2);
+extern swig_intgo indri_go_annotations_size(uintptr_t arg1);
+extern void indri_go_annotations_entries(uintptr_t arg1, _gostring_ *arg2, uintptr_t *arg3);
+extern swig_intgo indri_go_query_environment_document_live(uintptr_t arg1, swig_intgo arg2, swig_intgo arg3);
+extern uintptr_t indri_go_document_length_histogram(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo indri_go_field_term_count(uintptr_t arg1, _gostring_ arg2);
 extern void _wrap_Swig_free_indri_go_add17ee78870902e(uintptr_t arg1);
 extern uintptr_t _wrap_Swig_malloc_indri_go_add17ee78870902e(swig_intgo arg1);
 extern swig_type_1 _wrap_indriVersion_get_indri_go_add17ee78870902e(void);
@@ -271,12 +344,12 @@ extern void _wrap_Wrapped_Parameters_clear_indri_go_add17ee78870902e(uintptr_t a
 extern swig_type_56 _wrap_Wrapped_Parameters_size_indri_go_add17ee78870902e(uintptr_t arg1);
 extern _Bool _wrap_Wrapped_Parameters_exists_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_57 arg2);
 extern void _wrap_Wrapped_Parameters_load_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_58 arg2);
-extern void _wrap_TermExtent_begin_set_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
-extern swig_intgo _wrap_TermExtent_begin_get_indri_go_add17ee78870902e(uintptr_t arg1);
-extern void _wrap_TermExtent_end_set_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
-extern swig_intgo _wrap_TermExtent_end_get_indri_go_add17ee78870902e(uintptr_t arg1);
-extern void _wrap_delete_TermExtent_indri_go_add17ee78870902e(uintptr_t arg1);
-extern uintptr_t _wrap_new_TermExtent_indri_go_add17ee78870902e(void);
+extern void _wrap_Wrapped_TermExtent_begin_set_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Wrapped_TermExtent_begin_get_indri_go_add17ee78870902e(uintptr_t arg1);
+extern void _wrap_Wrapped_TermExtent_end_set_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
+extern swig_intgo _wrap_Wrapped_TermExtent_end_get_indri_go_add17ee78870902e(uintptr_t arg1);
+extern void _wrap_delete_Wrapped_TermExtent_indri_go_add17ee78870902e(uintptr_t arg1);
+extern uintptr_t _wrap_new_Wrapped_TermExtent_indri_go_add17ee78870902e(void);
 extern void _wrap_delete_ScoredExtentResult_indri_go_add17ee78870902e(uintptr_t arg1);
 extern uintptr_t _wrap_new_ScoredExtentResult_indri_go_add17ee78870902e(void);
 extern void _wrap_delete_ParsedDocument_indri_go_add17ee78870902e(uintptr_t arg1);
@@ -297,67 +370,67 @@ extern uintptr_t _wrap_QueryAnnotation_getAnnotations_indri_go_add17ee78870902e(
 extern uintptr_t _wrap_QueryAnnotation_getResults_indri_go_add17ee78870902e(uintptr_t arg1);
 extern uintptr_t _wrap_new_QueryAnnotation_indri_go_add17ee78870902e(void);
 extern void _wrap_delete_QueryAnnotation_indri_go_add17ee78870902e(uintptr_t arg1);
-extern uintptr_t _wrap_new_QueryEnvironment_indri_go_add17ee78870902e(void);
-extern void _wrap_QueryEnvironment_addServer_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_66 arg2);
-extern void _wrap_QueryEnvironment_addIndex_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_67 arg2);
-extern void _wrap_QueryEnvironment_removeServer_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_68 arg2);
-extern void _wrap_QueryEnvironment_removeIndex_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_69 arg2);
-extern void _wrap_QueryEnvironment_close_indri_go_add17ee78870902e(uintptr_t arg1);
-extern void _wrap_QueryEnvironment_setMemory_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_70 arg2);
-extern void _wrap_QueryEnvironment_setScoringRules_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern void _wrap_QueryEnvironment_setStopwords_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern uintptr_t _wrap_QueryEnvironment_runQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_71 arg2, swig_intgo arg3);
-extern uintptr_t _wrap_QueryEnvironment_runQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_72 arg2, uintptr_t arg3, swig_intgo arg4);
-extern uintptr_t _wrap_QueryEnvironment_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_73 arg2, swig_intgo arg3);
-extern uintptr_t _wrap_QueryEnvironment_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_74 arg2, uintptr_t arg3, swig_intgo arg4);
-extern uintptr_t _wrap_QueryEnvironment_runQuerydocset_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_75 arg2, uintptr_t arg3, swig_intgo arg4);
-extern uintptr_t _wrap_QueryEnvironment_runAnnotatedQuerydocset_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_76 arg2, uintptr_t arg3, swig_intgo arg4);
-extern uintptr_t _wrap_QueryEnvironment_documents__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern uintptr_t _wrap_QueryEnvironment_documents__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern uintptr_t _wrap_QueryEnvironment_documentMetadata__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_77 arg3);
-extern uintptr_t _wrap_QueryEnvironment_documentMetadata__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_78 arg3);
-extern uintptr_t _wrap_QueryEnvironment_documentIDsFromMetadata_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_79 arg2, uintptr_t arg3);
-extern uintptr_t _wrap_QueryEnvironment_documentsFromMetadata_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_80 arg2, uintptr_t arg3);
-extern swig_type_81 _wrap_QueryEnvironment_termCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1);
-extern swig_type_82 _wrap_QueryEnvironment_termCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_83 arg2);
-extern swig_type_84 _wrap_QueryEnvironment_termFieldCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_85 arg2, swig_type_86 arg3);
-extern uintptr_t _wrap_QueryEnvironment_fieldList_indri_go_add17ee78870902e(uintptr_t arg1);
-extern swig_type_87 _wrap_QueryEnvironment_documentCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1);
-extern swig_type_88 _wrap_QueryEnvironment_documentCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_89 arg2);
-extern uintptr_t _wrap_QueryEnvironment_documentVectors_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern double _wrap_QueryEnvironment_expressionCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_90 arg2, swig_type_91 arg3);
-extern double _wrap_QueryEnvironment_expressionCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_92 arg2);
-extern double _wrap_QueryEnvironment_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_93 arg2, swig_type_94 arg3);
-extern double _wrap_QueryEnvironment_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_95 arg2);
-extern uintptr_t _wrap_QueryEnvironment_expressionList__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_96 arg2, swig_type_97 arg3);
-extern uintptr_t _wrap_QueryEnvironment_expressionList__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_98 arg2);
-extern swig_intgo _wrap_QueryEnvironment_documentLength_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
-extern void _wrap_QueryEnvironment_setFormulationParameters_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern swig_type_99 _wrap_QueryEnvironment_reformulateQuery_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_100 arg2);
-extern swig_type_101 _wrap_QueryEnvironment_stemTerm_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_102 arg2);
-extern swig_type_103 _wrap_QueryEnvironment_termCountUnique_indri_go_add17ee78870902e(uintptr_t arg1);
-extern swig_type_104 _wrap_QueryEnvironment_stemCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_105 arg2);
-extern swig_type_106 _wrap_QueryEnvironment_stemFieldCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_107 arg2, swig_type_108 arg3);
-extern swig_type_109 _wrap_QueryEnvironment_documentStemCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_110 arg2);
-extern uintptr_t _wrap_QueryEnvironment_documentsdocids_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern uintptr_t _wrap_QueryEnvironment_documentMetadatadocids_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_111 arg3);
-extern swig_type_112 _wrap_QueryEnvironment_onetermCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_113 arg2);
-extern swig_type_114 _wrap_QueryEnvironment_onedocumentCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_115 arg2);
-extern void _wrap_delete_QueryEnvironment_indri_go_add17ee78870902e(uintptr_t arg1);
+extern uintptr_t _wrap_new_Wrapped_QueryEnvironment_indri_go_add17ee78870902e(void);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_addServer_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_66 arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_addIndex_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_67 arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_removeServer_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_68 arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_removeIndex_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_69 arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_close_indri_go_add17ee78870902e(uintptr_t arg1);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_setMemory_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_70 arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_setScoringRules_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_setStopwords_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_71 arg2, swig_intgo arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_72 arg2, uintptr_t arg3, swig_intgo arg4);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_73 arg2, swig_intgo arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_74 arg2, uintptr_t arg3, swig_intgo arg4);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runQuerydocset_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_75 arg2, uintptr_t arg3, swig_intgo arg4);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuerydocset_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_76 arg2, uintptr_t arg3, swig_intgo arg4);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_77 arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_78 arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentIDsFromMetadata_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_79 arg2, uintptr_t arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentsFromMetadata_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_80 arg2, uintptr_t arg3);
+extern swig_type_81 _wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1);
+extern swig_type_82 _wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_83 arg2);
+extern swig_type_84 _wrap_Wrapped_QueryEnvironment_Wrapped_termFieldCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_85 arg2, swig_type_86 arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_fieldList_indri_go_add17ee78870902e(uintptr_t arg1);
+extern swig_type_87 _wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1);
+extern swig_type_88 _wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_89 arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentVectors_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern double _wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_90 arg2, swig_type_91 arg3);
+extern double _wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_92 arg2);
+extern double _wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_93 arg2, swig_type_94 arg3);
+extern double _wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_95 arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_96 arg2, swig_type_97 arg3);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_98 arg2);
+extern swig_intgo _wrap_Wrapped_QueryEnvironment_Wrapped_documentLength_indri_go_add17ee78870902e(uintptr_t arg1, swig_intgo arg2);
+extern void _wrap_Wrapped_QueryEnvironment_Wrapped_setFormulationParameters_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern swig_type_99 _wrap_Wrapped_QueryEnvironment_Wrapped_reformulateQuery_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_100 arg2);
+extern swig_type_101 _wrap_Wrapped_QueryEnvironment_Wrapped_stemTerm_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_102 arg2);
+extern swig_type_103 _wrap_Wrapped_QueryEnvironment_Wrapped_termCountUnique_indri_go_add17ee78870902e(uintptr_t arg1);
+extern swig_type_104 _wrap_Wrapped_QueryEnvironment_Wrapped_stemCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_105 arg2);
+extern swig_type_106 _wrap_Wrapped_QueryEnvironment_Wrapped_stemFieldCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_107 arg2, swig_type_108 arg3);
+extern swig_type_109 _wrap_Wrapped_QueryEnvironment_Wrapped_documentStemCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_110 arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentsdocids_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern uintptr_t _wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadatadocids_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2, swig_type_111 arg3);
+extern swig_type_112 _wrap_Wrapped_QueryEnvironment_Wrapped_onetermCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_113 arg2);
+extern swig_type_114 _wrap_Wrapped_QueryEnvironment_Wrapped_onedocumentCount_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_115 arg2);
+extern void _wrap_delete_Wrapped_QueryEnvironment_indri_go_add17ee78870902e(uintptr_t arg1);
 extern void _wrap_delete_QueryExpander_indri_go_add17ee78870902e(uintptr_t arg1);
-extern uintptr_t _wrap_QueryExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_116 arg2, swig_intgo arg3, _Bool arg4);
-extern uintptr_t _wrap_QueryExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_117 arg2, swig_intgo arg3);
+extern uintptr_t _wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_116 arg2, swig_intgo arg3, _Bool arg4);
+extern uintptr_t _wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_117 arg2, swig_intgo arg3);
 extern swig_type_118 _wrap_QueryExpander_expand_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_119 arg2, uintptr_t arg3);
-extern uintptr_t _wrap_new_RMExpander_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern swig_type_120 _wrap_RMExpander_expand_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_121 arg2, uintptr_t arg3);
-extern void _wrap_delete_RMExpander_indri_go_add17ee78870902e(uintptr_t arg1);
-extern uintptr_t _wrap_RMExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_122 arg1, swig_intgo arg2, _Bool arg3);
-extern uintptr_t _wrap_RMExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_123 arg1, swig_intgo arg2);
-extern uintptr_t _wrap_new_PonteExpander_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
-extern swig_type_124 _wrap_PonteExpander_expand_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_125 arg2, uintptr_t arg3);
-extern void _wrap_delete_PonteExpander_indri_go_add17ee78870902e(uintptr_t arg1);
-extern uintptr_t _wrap_PonteExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_122 arg1, swig_intgo arg2, _Bool arg3);
-extern uintptr_t _wrap_PonteExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_123 arg1, swig_intgo arg2);
+extern uintptr_t _wrap_new_Wrapped_RMExpander_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern swig_type_120 _wrap_Wrapped_RMExpander_expand_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_121 arg2, uintptr_t arg3);
+extern void _wrap_delete_Wrapped_RMExpander_indri_go_add17ee78870902e(uintptr_t arg1);
+extern uintptr_t _wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_122 arg1, swig_intgo arg2, _Bool arg3);
+extern uintptr_t _wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_123 arg1, swig_intgo arg2);
+extern uintptr_t _wrap_new_Wrapped_PonteExpander_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
+extern swig_type_124 _wrap_Wrapped_PonteExpander_expand_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_125 arg2, uintptr_t arg3);
+extern void _wrap_delete_Wrapped_PonteExpander_indri_go_add17ee78870902e(uintptr_t arg1);
+extern uintptr_t _wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_122 arg1, swig_intgo arg2, _Bool arg3);
+extern uintptr_t _wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(uintptr_t _swig_base, swig_type_123 arg1, swig_intgo arg2);
 extern void _wrap_Wrapped_MetadataPair_key_set_indri_go_add17ee78870902e(uintptr_t arg1, swig_type_126 arg2);
 extern swig_type_127 _wrap_Wrapped_MetadataPair_key_get_indri_go_add17ee78870902e(uintptr_t arg1);
 extern void _wrap_Wrapped_MetadataPair_value_set_indri_go_add17ee78870902e(uintptr_t arg1, uintptr_t arg2);
@@ -426,8 +499,26 @@ import "C"
 import "unsafe"
 import _ "runtime/cgo"
 import "sync"
+import "context"
+import "crypto/hmac"
+import "crypto/rand"
+import "crypto/sha256"
+import "encoding/base64"
+import "encoding/json"
+import "encoding/xml"
+import "errors"
 import "fmt"
+import "hash/fnv"
+import "io"
+import "io/ioutil"
+import "math"
+import "os"
+import "path/filepath"
 import "runtime"
+import "strconv"
+import "strings"
+import "time"
+import "github.com/dms3-fs/go-idx-indri/query"
 
 
 type _ unsafe.Pointer
@@ -1251,55 +1342,55 @@ type Wrapped_Parameters interface {
 	Load(arg2 string)
 }
 
-type SwigcptrTermExtent uintptr
+type SwigcptrWrapped_TermExtent uintptr
 
-func (p SwigcptrTermExtent) Swigcptr() uintptr {
+func (p SwigcptrWrapped_TermExtent) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrTermExtent) SwigIsTermExtent() {
+func (p SwigcptrWrapped_TermExtent) SwigIsWrapped_TermExtent() {
 }
 
-func (arg1 SwigcptrTermExtent) SetBegin(arg2 int) {
+func (arg1 SwigcptrWrapped_TermExtent) SetBegin(arg2 int) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_TermExtent_begin_set_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+	C._wrap_Wrapped_TermExtent_begin_set_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrTermExtent) GetBegin() (_swig_ret int) {
+func (arg1 SwigcptrWrapped_TermExtent) GetBegin() (_swig_ret int) {
 	var swig_r int
 	_swig_i_0 := arg1
-	swig_r = (int)(C._wrap_TermExtent_begin_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
+	swig_r = (int)(C._wrap_Wrapped_TermExtent_begin_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrTermExtent) SetEnd(arg2 int) {
+func (arg1 SwigcptrWrapped_TermExtent) SetEnd(arg2 int) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_TermExtent_end_set_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
+	C._wrap_Wrapped_TermExtent_end_set_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))
 }
 
-func (arg1 SwigcptrTermExtent) GetEnd() (_swig_ret int) {
+func (arg1 SwigcptrWrapped_TermExtent) GetEnd() (_swig_ret int) {
 	var swig_r int
 	_swig_i_0 := arg1
-	swig_r = (int)(C._wrap_TermExtent_end_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
+	swig_r = (int)(C._wrap_Wrapped_TermExtent_end_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func DeleteTermExtent(arg1 TermExtent) {
+func DeleteWrapped_TermExtent(arg1 Wrapped_TermExtent) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_TermExtent_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_Wrapped_TermExtent_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-func NewTermExtent() (_swig_ret TermExtent) {
-	var swig_r TermExtent
-	swig_r = (TermExtent)(SwigcptrTermExtent(C._wrap_new_TermExtent_indri_go_add17ee78870902e()))
+func NewWrapped_TermExtent() (_swig_ret Wrapped_TermExtent) {
+	var swig_r Wrapped_TermExtent
+	swig_r = (Wrapped_TermExtent)(SwigcptrWrapped_TermExtent(C._wrap_new_Wrapped_TermExtent_indri_go_add17ee78870902e()))
 	return swig_r
 }
 
-type TermExtent interface {
+type Wrapped_TermExtent interface {
 	Swigcptr() uintptr
-	SwigIsTermExtent()
+	SwigIsWrapped_TermExtent()
 	SetBegin(arg2 int)
 	GetBegin() (_swig_ret int)
 	SetEnd(arg2 int)
@@ -1519,307 +1610,307 @@ type QueryAnnotation interface {
 	GetResults() (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)
 }
 
-type SwigcptrQueryEnvironment uintptr
+type SwigcptrWrapped_QueryEnvironment uintptr
 
-func (p SwigcptrQueryEnvironment) Swigcptr() uintptr {
+func (p SwigcptrWrapped_QueryEnvironment) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrQueryEnvironment) SwigIsQueryEnvironment() {
+func (p SwigcptrWrapped_QueryEnvironment) SwigIsWrapped_QueryEnvironment() {
 }
 
-func NewQueryEnvironment() (_swig_ret QueryEnvironment) {
-	var swig_r QueryEnvironment
-	swig_r = (QueryEnvironment)(SwigcptrQueryEnvironment(C._wrap_new_QueryEnvironment_indri_go_add17ee78870902e()))
+func NewWrapped_QueryEnvironment() (_swig_ret Wrapped_QueryEnvironment) {
+	var swig_r Wrapped_QueryEnvironment
+	swig_r = (Wrapped_QueryEnvironment)(SwigcptrWrapped_QueryEnvironment(C._wrap_new_Wrapped_QueryEnvironment_indri_go_add17ee78870902e()))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) AddServer(arg2 string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_addServer(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_QueryEnvironment_addServer_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_66)(unsafe.Pointer(&_swig_i_1)))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_addServer_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_66)(unsafe.Pointer(&_swig_i_1)))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 }
 
-func (arg1 SwigcptrQueryEnvironment) AddIndex(arg2 string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_addIndex(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_QueryEnvironment_addIndex_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_67)(unsafe.Pointer(&_swig_i_1)))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_addIndex_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_67)(unsafe.Pointer(&_swig_i_1)))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 }
 
-func (arg1 SwigcptrQueryEnvironment) RemoveServer(arg2 string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_removeServer(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_QueryEnvironment_removeServer_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_68)(unsafe.Pointer(&_swig_i_1)))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_removeServer_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_68)(unsafe.Pointer(&_swig_i_1)))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 }
 
-func (arg1 SwigcptrQueryEnvironment) RemoveIndex(arg2 string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_removeIndex(arg2 string) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_QueryEnvironment_removeIndex_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_69)(unsafe.Pointer(&_swig_i_1)))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_removeIndex_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_69)(unsafe.Pointer(&_swig_i_1)))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 }
 
-func (arg1 SwigcptrQueryEnvironment) Close() {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_close() {
 	_swig_i_0 := arg1
-	C._wrap_QueryEnvironment_close_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_close_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-func (arg1 SwigcptrQueryEnvironment) SetMemory(arg2 int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_setMemory(arg2 int64) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	C._wrap_QueryEnvironment_setMemory_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_type_70(_swig_i_1))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_setMemory_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_type_70(_swig_i_1))
 }
 
-func (arg1 SwigcptrQueryEnvironment) SetScoringRules(arg2 StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_setScoringRules(arg2 StringVector) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_QueryEnvironment_setScoringRules_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_setScoringRules_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrQueryEnvironment) SetStopwords(arg2 StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_setStopwords(arg2 StringVector) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_QueryEnvironment_setStopwords_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_setStopwords_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunQuery__SWIG_0(arg2 string, arg3 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runQuery__SWIG_0(arg2 string, arg3 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryEnvironment_runQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_71)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_71)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunQuery__SWIG_1(arg2 string, arg3 IntVector, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runQuery__SWIG_1(arg2 string, arg3 IntVector, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
 	_swig_i_3 := arg4
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryEnvironment_runQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_72)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_72)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) RunQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_runQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
 	argc := len(a)
 	if argc == 2 {
-		return p.RunQuery__SWIG_0(a[0].(string), a[1].(int))
+		return p.Wrapped_runQuery__SWIG_0(a[0].(string), a[1].(int))
 	}
 	if argc == 3 {
-		return p.RunQuery__SWIG_1(a[0].(string), a[1].(IntVector), a[2].(int))
+		return p.Wrapped_runQuery__SWIG_1(a[0].(string), a[1].(IntVector), a[2].(int))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunAnnotatedQuery__SWIG_0(arg2 string, arg3 int) (_swig_ret QueryAnnotation) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runAnnotatedQuery__SWIG_0(arg2 string, arg3 int) (_swig_ret QueryAnnotation) {
 	var swig_r QueryAnnotation
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_QueryEnvironment_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_73)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
+	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_73)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunAnnotatedQuery__SWIG_1(arg2 string, arg3 IntVector, arg4 int) (_swig_ret QueryAnnotation) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runAnnotatedQuery__SWIG_1(arg2 string, arg3 IntVector, arg4 int) (_swig_ret QueryAnnotation) {
 	var swig_r QueryAnnotation
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
 	_swig_i_3 := arg4
-	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_QueryEnvironment_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_74)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
+	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_74)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) RunAnnotatedQuery(a ...interface{}) QueryAnnotation {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_runAnnotatedQuery(a ...interface{}) QueryAnnotation {
 	argc := len(a)
 	if argc == 2 {
-		return p.RunAnnotatedQuery__SWIG_0(a[0].(string), a[1].(int))
+		return p.Wrapped_runAnnotatedQuery__SWIG_0(a[0].(string), a[1].(int))
 	}
 	if argc == 3 {
-		return p.RunAnnotatedQuery__SWIG_1(a[0].(string), a[1].(IntVector), a[2].(int))
+		return p.Wrapped_runAnnotatedQuery__SWIG_1(a[0].(string), a[1].(IntVector), a[2].(int))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
 	_swig_i_3 := arg4
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryEnvironment_runQuerydocset_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_75)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_runQuerydocset_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_75)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) RunAnnotatedQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret QueryAnnotation) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_runAnnotatedQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret QueryAnnotation) {
 	var swig_r QueryAnnotation
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
 	_swig_i_3 := arg4
-	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_QueryEnvironment_runAnnotatedQuerydocset_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_76)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
+	swig_r = (QueryAnnotation)(SwigcptrQueryAnnotation(C._wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuerydocset_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_76)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2), C.swig_intgo(_swig_i_3))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) Documents__SWIG_0(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documents__SWIG_0(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_QueryEnvironment_documents__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) Documents__SWIG_1(arg2 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documents__SWIG_1(arg2 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_QueryEnvironment_documents__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) Documents(a ...interface{}) Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_ {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_documents(a ...interface{}) Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_ {
 	argc := len(a)
 	if argc == 1 {
 		if _, ok := a[0].(SwigcptrIntVector); !ok {
 			goto check_1
 		}
-		return p.Documents__SWIG_0(a[0].(IntVector))
+		return p.Wrapped_documents__SWIG_0(a[0].(IntVector))
 	}
 check_1:
 	if argc == 1 {
-		return p.Documents__SWIG_1(a[0].(Std_vector_Sl_indri_api_ScoredExtentResult_Sg_))
+		return p.Wrapped_documents__SWIG_1(a[0].(Std_vector_Sl_indri_api_ScoredExtentResult_Sg_))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentMetadata__SWIG_0(arg2 IntVector, arg3 string) (_swig_ret StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentMetadata__SWIG_0(arg2 IntVector, arg3 string) (_swig_ret StringVector) {
 	var swig_r StringVector
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
 	_swig_i_2 := arg3
-	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_QueryEnvironment_documentMetadata__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_77)(unsafe.Pointer(&_swig_i_2)))))
+	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_77)(unsafe.Pointer(&_swig_i_2)))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg3
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentMetadata__SWIG_1(arg2 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_, arg3 string) (_swig_ret StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentMetadata__SWIG_1(arg2 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_, arg3 string) (_swig_ret StringVector) {
 	var swig_r StringVector
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
 	_swig_i_2 := arg3
-	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_QueryEnvironment_documentMetadata__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_78)(unsafe.Pointer(&_swig_i_2)))))
+	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_78)(unsafe.Pointer(&_swig_i_2)))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg3
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) DocumentMetadata(a ...interface{}) StringVector {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_documentMetadata(a ...interface{}) StringVector {
 	argc := len(a)
 	if argc == 2 {
 		if _, ok := a[0].(SwigcptrIntVector); !ok {
 			goto check_1
 		}
-		return p.DocumentMetadata__SWIG_0(a[0].(IntVector), a[1].(string))
+		return p.Wrapped_documentMetadata__SWIG_0(a[0].(IntVector), a[1].(string))
 	}
 check_1:
 	if argc == 2 {
-		return p.DocumentMetadata__SWIG_1(a[0].(Std_vector_Sl_indri_api_ScoredExtentResult_Sg_), a[1].(string))
+		return p.Wrapped_documentMetadata__SWIG_1(a[0].(Std_vector_Sl_indri_api_ScoredExtentResult_Sg_), a[1].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentIDsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret IntVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentIDsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret IntVector) {
 	var swig_r IntVector
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
-	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_QueryEnvironment_documentIDsFromMetadata_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_79)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))))
+	swig_r = (IntVector)(SwigcptrIntVector(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentIDsFromMetadata_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_79)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
-	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_QueryEnvironment_documentsFromMetadata_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_80)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))))
+	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentsFromMetadata_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_80)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) TermCount__SWIG_0() (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_termCount__SWIG_0() (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
-	swig_r = (int64)(C._wrap_QueryEnvironment_termCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) TermCount__SWIG_1(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_termCount__SWIG_1(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_termCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_83)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_83)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) TermCount(a ...interface{}) int64 {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_termCount(a ...interface{}) int64 {
 	argc := len(a)
 	if argc == 0 {
-		return p.TermCount__SWIG_0()
+		return p.Wrapped_termCount__SWIG_0()
 	}
 	if argc == 1 {
-		return p.TermCount__SWIG_1(a[0].(string))
+		return p.Wrapped_termCount__SWIG_1(a[0].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) TermFieldCount(arg2 string, arg3 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_termFieldCount(arg2 string, arg3 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (int64)(C._wrap_QueryEnvironment_termFieldCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_85)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_86)(unsafe.Pointer(&_swig_i_2))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_termFieldCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_85)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_86)(unsafe.Pointer(&_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
@@ -1829,56 +1920,56 @@ func (arg1 SwigcptrQueryEnvironment) TermFieldCount(arg2 string, arg3 string) (_
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) FieldList() (_swig_ret StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_fieldList() (_swig_ret StringVector) {
 	var swig_r StringVector
 	_swig_i_0 := arg1
-	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_QueryEnvironment_fieldList_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))))
+	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_Wrapped_QueryEnvironment_Wrapped_fieldList_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentCount__SWIG_0() (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentCount__SWIG_0() (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
-	swig_r = (int64)(C._wrap_QueryEnvironment_documentCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentCount__SWIG_1(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentCount__SWIG_1(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_documentCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_89)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_89)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) DocumentCount(a ...interface{}) int64 {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_documentCount(a ...interface{}) int64 {
 	argc := len(a)
 	if argc == 0 {
-		return p.DocumentCount__SWIG_0()
+		return p.Wrapped_documentCount__SWIG_0()
 	}
 	if argc == 1 {
-		return p.DocumentCount__SWIG_1(a[0].(string))
+		return p.Wrapped_documentCount__SWIG_1(a[0].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentVectors(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentVectors(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_) {
 	var swig_r Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_DocumentVector_Sm__Sg_(C._wrap_QueryEnvironment_documentVectors_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_DocumentVector_Sm__Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentVectors_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) ExpressionCount__SWIG_0(arg2 string, arg3 string) (_swig_ret float64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_expressionCount__SWIG_0(arg2 string, arg3 string) (_swig_ret float64) {
 	var swig_r float64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (float64)(C._wrap_QueryEnvironment_expressionCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_90)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_91)(unsafe.Pointer(&_swig_i_2))))
+	swig_r = (float64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_90)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_91)(unsafe.Pointer(&_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
@@ -1888,34 +1979,34 @@ func (arg1 SwigcptrQueryEnvironment) ExpressionCount__SWIG_0(arg2 string, arg3 s
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) ExpressionCount__SWIG_1(arg2 string) (_swig_ret float64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_expressionCount__SWIG_1(arg2 string) (_swig_ret float64) {
 	var swig_r float64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (float64)(C._wrap_QueryEnvironment_expressionCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_92)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (float64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_92)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) ExpressionCount(a ...interface{}) float64 {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_expressionCount(a ...interface{}) float64 {
 	argc := len(a)
 	if argc == 1 {
-		return p.ExpressionCount__SWIG_1(a[0].(string))
+		return p.Wrapped_expressionCount__SWIG_1(a[0].(string))
 	}
 	if argc == 2 {
-		return p.ExpressionCount__SWIG_0(a[0].(string), a[1].(string))
+		return p.Wrapped_expressionCount__SWIG_0(a[0].(string), a[1].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentExpressionCount__SWIG_0(arg2 string, arg3 string) (_swig_ret float64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentExpressionCount__SWIG_0(arg2 string, arg3 string) (_swig_ret float64) {
 	var swig_r float64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (float64)(C._wrap_QueryEnvironment_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_93)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_94)(unsafe.Pointer(&_swig_i_2))))
+	swig_r = (float64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_93)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_94)(unsafe.Pointer(&_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
@@ -1925,34 +2016,34 @@ func (arg1 SwigcptrQueryEnvironment) DocumentExpressionCount__SWIG_0(arg2 string
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentExpressionCount__SWIG_1(arg2 string) (_swig_ret float64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentExpressionCount__SWIG_1(arg2 string) (_swig_ret float64) {
 	var swig_r float64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (float64)(C._wrap_QueryEnvironment_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_95)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (float64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_95)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) DocumentExpressionCount(a ...interface{}) float64 {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_documentExpressionCount(a ...interface{}) float64 {
 	argc := len(a)
 	if argc == 1 {
-		return p.DocumentExpressionCount__SWIG_1(a[0].(string))
+		return p.Wrapped_documentExpressionCount__SWIG_1(a[0].(string))
 	}
 	if argc == 2 {
-		return p.DocumentExpressionCount__SWIG_0(a[0].(string), a[1].(string))
+		return p.Wrapped_documentExpressionCount__SWIG_0(a[0].(string), a[1].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) ExpressionList__SWIG_0(arg2 string, arg3 string) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_expressionList__SWIG_0(arg2 string, arg3 string) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryEnvironment_expressionList__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_96)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_97)(unsafe.Pointer(&_swig_i_2)))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_96)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_97)(unsafe.Pointer(&_swig_i_2)))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
@@ -1962,47 +2053,47 @@ func (arg1 SwigcptrQueryEnvironment) ExpressionList__SWIG_0(arg2 string, arg3 st
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) ExpressionList__SWIG_1(arg2 string) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_expressionList__SWIG_1(arg2 string) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryEnvironment_expressionList__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_98)(unsafe.Pointer(&_swig_i_1)))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_98)(unsafe.Pointer(&_swig_i_1)))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryEnvironment) ExpressionList(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+func (p SwigcptrWrapped_QueryEnvironment) Wrapped_expressionList(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
 	argc := len(a)
 	if argc == 1 {
-		return p.ExpressionList__SWIG_1(a[0].(string))
+		return p.Wrapped_expressionList__SWIG_1(a[0].(string))
 	}
 	if argc == 2 {
-		return p.ExpressionList__SWIG_0(a[0].(string), a[1].(string))
+		return p.Wrapped_expressionList__SWIG_0(a[0].(string), a[1].(string))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentLength(arg2 int) (_swig_ret int) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentLength(arg2 int) (_swig_ret int) {
 	var swig_r int
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int)(C._wrap_QueryEnvironment_documentLength_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1)))
+	swig_r = (int)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentLength_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1)))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) SetFormulationParameters(arg2 Wrapped_Parameters) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_setFormulationParameters(arg2 Wrapped_Parameters) {
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	C._wrap_QueryEnvironment_setFormulationParameters_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
+	C._wrap_Wrapped_QueryEnvironment_Wrapped_setFormulationParameters_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))
 }
 
-func (arg1 SwigcptrQueryEnvironment) ReformulateQuery(arg2 string) (_swig_ret string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_reformulateQuery(arg2 string) (_swig_ret string) {
 	var swig_r string
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r_p := C._wrap_QueryEnvironment_reformulateQuery_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_100)(unsafe.Pointer(&_swig_i_1)))
+	swig_r_p := C._wrap_Wrapped_QueryEnvironment_Wrapped_reformulateQuery_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_100)(unsafe.Pointer(&_swig_i_1)))
 	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
@@ -2012,11 +2103,11 @@ func (arg1 SwigcptrQueryEnvironment) ReformulateQuery(arg2 string) (_swig_ret st
 	return swig_r_1
 }
 
-func (arg1 SwigcptrQueryEnvironment) StemTerm(arg2 string) (_swig_ret string) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_stemTerm(arg2 string) (_swig_ret string) {
 	var swig_r string
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r_p := C._wrap_QueryEnvironment_stemTerm_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_102)(unsafe.Pointer(&_swig_i_1)))
+	swig_r_p := C._wrap_Wrapped_QueryEnvironment_Wrapped_stemTerm_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_102)(unsafe.Pointer(&_swig_i_1)))
 	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
@@ -2026,30 +2117,30 @@ func (arg1 SwigcptrQueryEnvironment) StemTerm(arg2 string) (_swig_ret string) {
 	return swig_r_1
 }
 
-func (arg1 SwigcptrQueryEnvironment) TermCountUnique() (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_termCountUnique() (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
-	swig_r = (int64)(C._wrap_QueryEnvironment_termCountUnique_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_termCountUnique_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0)))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) StemCount(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_stemCount(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_stemCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_105)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_stemCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_105)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) StemFieldCount(arg2 string, arg3 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_stemFieldCount(arg2 string, arg3 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (int64)(C._wrap_QueryEnvironment_stemFieldCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_107)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_108)(unsafe.Pointer(&_swig_i_2))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_stemFieldCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_107)(unsafe.Pointer(&_swig_i_1)), *(*C.swig_type_108)(unsafe.Pointer(&_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
@@ -2059,103 +2150,103 @@ func (arg1 SwigcptrQueryEnvironment) StemFieldCount(arg2 string, arg3 string) (_
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentStemCount(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentStemCount(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_documentStemCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_110)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentStemCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_110)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) Documentsdocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentsdocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_QueryEnvironment_documentsdocids_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)(SwigcptrStd_vector_Sl_indri_api_ParsedDocument_Sm__Sg_(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentsdocids_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) DocumentMetadatadocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg3 string) (_swig_ret StringVector) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_documentMetadatadocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg3 string) (_swig_ret StringVector) {
 	var swig_r StringVector
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2.Swigcptr()
 	_swig_i_2 := arg3
-	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_QueryEnvironment_documentMetadatadocids_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_111)(unsafe.Pointer(&_swig_i_2)))))
+	swig_r = (StringVector)(SwigcptrStringVector(C._wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadatadocids_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1), *(*C.swig_type_111)(unsafe.Pointer(&_swig_i_2)))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg3
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) OnetermCount(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_onetermCount(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_onetermCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_113)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_onetermCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_113)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryEnvironment) OnedocumentCount(arg2 string) (_swig_ret int64) {
+func (arg1 SwigcptrWrapped_QueryEnvironment) Wrapped_onedocumentCount(arg2 string) (_swig_ret int64) {
 	var swig_r int64
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (int64)(C._wrap_QueryEnvironment_onedocumentCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_115)(unsafe.Pointer(&_swig_i_1))))
+	swig_r = (int64)(C._wrap_Wrapped_QueryEnvironment_Wrapped_onedocumentCount_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_115)(unsafe.Pointer(&_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func DeleteQueryEnvironment(arg1 QueryEnvironment) {
+func DeleteWrapped_QueryEnvironment(arg1 Wrapped_QueryEnvironment) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_QueryEnvironment_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_Wrapped_QueryEnvironment_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-type QueryEnvironment interface {
+type Wrapped_QueryEnvironment interface {
 	Swigcptr() uintptr
-	SwigIsQueryEnvironment()
-	AddServer(arg2 string)
-	AddIndex(arg2 string)
-	RemoveServer(arg2 string)
-	RemoveIndex(arg2 string)
-	Close()
-	SetMemory(arg2 int64)
-	SetScoringRules(arg2 StringVector)
-	SetStopwords(arg2 StringVector)
-	RunQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
-	RunAnnotatedQuery(a ...interface{}) QueryAnnotation
-	RunQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)
-	RunAnnotatedQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret QueryAnnotation)
-	Documents(a ...interface{}) Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
-	DocumentMetadata(a ...interface{}) StringVector
-	DocumentIDsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret IntVector)
-	DocumentsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)
-	TermCount(a ...interface{}) int64
-	TermFieldCount(arg2 string, arg3 string) (_swig_ret int64)
-	FieldList() (_swig_ret StringVector)
-	DocumentCount(a ...interface{}) int64
-	DocumentVectors(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_)
-	ExpressionCount(a ...interface{}) float64
-	DocumentExpressionCount(a ...interface{}) float64
-	ExpressionList(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
-	DocumentLength(arg2 int) (_swig_ret int)
-	SetFormulationParameters(arg2 Wrapped_Parameters)
-	ReformulateQuery(arg2 string) (_swig_ret string)
-	StemTerm(arg2 string) (_swig_ret string)
-	TermCountUnique() (_swig_ret int64)
-	StemCount(arg2 string) (_swig_ret int64)
-	StemFieldCount(arg2 string, arg3 string) (_swig_ret int64)
-	DocumentStemCount(arg2 string) (_swig_ret int64)
-	Documentsdocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)
-	DocumentMetadatadocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg3 string) (_swig_ret StringVector)
-	OnetermCount(arg2 string) (_swig_ret int64)
-	OnedocumentCount(arg2 string) (_swig_ret int64)
+	SwigIsWrapped_QueryEnvironment()
+	Wrapped_addServer(arg2 string)
+	Wrapped_addIndex(arg2 string)
+	Wrapped_removeServer(arg2 string)
+	Wrapped_removeIndex(arg2 string)
+	Wrapped_close()
+	Wrapped_setMemory(arg2 int64)
+	Wrapped_setScoringRules(arg2 StringVector)
+	Wrapped_setStopwords(arg2 StringVector)
+	Wrapped_runQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
+	Wrapped_runAnnotatedQuery(a ...interface{}) QueryAnnotation
+	Wrapped_runQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)
+	Wrapped_runAnnotatedQuerydocset(arg2 string, arg3 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg4 int) (_swig_ret QueryAnnotation)
+	Wrapped_documents(a ...interface{}) Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_
+	Wrapped_documentMetadata(a ...interface{}) StringVector
+	Wrapped_documentIDsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret IntVector)
+	Wrapped_documentsFromMetadata(arg2 string, arg3 StringVector) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)
+	Wrapped_termCount(a ...interface{}) int64
+	Wrapped_termFieldCount(arg2 string, arg3 string) (_swig_ret int64)
+	Wrapped_fieldList() (_swig_ret StringVector)
+	Wrapped_documentCount(a ...interface{}) int64
+	Wrapped_documentVectors(arg2 IntVector) (_swig_ret Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_)
+	Wrapped_expressionCount(a ...interface{}) float64
+	Wrapped_documentExpressionCount(a ...interface{}) float64
+	Wrapped_expressionList(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
+	Wrapped_documentLength(arg2 int) (_swig_ret int)
+	Wrapped_setFormulationParameters(arg2 Wrapped_Parameters)
+	Wrapped_reformulateQuery(arg2 string) (_swig_ret string)
+	Wrapped_stemTerm(arg2 string) (_swig_ret string)
+	Wrapped_termCountUnique() (_swig_ret int64)
+	Wrapped_stemCount(arg2 string) (_swig_ret int64)
+	Wrapped_stemFieldCount(arg2 string, arg3 string) (_swig_ret int64)
+	Wrapped_documentStemCount(arg2 string) (_swig_ret int64)
+	Wrapped_documentsdocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_) (_swig_ret Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_)
+	Wrapped_documentMetadatadocids(arg2 Std_vector_Sl_lemur_api_DOCID_T_Sg_, arg3 string) (_swig_ret StringVector)
+	Wrapped_onetermCount(arg2 string) (_swig_ret int64)
+	Wrapped_onedocumentCount(arg2 string) (_swig_ret int64)
 }
 
 type SwigcptrQueryExpander uintptr
@@ -2172,38 +2263,38 @@ func DeleteQueryExpander(arg1 QueryExpander) {
 	C._wrap_delete_QueryExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-func (arg1 SwigcptrQueryExpander) RunExpandedQuery__SWIG_0(arg2 string, arg3 int, arg4 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrQueryExpander) Wrapped_runExpandedQuery__SWIG_0(arg2 string, arg3 int, arg4 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
 	_swig_i_3 := arg4
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_116)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2), C._Bool(_swig_i_3))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_116)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2), C._Bool(_swig_i_3))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (arg1 SwigcptrQueryExpander) RunExpandedQuery__SWIG_1(arg2 string, arg3 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (arg1 SwigcptrQueryExpander) Wrapped_runExpandedQuery__SWIG_1(arg2 string, arg3 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_117)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_117)(unsafe.Pointer(&_swig_i_1)), C.swig_intgo(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
 	}
 	return swig_r
 }
 
-func (p SwigcptrQueryExpander) RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+func (p SwigcptrQueryExpander) Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
 	argc := len(a)
 	if argc == 2 {
-		return p.RunExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
+		return p.Wrapped_runExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
 	}
 	if argc == 3 {
-		return p.RunExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
+		return p.Wrapped_runExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
 	}
 	panic("No match for overloaded function call")
 }
@@ -2226,33 +2317,33 @@ func (arg1 SwigcptrQueryExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_a
 type QueryExpander interface {
 	Swigcptr() uintptr
 	SwigIsQueryExpander()
-	RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
+	Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string)
 }
 
-type SwigcptrRMExpander uintptr
+type SwigcptrWrapped_RMExpander uintptr
 
-func (p SwigcptrRMExpander) Swigcptr() uintptr {
+func (p SwigcptrWrapped_RMExpander) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrRMExpander) SwigIsRMExpander() {
+func (p SwigcptrWrapped_RMExpander) SwigIsWrapped_RMExpander() {
 }
 
-func NewRMExpander(arg1 QueryEnvironment, arg2 Wrapped_Parameters) (_swig_ret RMExpander) {
-	var swig_r RMExpander
+func NewWrapped_RMExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) (_swig_ret Wrapped_RMExpander) {
+	var swig_r Wrapped_RMExpander
 	_swig_i_0 := arg1.Swigcptr()
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (RMExpander)(SwigcptrRMExpander(C._wrap_new_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Wrapped_RMExpander)(SwigcptrWrapped_RMExpander(C._wrap_new_Wrapped_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (arg1 SwigcptrRMExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string) {
+func (arg1 SwigcptrWrapped_RMExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string) {
 	var swig_r string
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
-	swig_r_p := C._wrap_RMExpander_expand_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_121)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))
+	swig_r_p := C._wrap_Wrapped_RMExpander_expand_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_121)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))
 	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
@@ -2262,84 +2353,84 @@ func (arg1 SwigcptrRMExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_api_
 	return swig_r_1
 }
 
-func DeleteRMExpander(arg1 RMExpander) {
+func DeleteWrapped_RMExpander(arg1 Wrapped_RMExpander) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_Wrapped_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-func (_swig_base SwigcptrRMExpander) RunExpandedQuery__SWIG_0(arg1 string, arg2 int, arg3 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (_swig_base SwigcptrWrapped_RMExpander) Wrapped_runExpandedQuery__SWIG_0(arg1 string, arg2 int, arg3 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_RMExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_122)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1), C._Bool(_swig_i_2))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_122)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1), C._Bool(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg1
 	}
 	return swig_r
 }
 
-func (_swig_base SwigcptrRMExpander) RunExpandedQuery__SWIG_1(arg1 string, arg2 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (_swig_base SwigcptrWrapped_RMExpander) Wrapped_runExpandedQuery__SWIG_1(arg1 string, arg2 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_RMExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_123)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_123)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg1
 	}
 	return swig_r
 }
 
-func (p SwigcptrRMExpander) RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+func (p SwigcptrWrapped_RMExpander) Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
 	argc := len(a)
 	if argc == 2 {
-		return p.RunExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
+		return p.Wrapped_runExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
 	}
 	if argc == 3 {
-		return p.RunExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
+		return p.Wrapped_runExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (p SwigcptrRMExpander) SwigIsQueryExpander() {
+func (p SwigcptrWrapped_RMExpander) SwigIsQueryExpander() {
 }
 
-func (p SwigcptrRMExpander) SwigGetQueryExpander() QueryExpander {
+func (p SwigcptrWrapped_RMExpander) SwigGetQueryExpander() QueryExpander {
 	return SwigcptrQueryExpander(p.Swigcptr())
 }
 
-type RMExpander interface {
+type Wrapped_RMExpander interface {
 	Swigcptr() uintptr
-	SwigIsRMExpander()
+	SwigIsWrapped_RMExpander()
 	Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string)
-	RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
+	Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	SwigIsQueryExpander()
 	SwigGetQueryExpander() QueryExpander
 }
 
-type SwigcptrPonteExpander uintptr
+type SwigcptrWrapped_PonteExpander uintptr
 
-func (p SwigcptrPonteExpander) Swigcptr() uintptr {
+func (p SwigcptrWrapped_PonteExpander) Swigcptr() uintptr {
 	return (uintptr)(p)
 }
 
-func (p SwigcptrPonteExpander) SwigIsPonteExpander() {
+func (p SwigcptrWrapped_PonteExpander) SwigIsWrapped_PonteExpander() {
 }
 
-func NewPonteExpander(arg1 QueryEnvironment, arg2 Wrapped_Parameters) (_swig_ret PonteExpander) {
-	var swig_r PonteExpander
+func NewWrapped_PonteExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) (_swig_ret Wrapped_PonteExpander) {
+	var swig_r Wrapped_PonteExpander
 	_swig_i_0 := arg1.Swigcptr()
 	_swig_i_1 := arg2.Swigcptr()
-	swig_r = (PonteExpander)(SwigcptrPonteExpander(C._wrap_new_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
+	swig_r = (Wrapped_PonteExpander)(SwigcptrWrapped_PonteExpander(C._wrap_new_Wrapped_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.uintptr_t(_swig_i_1))))
 	return swig_r
 }
 
-func (arg1 SwigcptrPonteExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string) {
+func (arg1 SwigcptrWrapped_PonteExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string) {
 	var swig_r string
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3.Swigcptr()
-	swig_r_p := C._wrap_PonteExpander_expand_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_125)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))
+	swig_r_p := C._wrap_Wrapped_PonteExpander_expand_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), *(*C.swig_type_125)(unsafe.Pointer(&_swig_i_1)), C.uintptr_t(_swig_i_2))
 	swig_r = *(*string)(unsafe.Pointer(&swig_r_p))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg2
@@ -2349,57 +2440,57 @@ func (arg1 SwigcptrPonteExpander) Expand(arg2 string, arg3 Std_vector_Sl_indri_a
 	return swig_r_1
 }
 
-func DeletePonteExpander(arg1 PonteExpander) {
+func DeleteWrapped_PonteExpander(arg1 Wrapped_PonteExpander) {
 	_swig_i_0 := arg1.Swigcptr()
-	C._wrap_delete_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
+	C._wrap_delete_Wrapped_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0))
 }
 
-func (_swig_base SwigcptrPonteExpander) RunExpandedQuery__SWIG_0(arg1 string, arg2 int, arg3 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (_swig_base SwigcptrWrapped_PonteExpander) Wrapped_runExpandedQuery__SWIG_0(arg1 string, arg2 int, arg3 bool) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
 	_swig_i_2 := arg3
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_PonteExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_122)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1), C._Bool(_swig_i_2))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_122)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1), C._Bool(_swig_i_2))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg1
 	}
 	return swig_r
 }
 
-func (_swig_base SwigcptrPonteExpander) RunExpandedQuery__SWIG_1(arg1 string, arg2 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+func (_swig_base SwigcptrWrapped_PonteExpander) Wrapped_runExpandedQuery__SWIG_1(arg1 string, arg2 int) (_swig_ret Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
 	var swig_r Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	_swig_i_0 := arg1
 	_swig_i_1 := arg2
-	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_PonteExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_123)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1))))
+	swig_r = (Std_vector_Sl_indri_api_ScoredExtentResult_Sg_)(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C._wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(C.uintptr_t(_swig_base), *(*C.swig_type_123)(unsafe.Pointer(&_swig_i_0)), C.swig_intgo(_swig_i_1))))
 	if Swig_escape_always_false {
 		Swig_escape_val = arg1
 	}
 	return swig_r
 }
 
-func (p SwigcptrPonteExpander) RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+func (p SwigcptrWrapped_PonteExpander) Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
 	argc := len(a)
 	if argc == 2 {
-		return p.RunExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
+		return p.Wrapped_runExpandedQuery__SWIG_1(a[0].(string), a[1].(int))
 	}
 	if argc == 3 {
-		return p.RunExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
+		return p.Wrapped_runExpandedQuery__SWIG_0(a[0].(string), a[1].(int), a[2].(bool))
 	}
 	panic("No match for overloaded function call")
 }
 
-func (p SwigcptrPonteExpander) SwigIsQueryExpander() {
+func (p SwigcptrWrapped_PonteExpander) SwigIsQueryExpander() {
 }
 
-func (p SwigcptrPonteExpander) SwigGetQueryExpander() QueryExpander {
+func (p SwigcptrWrapped_PonteExpander) SwigGetQueryExpander() QueryExpander {
 	return SwigcptrQueryExpander(p.Swigcptr())
 }
 
-type PonteExpander interface {
+type Wrapped_PonteExpander interface {
 	Swigcptr() uintptr
-	SwigIsPonteExpander()
+	SwigIsWrapped_PonteExpander()
 	Expand(arg2 string, arg3 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret string)
-	RunExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
+	Wrapped_runExpandedQuery(a ...interface{}) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_
 	SwigIsQueryExpander()
 	SwigGetQueryExpander() QueryExpander
 }
@@ -3152,21 +3243,157 @@ type Wrapped_IndexEnvironment interface {
 func catch(err *error) {
     if r := recover(); r != nil {
         var sf []string = []string{}
-        var pc []uintptr
-        var skip, fc int = 0, 0
+        var pc []uintptr = make([]uintptr, 64)
+        var skip, fc int = 2, 0
         fc = runtime.Callers(skip, pc)
         if fc > 0 {
-            fp := runtime.CallersFrames(pc)
+            fp := runtime.CallersFrames(pc[:fc])
             if fp != nil {
-                var more bool
+                var more bool = true
                 var f runtime.Frame
-                for more = true; more; f, more = fp.Next() {
-                    sf = append(sf, fmt.Sprintf("%v %v %v %v\n", f.PC, f.Function, f.File, f.Line))
+                for more {
+                    f, more = fp.Next()
+                    sf = append(sf, fmt.Sprintf("%v %v %v %v", f.PC, f.Function, f.File, f.Line))
                 }
             }
         }
-        *err = fmt.Errorf("Panic stack %v, error %v", sf, r)
+        *err = newPanicError(r, sf)
+    }
+}
+
+
+
+
+//
+//  structured errors
+//
+// catch returns a *LemurError for a lemur::api::Exception and a *PanicError
+// for any other panic. The kinds of lemur error below are told apart by the
+// exception code and message, test them with errors.Is.
+//
+
+var (
+    ErrRepositoryLocked = errors.New("indri_go: repository locked")
+    ErrRepositoryNotFound = errors.New("indri_go: repository not found")
+    ErrParse = errors.New("indri_go: parse error")
+    ErrIO = errors.New("indri_go: i/o error")
+    ErrBadParameter = errors.New("indri_go: bad parameter")
+    ErrUnknownFileClass = errors.New("indri_go: unknown file class")
+)
+
+//
+// LemurError is a lemur::api::Exception. Code is the name of its
+// LemurErrorType, such as LEMUR_IO_ERROR, and Location the C++ file and
+// line it was thrown from. Kind is one of the Err values above, or nil.
+// Stack is the go stack where it was recovered.
+//
+type LemurError struct {
+    Code string
+    Location string
+    Message string
+    Kind error
+    Stack []string
+}
+
+func (e *LemurError) Error() string {
+    if e.Location == "" {
+        return e.Message
+    }
+    return e.Location + ": " + e.Message
+}
+
+func (e *LemurError) Unwrap() error {
+    return e.Kind
+}
+
+//
+// PanicError is any other panic recovered by catch, a C++ exception that
+// is not a lemur::api::Exception or a go panic.
+//
+type PanicError struct {
+    Value interface{}
+    Stack []string
+}
+
+func (e *PanicError) Error() string {
+    return fmt.Sprint(e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+    err, _ := e.Value.(error)
+    return err
+}
+
+const lemurExceptionPrefix = "lemur exception "
+
+// newPanicError makes the error catch returns for the recovered value r
+func newPanicError(r interface{}, stack []string) error {
+    s, ok := r.(string)
+    if !ok {
+        if e, isError := r.(error); isError {
+            s, ok = e.Error(), true
+        }
+    }
+    if !ok || !strings.HasPrefix(s, lemurExceptionPrefix) {
+        return &PanicError{Value: r, Stack: stack}
+    }
+
+    // lemur exception CODE: location: message
+    s = strings.TrimPrefix(s, lemurExceptionPrefix)
+    e := &LemurError{Stack: stack}
+    if i := strings.Index(s, ": "); i >= 0 {
+        e.Code, s = s[:i], s[i+2:]
+    }
+    if i := strings.Index(s, ": "); i >= 0 && !strings.ContainsAny(s[:i], " \n") {
+        e.Location, s = s[:i], s[i+2:]
+    }
+    e.Message = s
+    e.Kind = lemurErrorKind(e.Code, e.Message)
+    return e
+}
+
+// the exact messages, by prefix, indri throws for the kinds below
+const (
+    // Parameters::load of the manifest of a repository that does not exist
+    lemurMissingManifestPrefix = "Couldn't open parameter file '"
+    lemurMissingManifestSuffix = "manifest' for reading."
+    // FileClassEnvironmentFactory of an unknown file class
+    lemurUnknownFileClassPrefix = "File class '"
+)
+
+// lemurLockedPrefixes start the messages of a repository held by another writer
+var lemurLockedPrefixes = []string{
+    "Unable to lock ",
+    "repository is locked",
+}
+
+//
+// lemurErrorKind maps an exception code and message to one of the Err
+// values. The code decides, the message only picks out the errors indri
+// throws with a known message under that code.
+//
+func lemurErrorKind(code, message string) error {
+    switch code {
+    case "LEMUR_IO_ERROR", "LEMUR_KEYFILE_IO_ERROR":
+        if strings.HasPrefix(message, lemurMissingManifestPrefix) && strings.HasSuffix(message, lemurMissingManifestSuffix) {
+            return ErrRepositoryNotFound
+        }
+        for _, prefix := range lemurLockedPrefixes {
+            if strings.HasPrefix(message, prefix) {
+                return ErrRepositoryLocked
+            }
+        }
+        return ErrIO
+    case "LEMUR_RUNTIME_ERROR":
+        if strings.HasPrefix(message, lemurUnknownFileClassPrefix) {
+            return ErrUnknownFileClass
+        }
+    case "LEMUR_PARSE_ERROR":
+        return ErrParse
+    case "LEMUR_BAD_PARAMETER_ERROR", "LEMUR_MISSING_PARAMETER_ERROR":
+        return ErrBadParameter
     }
+    return nil
 }
 
 
@@ -3227,14 +3454,32 @@ type IndexEnvironment interface {
 	Open(a ...interface{}) (err error)
 	Close() (err error)
 	AddFile(a ...interface{}) (err error)
+	AddFileContext(ctx context.Context, a ...interface{}) (err error)
 	AddString(arg2 string, arg3 string, arg4 MetadataPairVector) (_swig_ret int, err error)
 	AddParsedDocument(arg2 ParsedDocument) (_swig_ret int, err error)
+	AddDocument(arg2 string, arg3 string, arg4 map[string]string) (_swig_ret int, err error)
+	DocumentIDsFromDocno(arg2 string) (_swig_ret []int, err error)
+	DocumentExists(arg2 int) (_swig_ret bool, err error)
+	UpsertDocument(docno string, text string, fileClass string, metadata map[string]string) (docid int, replaced []int, err error)
+	DeleteByDocno(docno string) (deleted []int, err error)
 	DocumentsIndexed() (_swig_ret int, err error)
 	DocumentsSeen() (_swig_ret int, err error)
 }
 
+//
+// indexEnvironment is the IndexEnvironment NewIndexEnvironment returns. It
+// overrides the methods that open, close and add files, to keep the
+// indri_go_cancel_status the repository was created or opened with.
+//
+type indexEnvironment struct {
+    SwigcptrWrapped_IndexEnvironment
+
+    mu sync.Mutex
+    status C.uintptr_t
+}
+
 func NewIndexEnvironment() IndexEnvironment {
-    return (IndexEnvironment)(SwigcptrWrapped_IndexEnvironment(C._wrap_new_Wrapped_IndexEnvironment_indri_go_add17ee78870902e()))
+    return &indexEnvironment{SwigcptrWrapped_IndexEnvironment: SwigcptrWrapped_IndexEnvironment(C._wrap_new_Wrapped_IndexEnvironment_indri_go_add17ee78870902e())}
 }
 
 func (e SwigcptrWrapped_IndexEnvironment) SetDocumentRoot(arg2 string) (err error) {
@@ -3359,39 +3604,80 @@ func (e SwigcptrWrapped_IndexEnvironment) SetStoreDocs(arg2 bool) (err error) {
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Create(a ...interface{}) (err error) {
+func (e *indexEnvironment) cancelStatus() (status C.uintptr_t, ok bool) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    return e.status, e.status != 0
+}
+
+func (e *indexEnvironment) setCancelStatus(status C.uintptr_t) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    if e.status != 0 {
+        C.indri_go_cancel_status_delete(e.status)
+    }
+    e.status = status
+}
+
+//
+// withCancelStatus wraps next, which may be nil, in a new cancel status,
+// passes it to create or open and keeps it once the repository is open.
+//
+func (e *indexEnvironment) withCancelStatus(next interface{}, open func(status IndexStatus)) {
+    var p C.uintptr_t
+    if next != nil {
+        p = C.uintptr_t(next.(IndexStatus).Swigcptr())
+    }
+    status := C.indri_go_cancel_status_new(p)
+    opened := false
+    defer func() {
+        if !opened {
+            C.indri_go_cancel_status_delete(status)
+        }
+    }()
+    open(SwigcptrIndexStatus(status))
+    opened = true
+    e.setCancelStatus(status)
+}
+
+func (e *indexEnvironment) Create(a ...interface{}) (err error) {
     defer catch(&err)
     argc := len(a)
-	if argc == 1 {
-		e.Wrapped_create(a[0].(string))
-		return
-	}
-	if argc == 2 {
-		e.Wrapped_create(a[0].(string), a[1].(IndexStatus))
+	if argc == 1 || argc == 2 {
+		var next interface{}
+		if argc == 2 {
+			next = a[1]
+		}
+		e.withCancelStatus(next, func(status IndexStatus) {
+			e.Wrapped_create(a[0].(string), status)
+		})
 		return
 	}
 	panic("No match for overloaded function call")
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Open(a ...interface{}) (err error) {
+func (e *indexEnvironment) Open(a ...interface{}) (err error) {
     defer catch(&err)
     argc := len(a)
-	if argc == 1 {
-		e.Wrapped_open(a[0].(string))
-		return
-	}
-	if argc == 2 {
-		e.Wrapped_open(a[0].(string), a[1].(IndexStatus))
+	if argc == 1 || argc == 2 {
+		var next interface{}
+		if argc == 2 {
+			next = a[1]
+		}
+		e.withCancelStatus(next, func(status IndexStatus) {
+			e.Wrapped_open(a[0].(string), status)
+		})
 		return
 	}
 	panic("No match for overloaded function call")
     return
 }
 
-func (e SwigcptrWrapped_IndexEnvironment) Close() (err error) {
+func (e *indexEnvironment) Close() (err error) {
     defer catch(&err)
     e.Wrapped_close()
+    e.setCancelStatus(0)
     return
 }
 
@@ -3410,6 +3696,48 @@ func (e SwigcptrWrapped_IndexEnvironment) AddFile(a ...interface{}) (err error)
     return
 }
 
+// cancelCheck is the AddFileContext check on each status call
+type cancelCheck struct {
+    ctx context.Context
+    status C.uintptr_t
+}
+
+func (c *cancelCheck) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    if c.ctx.Err() != nil {
+        C.indri_go_cancel_status_cancel(c.status)
+    }
+}
+
+//
+// AddFileContext takes the same arguments as AddFile. ctx is checked on
+// each status call, once it is done the file is abandoned after the
+// document being indexed, documents already added are kept and ctx.Err()
+// is returned. The repository stays open.
+//
+func (e *indexEnvironment) AddFileContext(ctx context.Context, a ...interface{}) (err error) {
+    if err = ctx.Err(); err != nil {
+        return
+    }
+    status, ok := e.cancelStatus()
+    if !ok {
+        // not open, let AddFile report it
+        return e.AddFile(a...)
+    }
+
+    check := NewDirectorIndexStatus(&cancelCheck{ctx: ctx, status: status})
+    C.indri_go_cancel_status_check(status, C.uintptr_t(check.Swigcptr()))
+    defer func() {
+        C.indri_go_cancel_status_check(status, 0)
+        DeleteDirectorIndexStatus(check)
+    }()
+
+    err = e.AddFile(a...)
+    if err != nil && ctx.Err() != nil {
+        err = ctx.Err()
+    }
+    return
+}
+
 func (e SwigcptrWrapped_IndexEnvironment) AddString(arg2 string, arg3 string, arg4 MetadataPairVector) (_swig_ret int, err error) {
     defer catch(&err)
     _swig_ret = e.Wrapped_addString(arg2, arg3, arg4)
@@ -3422,6 +3750,105 @@ func (e SwigcptrWrapped_IndexEnvironment) AddParsedDocument(arg2 ParsedDocument)
     return
 }
 
+//
+// AddDocument adds a document held in text, parsed with fileClass, and
+// attaches metadata to it. It is AddString without the MetadataPairVector,
+// the metadata is copied into C++ in one call.
+//
+func (e SwigcptrWrapped_IndexEnvironment) AddDocument(arg2 string, arg3 string, arg4 map[string]string) (_swig_ret int, err error) {
+    defer catch(&err)
+
+    var metadata []byte
+    lengths := make([]C.swig_intgo, 0, 2 * len(arg4))
+    for k, v := range arg4 {
+        metadata = append(metadata, k...)
+        metadata = append(metadata, v...)
+        lengths = append(lengths, C.swig_intgo(len(k)), C.swig_intgo(len(v)))
+    }
+    var plengths *C.swig_intgo
+    if len(lengths) > 0 {
+        plengths = &lengths[0]
+    }
+
+    _swig_ret = int(C.indri_go_index_environment_add_document(C.uintptr_t(e), gostring(arg2), gostring(arg3), gostring(string(metadata)), plengths, C.swig_intgo(len(arg4))))
+    if Swig_escape_always_false {
+        Swig_escape_val = arg2
+        Swig_escape_val = arg3
+    }
+    return
+}
+
+//
+// DocumentIDsFromDocno returns the ids of the documents in the open
+// repository with the docno arg2. Deleted documents, which keep their
+// docno metadata until the repository is compacted, are left out.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DocumentIDsFromDocno(arg2 string) (_swig_ret []int, err error) {
+    defer catch(&err)
+    ids := SwigcptrIntVector(C.indri_go_index_environment_document_ids(C.uintptr_t(e), gostring("docno"), gostring(arg2)))
+    _swig_ret = takeInts(ids)
+    if Swig_escape_always_false {
+        Swig_escape_val = arg2
+    }
+    return
+}
+
+//
+// DocumentExists reports whether arg2 is the id of a document of the open
+// repository that is not deleted.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DocumentExists(arg2 int) (_swig_ret bool, err error) {
+    defer catch(&err)
+    _swig_ret = C.indri_go_index_environment_document_live(C.uintptr_t(e), C.swig_intgo(arg2)) != 0
+    return
+}
+
+//
+// UpsertDocument adds text as the document docno, replacing any document
+// already indexed with that docno. docno is added to metadata. It returns
+// the id of the new document and the ids of the replaced documents, which
+// are deleted once the new one is added.
+//
+func (e SwigcptrWrapped_IndexEnvironment) UpsertDocument(docno string, text string, fileClass string, metadata map[string]string) (docid int, replaced []int, err error) {
+    if replaced, err = e.DocumentIDsFromDocno(docno); err != nil {
+        return
+    }
+
+    m := make(map[string]string, len(metadata) + 1)
+    for k, v := range metadata {
+        m[k] = v
+    }
+    m["docno"] = docno
+    if docid, err = e.AddDocument(text, fileClass, m); err != nil {
+        return
+    }
+
+    for _, id := range replaced {
+        if err = e.DeleteDocument(id); err != nil {
+            return
+        }
+    }
+    return
+}
+
+//
+// DeleteByDocno deletes the documents indexed with docno and returns their
+// ids, none when there are no such documents.
+//
+func (e SwigcptrWrapped_IndexEnvironment) DeleteByDocno(docno string) (deleted []int, err error) {
+    ids, err := e.DocumentIDsFromDocno(docno)
+    if err != nil {
+        return
+    }
+    for _, id := range ids {
+        if err = e.DeleteDocument(id); err != nil {
+            return
+        }
+        deleted = append(deleted, id)
+    }
+    return
+}
+
 func (e SwigcptrWrapped_IndexEnvironment) DocumentsIndexed() (_swig_ret int, err error) {
     defer catch(&err)
     _swig_ret = e.Wrapped_documentsIndexed()
@@ -3469,16 +3896,8 @@ func (om *overwrittenMethodsOnIndexStatus) Status( code int, documentFile string
 
     // C++ code defines class local enums, method signature uses int for code
     // swiggo generates constants we cannot use because of signature mismatch
-    // note: must match C++ definition
-    const (
-        FileOpen = iota
-        FileSkip
-        FileError
-        FileClose
-        DocumentCount
-    )
-
-    switch(code) {
+    // so we switch on the go IndexEventKind copy of the enum
+    switch(IndexEventKind(code)) {
     case FileOpen:
         Buildindex_print_event( fmt.Sprintf("Opened %v", documentFile) )
 
@@ -3517,6 +3936,136 @@ func DeleteMyStatusMonitor(isgs MyStatusMonitor) {
     isgs.DeleteIndexStatus()
 }
 
+//
+// IndexEventKind mirrors the C++ indri::api::IndexStatus::action_code enum.
+// note: must match C++ definition
+//
+type IndexEventKind int
+
+const (
+    FileOpen IndexEventKind = iota
+    FileSkip
+    FileError
+    FileClose
+    DocumentCount
+)
+
+func (k IndexEventKind) String() string {
+    switch k {
+    case FileOpen:
+        return "FileOpen"
+    case FileSkip:
+        return "FileSkip"
+    case FileError:
+        return "FileError"
+    case FileClose:
+        return "FileClose"
+    case DocumentCount:
+        return "DocumentCount"
+    }
+    return fmt.Sprintf("IndexEventKind(%d)", int(k))
+}
+
+// IndexEvent is one IndexStatus.Status call
+type IndexEvent struct {
+    Kind IndexEventKind
+    Path string
+    Error string
+    DocumentsIndexed int
+    DocumentsSeen int
+}
+
+//
+// IndexStatusChannel is an IndexStatus that sends each status call as an
+// IndexEvent on a channel, see NewIndexStatusChannel.
+//
+type IndexStatusChannel interface {
+    MyStatusMonitor
+}
+
+type indexStatusChannel struct {
+    IndexStatus
+}
+
+// the channel is closed first, releasing a Status call blocked on it
+func (isc *indexStatusChannel) DeleteIndexStatus() {
+    isc.IndexStatus.DirectorInterface().(*overwrittenMethodsOnIndexStatusChannel).close()
+    DeleteDirectorIndexStatus(isc.IndexStatus)
+}
+
+func (isc *indexStatusChannel) IsIndexStatus() {}
+
+//
+// overwrittenMethodsOnIndexStatusChannel sends events while holding mu for
+// reading, and close takes it for writing to close events. done is closed
+// first so a send blocked on a consumer that stopped reading gives up.
+//
+type overwrittenMethodsOnIndexStatusChannel struct {
+    events chan IndexEvent
+    done chan struct{}
+    stop sync.Once
+    mu sync.RWMutex
+    closed bool
+}
+
+//
+// Status blocks on a full channel for file events, so none are lost while
+// the channel is read. DocumentCount events carry running totals and are
+// dropped instead, the next one sent makes up for them.
+//
+func (om *overwrittenMethodsOnIndexStatusChannel) Status( code int, documentFile string, error string, documentsIndexed int, documentsSeen int ) {
+    om.mu.RLock()
+    defer om.mu.RUnlock()
+    if om.closed {
+        return
+    }
+
+    event := IndexEvent{
+        Kind: IndexEventKind(code),
+        Path: documentFile,
+        Error: error,
+        DocumentsIndexed: documentsIndexed,
+        DocumentsSeen: documentsSeen,
+    }
+    if event.Kind == DocumentCount {
+        select {
+        case om.events <- event:
+        default:
+        }
+        return
+    }
+    select {
+    case om.events <- event:
+    case <-om.done:
+    }
+}
+
+func (om *overwrittenMethodsOnIndexStatusChannel) close() {
+    om.stop.Do(func() { close(om.done) })
+    om.mu.Lock()
+    defer om.mu.Unlock()
+    if !om.closed {
+        om.closed = true
+        close(om.events)
+    }
+}
+
+//
+// NewIndexStatusChannel returns an IndexStatus for IndexEnvironment Create
+// and Open, and the channel its events are sent on. buffer is the channel
+// capacity. The channel must be drained while documents are added, and is
+// closed by DeleteIndexStatusChannel.
+//
+func NewIndexStatusChannel(buffer int) (IndexStatusChannel, <-chan IndexEvent) {
+    om := &overwrittenMethodsOnIndexStatusChannel{events: make(chan IndexEvent, buffer), done: make(chan struct{})}
+    isc := &indexStatusChannel{IndexStatus: NewDirectorIndexStatus(om)}
+    return isc, om.events
+}
+
+func DeleteIndexStatusChannel(isc IndexStatusChannel) {
+    isc.DeleteIndexStatus()
+}
+
 
 
 
@@ -3549,202 +4098,3601 @@ func Wrapped_Buildindex_mymain(argc int, arg0, arg1, arg2, arg3, arg4 string) (e
     return
 }
 
+//
+// BuildContext builds the repository described by cfg the way
+// IndriBuildIndex does, using AddFileContext for each corpus file. When
+// ctx is done the build stops after the document being indexed, the
+// repository is closed with the documents indexed so far and ctx.Err()
+// is returned. status may be nil, a file that fails is then printed and
+// the build goes on with the next file, as IndriBuildIndex does.
+//
+func BuildContext(ctx context.Context, cfg IndexConfig, status IndexStatus) (err error) {
+    if status == nil {
+        printer := NewDirectorIndexStatus(fileErrorPrinter{})
+        defer DeleteDirectorIndexStatus(printer)
+        status = printer
+    }
+    return build(ctx, cfg, status, nil, nil)
+}
 
+// fileErrorPrinter is the IndexStatus of BuildContext without one
+type fileErrorPrinter struct{}
 
+func (fileErrorPrinter) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    if IndexEventKind(code) == FileError {
+        Buildindex_print_event(fmt.Sprintf("Error in %v : %v", documentFile, error))
+    }
+}
 
 //
-//  extend MetadataPair.i
+// BuildOptions selects corpus files for BuildIndex. A pattern containing
+// a separator is matched against the path relative to the corpus path,
+// any other pattern against the file name, see filepath.Match. With
+// Include set only files matching one of its patterns are indexed, files
+// matching an Exclude pattern never are. Status may be nil.
 //
+type BuildOptions struct {
+    Include []string
+    Exclude []string
+    Status IndexStatus
+}
 
-type MetadataPair interface {
-    Wrapped_MetadataPair
-
-    WSetKey(arg2 string) (err error)
-	WGetKey() (_swig_ret string, err error)
-	WSetValue(arg2 []byte) (err error)
-	WGetValue() (_swig_ret []byte, err error)
-
-    WReset()
-    WClear() (err error)
+// BuildFile is a corpus file BuildIndex did not index, and why
+type BuildFile struct {
+    Path string
+    Reason string
 }
 
-func NewMetadataPair() (_swig_ret MetadataPair, err error) {
-    _swig_ret = (MetadataPair)(SwigcptrWrapped_MetadataPair(C._wrap_new_Wrapped_MetadataPair_indri_go_add17ee78870902e()))
-    if _swig_ret == nil {
-        return
-    }
-    _swig_ret.WReset()
-	return
+//
+// BuildReport is the outcome of BuildIndex. FilesSeen counts every corpus
+// file, FilesSkipped and FilesFailed list those that were not indexed.
+//
+type BuildReport struct {
+    FilesSeen int
+    FilesSkipped []BuildFile
+    FilesFailed []BuildFile
+    DocumentsIndexed int
+    DocumentsSeen int
+    Elapsed time.Duration
 }
 
-func DeleteMetadataPair(arg1 MetadataPair) {
+//
+// BuildIndex builds the repository described by cfg, as BuildContext
+// does, and reports on each corpus file. A file that fails to parse is
+// reported in FilesFailed and the build goes on with the next file, as
+// IndriBuildIndex does. The report is returned with the error, covering
+// the files reached before it.
+//
+func BuildIndex(ctx context.Context, cfg IndexConfig, opts BuildOptions) (report BuildReport, err error) {
+    start := time.Now()
+    defer func() {
+        report.Elapsed = time.Since(start)
+    }()
+
+    for _, pattern := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+        if _, e := filepath.Match(pattern, ""); e != nil {
+            err = fmt.Errorf("bad file pattern %q: %v", pattern, e)
+            return
+        }
+    }
 
-    // don't leak resource
-    arg1.WClear()
+    r := &buildRecorder{ctx: ctx, report: &report, next: opts.Status}
+    status := NewDirectorIndexStatus(r)
+    defer DeleteDirectorIndexStatus(status)
 
-    DeleteWrapped_MetadataPair(arg1)
+    filter := func(root, path string) bool {
+        report.FilesSeen++
+        reason := opts.filter(root, path)
+        if reason != "" {
+            report.FilesSkipped = append(report.FilesSkipped, BuildFile{Path: path, Reason: reason})
+        }
+        return reason == ""
+    }
+    counts := func(env IndexEnvironment) {
+        report.DocumentsIndexed, _ = env.DocumentsIndexed()
+        report.DocumentsSeen, _ = env.DocumentsSeen()
+    }
+    err = build(ctx, cfg, status, filter, counts)
     return
 }
 
-func (m SwigcptrWrapped_MetadataPair) WReset() {
-    var zvs string
-    var zvv uintptr = 0
+// filter returns why path is not indexed, or "" when it is
+func (opts BuildOptions) filter(root, path string) string {
+    name := filepath.Base(path)
+    rel, e := filepath.Rel(root, path)
+    if e != nil || rel == "." {
+        rel = name
+    }
+    match := func(pattern string) bool {
+        target := name
+        if strings.ContainsRune(pattern, '/') || strings.ContainsRune(pattern, filepath.Separator) {
+            target = filepath.ToSlash(rel)
+            pattern = filepath.ToSlash(pattern)
+        }
+        ok, _ := filepath.Match(pattern, target)
+        return ok
+    }
+    for _, pattern := range opts.Exclude {
+        if match(pattern) {
+            return "excluded by " + pattern
+        }
+    }
+    if len(opts.Include) == 0 {
+        return ""
+    }
+    for _, pattern := range opts.Include {
+        if match(pattern) {
+            return ""
+        }
+    }
+    return "not included"
+}
 
-    // initialize key/value to zero values
-    m.SetKey(zvs)
-    m.SetValue(zvv)
-    m.SetValueLength(0)
+//
+// buildRecorder is the IndexStatus BuildIndex opens the repository with.
+// It records skipped and failed files and passes every call on to next.
+//
+type buildRecorder struct {
+    ctx context.Context
+    report *BuildReport
+    next IndexStatus
+}
 
-    return
+func (r *buildRecorder) Status(code int, documentFile string, error string, documentsIndexed int, documentsSeen int) {
+    switch IndexEventKind(code) {
+    case FileSkip:
+        r.report.FilesSkipped = append(r.report.FilesSkipped, BuildFile{Path: documentFile, Reason: "no file class"})
+    case FileError:
+        // a cancelled file is abandoned, not failed
+        if r.ctx.Err() == nil {
+            r.report.FilesFailed = append(r.report.FilesFailed, BuildFile{Path: documentFile, Reason: error})
+        }
+    }
+    if r.next != nil {
+        r.next.Status(code, documentFile, error, documentsIndexed, documentsSeen)
+    }
 }
 
-func (m SwigcptrWrapped_MetadataPair) WClear() (err error) {
+//
+// build is BuildContext and BuildIndex. filter, when not nil, is asked
+// whether to index each corpus file, and counts, when not nil, is called
+// with the open repository once the corpora are added.
+//
+func build(ctx context.Context, cfg IndexConfig, status IndexStatus, filter corpusFilter, counts func(env IndexEnvironment)) (err error) {
+    defer catch(&err)
+
+    if cfg.Index == "" {
+        return fmt.Errorf("Must specify a index parameter.")
+    }
+    if len(cfg.Corpora) == 0 {
+        return fmt.Errorf("Must specify a corpus parameter.")
+    }
+    if err = ctx.Err(); err != nil {
+        return
+    }
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = Configure(env, cfg); err != nil {
+        return
+    }
+    if err = openRepository(env, cfg.Index, status); err != nil {
+        return
+    }
+    defer func() {
+        if counts != nil {
+            counts(env)
+        }
+        if e := env.Close(); err == nil {
+            err = e
+        }
+    }()
+
+    b := newSpecAugmenter(cfg)
+    defer b.delete()
+
+    for _, corpus := range cfg.Corpora {
+        if err = addCorpus(ctx, env, b, corpus, filter); err != nil {
+            return
+        }
+    }
+    return
+}
+
+//
+// openRepository opens an existing repository, recovering it after an
+// indexing crash, or creates a new one.
+//
+func openRepository(env IndexEnvironment, path string, status IndexStatus) (err error) {
+    args := []interface{}{path}
+    if status != nil {
+        args = append(args, status)
+    }
+    if _, e := os.Stat(filepath.Join(path, "manifest")); e == nil {
+        var recovered bool
+        if recovered, err = Wrapped_Buildindex_recoverRepository(path); err != nil {
+            return
+        }
+        if recovered {
+            if err = env.Open(args...); err == nil {
+                Buildindex_print_event("Opened repository " + path)
+            }
+            return
+        }
+    }
+    // create will remove any cruft.
+    if err = env.Create(args...); err == nil {
+        Buildindex_print_event("Created repository " + path)
+    }
+    return
+}
+
+//
+// specAugmenter adds the configured fields and metadata to file class
+// specifications, as augmentSpec does in IndriBuildIndex.
+//
+type specAugmenter struct {
+    fields, metadata, forward, backward StringVector
+}
+
+func newSpecAugmenter(cfg IndexConfig) *specAugmenter {
+    fields := make([]string, len(cfg.Fields))
+    for i, f := range cfg.Fields {
+        fields[i] = strings.ToLower(f.Name)
+    }
+    metadata := make([]string, len(cfg.Metadata.Fields))
+    for i, m := range cfg.Metadata.Fields {
+        metadata[i] = strings.ToLower(m)
+    }
+    forward, backward := cfg.metadataIndexedFields()
+    return &specAugmenter{
+        fields: newStringVector(fields),
+        metadata: newStringVector(metadata),
+        forward: newStringVector(forward),
+        backward: newStringVector(backward),
+    }
+}
+
+func (b *specAugmenter) delete() {
+    DeleteStringVector(b.fields)
+    DeleteStringVector(b.metadata)
+    DeleteStringVector(b.forward)
+    DeleteStringVector(b.backward)
+}
+
+func (b *specAugmenter) augment(env IndexEnvironment, class string) (err error) {
+    spec, err := env.GetFileClassSpec(class)
+    if err != nil || spec == nil || spec.Swigcptr() == 0 {
+        return
+    }
+    defer Wrapped_deleteFileClassSpec(spec)
+    var changed bool
+    if changed, err = Wrapped_Buildindex_augmentSpec(spec, b.fields, b.metadata, b.forward, b.backward); err != nil || !changed {
+        return
+    }
+    return env.AddFileClass(spec)
+}
+
+// corpusFilter reports whether to index path, a file under the corpus root
+type corpusFilter func(root, path string) bool
+
+func addCorpus(ctx context.Context, env IndexEnvironment, b *specAugmenter, corpus IndexCorpus, filter corpusFilter) (err error) {
+    if corpus.Class != "" {
+        if err = b.augment(env, corpus.Class); err != nil {
+            return
+        }
+    }
+
+    // First record the document root, and then the paths to any annotator inputs
+    if err = env.SetDocumentRoot(corpus.Path); err != nil {
+        return
+    }
+    if err = env.SetAnchorTextPath(corpus.Inlink); err != nil {
+        return
+    }
+    if err = env.SetOffsetAnnotationsPath(corpus.Annotations); err != nil {
+        return
+    }
+    if err = env.SetOffsetMetadataPath(corpus.Metadata); err != nil {
+        return
+    }
+
+    return filepath.Walk(corpus.Path, func(path string, info os.FileInfo, err error) error {
+        if err != nil {
+            return err
+        }
+        if info.IsDir() {
+            return nil
+        }
+        if filter != nil && !filter(corpus.Path, path) {
+            return nil
+        }
+        if corpus.Class != "" {
+            return env.AddFileContext(ctx, path, corpus.Class)
+        }
+        if err = b.augment(env, strings.TrimPrefix(filepath.Ext(path), ".")); err != nil {
+            return err
+        }
+        return env.AddFileContext(ctx, path)
+    })
+}
+
+
+
+
+//
+//  typed IndriBuildIndex configuration
+//
+
+//
+// IndexField is an indexed field, see the <field> parameters of
+// IndriBuildIndex. Parser names the annotator used for a numeric field,
+// it defaults to NumericFieldAnnotator.
+//
+type IndexField struct {
+    Name string
+    Numeric bool
+    Parser string
+    Ordinal bool
+    Parental bool
+}
+
+//
+// IndexMetadata lists the metadata fields of a repository. Forward fields
+// can be looked up by document id, Backward fields can be used to find
+// documents by value. docno is always added to both.
+//
+type IndexMetadata struct {
+    Fields []string
+    Forward []string
+    Backward []string
+}
+
+// IndexCorpus is a file or directory of documents to index
+type IndexCorpus struct {
+    Path string
+    Class string
+    Annotations string
+    Metadata string
+    Inlink string
+}
+
+//
+// IndexConfig is a go copy of the IndriBuildIndex parameters. Memory is in
+// bytes, an empty Stemmer means no stemming.
+//
+type IndexConfig struct {
+    Index string
+    Memory int64
+    Stemmer string
+    Normalize bool
+    StoreDocs bool
+    Stopwords []string
+    Fields []IndexField
+    Metadata IndexMetadata
+    Corpora []IndexCorpus
+}
+
+//
+// IndexConfigError holds every problem found validating an IndexConfig.
+//
+type IndexConfigError struct {
+    Problems []string
+}
+
+func (e *IndexConfigError) Error() string {
+    return fmt.Sprintf("invalid index config: %v", strings.Join(e.Problems, "; "))
+}
+
+// DefaultIndexMemory is the IndriBuildIndex default memory, 1G
+const DefaultIndexMemory int64 = 1024*1024*1024
+
+//
+// NewIndexConfig returns a config with the IndriBuildIndex defaults.
+//
+func NewIndexConfig() IndexConfig {
+    return IndexConfig{
+        Memory: DefaultIndexMemory,
+        Normalize: true,
+        StoreDocs: true,
+    }
+}
+
+// stemmers known to indri::parse::StemmerFactory
+var indexStemmers = map[string]bool{
+    "krovetz": true,
+    "kstem": true,
+    "porter": true,
+    "arabic_stop": true,
+    "arabic_norm2": true,
+    "arabic_norm2_stop": true,
+    "arabic_light10": true,
+    "arabic_light10_stop": true,
+}
+
+//
+// Validate checks cfg, a nil env skips the corpus file class checks.
+// All problems are returned together in an *IndexConfigError.
+//
+func (cfg IndexConfig) Validate(env IndexEnvironment) error {
+    var problems []string
+
+    if cfg.Memory < 0 {
+        problems = append(problems, fmt.Sprintf("memory %v is negative", cfg.Memory))
+    }
+    if cfg.Stemmer != "" && !indexStemmers[strings.ToLower(cfg.Stemmer)] {
+        problems = append(problems, fmt.Sprintf("unknown stemmer %q", cfg.Stemmer))
+    }
+    for i, w := range cfg.Stopwords {
+        if strings.TrimSpace(w) == "" {
+            problems = append(problems, fmt.Sprintf("stopword %v is empty", i))
+        }
+    }
+
+    fields := make(map[string]bool)
+    for i, f := range cfg.Fields {
+        name := strings.ToLower(f.Name)
+        if name == "" {
+            problems = append(problems, fmt.Sprintf("field %v has no name", i))
+            continue
+        }
+        if fields[name] {
+            problems = append(problems, fmt.Sprintf("field %q is listed more than once", f.Name))
+        }
+        fields[name] = true
+        if f.Parser != "" && !f.Numeric {
+            problems = append(problems, fmt.Sprintf("field %q has a parser but is not numeric", f.Name))
+        }
+    }
+
+    for _, m := range []struct {
+        kind string
+        names []string
+    }{
+        {"metadata field", cfg.Metadata.Fields},
+        {"forward metadata field", cfg.Metadata.Forward},
+        {"backward metadata field", cfg.Metadata.Backward},
+    } {
+        for i, name := range m.names {
+            if name == "" {
+                problems = append(problems, fmt.Sprintf("%v %v has no name", m.kind, i))
+            }
+        }
+    }
+
+    for i, c := range cfg.Corpora {
+        if c.Path == "" {
+            problems = append(problems, fmt.Sprintf("corpus %v has no path", i))
+        }
+        if c.Class != "" && env != nil && !hasFileClass(env, c.Class) {
+            problems = append(problems, fmt.Sprintf("corpus %v has unknown file class %q", i, c.Class))
+        }
+    }
+
+    if len(problems) > 0 {
+        return &IndexConfigError{Problems: problems}
+    }
+    return nil
+}
+
+func hasFileClass(env IndexEnvironment, class string) bool {
+    spec, err := env.GetFileClassSpec(class)
+    if err != nil || spec == nil || spec.Swigcptr() == 0 {
+        return false
+    }
+    Wrapped_deleteFileClassSpec(spec)
+    return true
+}
+
+//
+// metadataIndexedFields returns the forward and backward metadata lists as
+// IndriBuildIndex sets them, lower case and always including docno.
+//
+func (cfg IndexConfig) metadataIndexedFields() (forward []string, backward []string) {
+    withDocno := func(names []string) []string {
+        lower := make([]string, 0, len(names) + 1)
+        found := false
+        for _, n := range names {
+            n = strings.ToLower(n)
+            found = found || n == "docno"
+            lower = append(lower, n)
+        }
+        if !found {
+            lower = append(lower, "docno")
+        }
+        return lower
+    }
+    return withDocno(cfg.Metadata.Forward), withDocno(cfg.Metadata.Backward)
+}
+
+//
+// Configure validates cfg and applies it to env in the order IndriBuildIndex
+// does: memory, normalization, stored documents, stemmer, stopwords,
+// metadata and then fields. env must not have been created or opened yet.
+// Corpora are only validated, they are added when the index is built.
+//
+func Configure(env IndexEnvironment, cfg IndexConfig) (err error) {
+    defer catch(&err)
+
+    if err = cfg.Validate(env); err != nil {
+        return
+    }
+
+    if cfg.Memory > 0 {
+        if err = env.SetMemory(cfg.Memory); err != nil {
+            return
+        }
+    }
+    if err = env.SetNormalization(cfg.Normalize); err != nil {
+        return
+    }
+    if err = env.SetStoreDocs(cfg.StoreDocs); err != nil {
+        return
+    }
+    if cfg.Stemmer != "" {
+        if err = env.SetStemmer(strings.ToLower(cfg.Stemmer)); err != nil {
+            return
+        }
+    }
+    if len(cfg.Stopwords) > 0 {
+        stopwords := newStringVector(cfg.Stopwords)
+        defer DeleteStringVector(stopwords)
+        if err = env.SetStopwords(stopwords); err != nil {
+            return
+        }
+    }
+
+    forward, backward := cfg.metadataIndexedFields()
+    forwardVector := newStringVector(forward)
+    defer DeleteStringVector(forwardVector)
+    backwardVector := newStringVector(backward)
+    defer DeleteStringVector(backwardVector)
+    if err = env.SetMetadataIndexedFields(forwardVector, backwardVector); err != nil {
+        return
+    }
+
+    if len(cfg.Fields) > 0 {
+        names := make([]string, len(cfg.Fields))
+        for i, f := range cfg.Fields {
+            names[i] = strings.ToLower(f.Name)
+        }
+        fields := newStringVector(names)
+        defer DeleteStringVector(fields)
+        if err = env.SetIndexedFields(fields); err != nil {
+            return
+        }
+        for i, f := range cfg.Fields {
+            if f.Numeric {
+                parser := f.Parser
+                if parser == "" {
+                    parser = "NumericFieldAnnotator"
+                }
+                if err = env.SetNumericField(names[i], true, parser); err != nil {
+                    return
+                }
+            }
+        }
+        for i, f := range cfg.Fields {
+            if f.Ordinal {
+                if err = env.SetOrdinalField(names[i], true); err != nil {
+                    return
+                }
+            }
+        }
+        for i, f := range cfg.Fields {
+            if f.Parental {
+                if err = env.SetParentalField(names[i], true); err != nil {
+                    return
+                }
+            }
+        }
+    }
+    return
+}
+
+
+
+
+//
+//  read and write IndexConfig as IndriBuildIndex parameters xml
+//
+
+// xmlName reads both <field>name</field> and <field><name>name</name></field>
+type xmlName struct {
+    Text string `xml:",chardata"`
+    Name string `xml:"name,omitempty"`
+}
+
+func (n xmlName) value() string {
+    if n.Name != "" {
+        return strings.TrimSpace(n.Name)
+    }
+    return strings.TrimSpace(n.Text)
+}
+
+type xmlIndexField struct {
+    Name string `xml:"name"`
+    Numeric string `xml:"numeric,omitempty"`
+    ParserName string `xml:"parserName,omitempty"`
+    Ordinal string `xml:"ordinal,omitempty"`
+    Parental string `xml:"parental,omitempty"`
+}
+
+type xmlIndexMetadata struct {
+    Forward []string `xml:"forward"`
+    Backward []string `xml:"backward"`
+    Fields []xmlName `xml:"field"`
+}
+
+type xmlIndexCorpus struct {
+    Path string `xml:"path"`
+    Class string `xml:"class,omitempty"`
+    Annotations string `xml:"annotations,omitempty"`
+    Metadata string `xml:"metadata,omitempty"`
+    Inlink string `xml:"inlink,omitempty"`
+}
+
+type xmlIndexConfig struct {
+    XMLName xml.Name `xml:"parameters"`
+    Index string `xml:"index,omitempty"`
+    Corpora []xmlIndexCorpus `xml:"corpus"`
+    Metadata *xmlIndexMetadata `xml:"metadata"`
+    Fields []xmlIndexField `xml:"field"`
+    Memory string `xml:"memory,omitempty"`
+    Stemmer *xmlName `xml:"stemmer"`
+    Normalize string `xml:"normalize,omitempty"`
+    StoreDocs string `xml:"storeDocs,omitempty"`
+    Stopwords []string `xml:"stopper>word"`
+}
+
+//
+// ParseMemorySize parses a memory size the way indri::api::Parameters does,
+// a number of bytes with an optional K, M or G suffix.
+//
+func ParseMemorySize(input string) (int64, error) {
+    s := strings.TrimSpace(input)
+    var scale int64 = 1
+    if n := len(s); n > 0 {
+        switch s[n-1] {
+        case 'k', 'K':
+            scale = 1024
+        case 'm', 'M':
+            scale = 1024*1024
+        case 'g', 'G':
+            scale = 1024*1024*1024
+        }
+        if scale > 1 {
+            s = s[:n-1]
+        }
+    }
+    v, err := strconv.ParseInt(s, 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid memory size %q", input)
+    }
+    if v > math.MaxInt64 / scale || v < math.MinInt64 / scale {
+        return 0, fmt.Errorf("memory size %q out of range", input)
+    }
+    return v * scale, nil
+}
+
+//
+// FormatMemorySize formats a memory size with the largest K, M or G suffix
+// that divides it.
+//
+func FormatMemorySize(v int64) string {
+    switch {
+    case v != 0 && v % (1024*1024*1024) == 0:
+        return fmt.Sprintf("%vg", v / (1024*1024*1024))
+    case v != 0 && v % (1024*1024) == 0:
+        return fmt.Sprintf("%vm", v / (1024*1024))
+    case v != 0 && v % 1024 == 0:
+        return fmt.Sprintf("%vk", v / 1024)
+    }
+    return strconv.FormatInt(v, 10)
+}
+
+// parseParameterBool accepts the boolean spellings indri::api::Parameters does
+func parseParameterBool(s string, def bool) (bool, error) {
+    switch strings.ToLower(strings.TrimSpace(s)) {
+    case "":
+        return def, nil
+    case "1", "true", "yes", "y", "on":
+        return true, nil
+    case "0", "false", "no", "n", "off":
+        return false, nil
+    }
+    return def, fmt.Errorf("invalid boolean %q", s)
+}
+
+//
+// ParseIndexConfig reads IndriBuildIndex parameters xml into an IndexConfig.
+// Parameters missing from the xml keep their NewIndexConfig defaults.
+//
+func ParseIndexConfig(s string) (cfg IndexConfig, err error) {
+    var x xmlIndexConfig
+    if err = xml.Unmarshal([]byte(s), &x); err != nil {
+        err = fmt.Errorf("failed to parse index config: %v", err)
+        return
+    }
+
+    cfg = NewIndexConfig()
+    cfg.Index = strings.TrimSpace(x.Index)
+    if x.Memory != "" {
+        if cfg.Memory, err = ParseMemorySize(x.Memory); err != nil {
+            return
+        }
+    }
+    if x.Stemmer != nil {
+        cfg.Stemmer = x.Stemmer.value()
+    }
+    if cfg.Normalize, err = parseParameterBool(x.Normalize, cfg.Normalize); err != nil {
+        err = fmt.Errorf("normalize: %v", err)
+        return
+    }
+    if cfg.StoreDocs, err = parseParameterBool(x.StoreDocs, cfg.StoreDocs); err != nil {
+        err = fmt.Errorf("storeDocs: %v", err)
+        return
+    }
+    for _, w := range x.Stopwords {
+        cfg.Stopwords = append(cfg.Stopwords, strings.TrimSpace(w))
+    }
+
+    for _, f := range x.Fields {
+        field := IndexField{Name: strings.TrimSpace(f.Name), Parser: strings.TrimSpace(f.ParserName)}
+        if field.Numeric, err = parseParameterBool(f.Numeric, false); err == nil {
+            if field.Ordinal, err = parseParameterBool(f.Ordinal, false); err == nil {
+                field.Parental, err = parseParameterBool(f.Parental, false)
+            }
+        }
+        if err != nil {
+            err = fmt.Errorf("field %q: %v", field.Name, err)
+            return
+        }
+        cfg.Fields = append(cfg.Fields, field)
+    }
+
+    if x.Metadata != nil {
+        for _, f := range x.Metadata.Fields {
+            cfg.Metadata.Fields = append(cfg.Metadata.Fields, f.value())
+        }
+        for _, f := range x.Metadata.Forward {
+            cfg.Metadata.Forward = append(cfg.Metadata.Forward, strings.TrimSpace(f))
+        }
+        for _, f := range x.Metadata.Backward {
+            cfg.Metadata.Backward = append(cfg.Metadata.Backward, strings.TrimSpace(f))
+        }
+    }
+
+    for _, c := range x.Corpora {
+        cfg.Corpora = append(cfg.Corpora, IndexCorpus{
+            Path: strings.TrimSpace(c.Path),
+            Class: strings.TrimSpace(c.Class),
+            Annotations: strings.TrimSpace(c.Annotations),
+            Metadata: strings.TrimSpace(c.Metadata),
+            Inlink: strings.TrimSpace(c.Inlink),
+        })
+    }
+    return
+}
+
+//
+// LoadIndexConfig reads an IndriBuildIndex parameters file, such as
+// data/params.xml, into an IndexConfig.
+//
+func LoadIndexConfig(path string) (cfg IndexConfig, err error) {
+    b, err := ioutil.ReadFile(path)
+    if err != nil {
+        return
+    }
+    return ParseIndexConfig(string(b))
+}
+
+//
+// Set applies an IndriBuildIndex command line parameter, -memory=100M is
+// Set("memory", "100M"). corpus.path, field.name, stopper.word and the
+// metadata lists append an entry, the other corpus and field keys set the
+// entry appended last.
+//
+func (cfg *IndexConfig) Set(key string, value string) (err error) {
+    value = strings.TrimSpace(value)
+    // the entry appended last, or nil with err set when there is none yet
+    lastCorpus := func() *IndexCorpus {
+        if len(cfg.Corpora) == 0 {
+            err = fmt.Errorf("given before corpus.path")
+            return nil
+        }
+        return &cfg.Corpora[len(cfg.Corpora)-1]
+    }
+    lastField := func() *IndexField {
+        if len(cfg.Fields) == 0 {
+            err = fmt.Errorf("given before field.name")
+            return nil
+        }
+        return &cfg.Fields[len(cfg.Fields)-1]
+    }
+    switch key {
+    case "index":
+        cfg.Index = value
+    case "memory":
+        cfg.Memory, err = ParseMemorySize(value)
+    case "stemmer", "stemmer.name":
+        cfg.Stemmer = value
+    case "normalize":
+        cfg.Normalize, err = parseParameterBool(value, cfg.Normalize)
+    case "storeDocs":
+        cfg.StoreDocs, err = parseParameterBool(value, cfg.StoreDocs)
+    case "stopper.word":
+        cfg.Stopwords = append(cfg.Stopwords, value)
+    case "metadata.field":
+        cfg.Metadata.Fields = append(cfg.Metadata.Fields, value)
+    case "metadata.forward":
+        cfg.Metadata.Forward = append(cfg.Metadata.Forward, value)
+    case "metadata.backward":
+        cfg.Metadata.Backward = append(cfg.Metadata.Backward, value)
+    case "corpus", "corpus.path":
+        cfg.Corpora = append(cfg.Corpora, IndexCorpus{Path: value})
+    case "corpus.class":
+        if c := lastCorpus(); c != nil {
+            c.Class = value
+        }
+    case "corpus.annotations":
+        if c := lastCorpus(); c != nil {
+            c.Annotations = value
+        }
+    case "corpus.metadata":
+        if c := lastCorpus(); c != nil {
+            c.Metadata = value
+        }
+    case "corpus.inlink":
+        if c := lastCorpus(); c != nil {
+            c.Inlink = value
+        }
+    case "field", "field.name":
+        cfg.Fields = append(cfg.Fields, IndexField{Name: value})
+    case "field.numeric":
+        if f := lastField(); f != nil {
+            f.Numeric, err = parseParameterBool(value, false)
+        }
+    case "field.parserName":
+        if f := lastField(); f != nil {
+            f.Parser = value
+        }
+    case "field.ordinal":
+        if f := lastField(); f != nil {
+            f.Ordinal, err = parseParameterBool(value, false)
+        }
+    case "field.parental":
+        if f := lastField(); f != nil {
+            f.Parental, err = parseParameterBool(value, false)
+        }
+    default:
+        return fmt.Errorf("unknown parameter %q", key)
+    }
+    if err != nil {
+        err = fmt.Errorf("%v: %v", key, err)
+    }
+    return
+}
+
+//
+// Marshal writes cfg as IndriBuildIndex parameters xml, which both
+// ParseIndexConfig and Parameters.MyLoad read back. memory is always
+// written, a zero Memory would otherwise read back as the default.
+//
+func (cfg IndexConfig) Marshal() (string, error) {
+    x := xmlIndexConfig{
+        Index: cfg.Index,
+        Normalize: strconv.FormatBool(cfg.Normalize),
+        StoreDocs: strconv.FormatBool(cfg.StoreDocs),
+        Memory: FormatMemorySize(cfg.Memory),
+        Stopwords: cfg.Stopwords,
+    }
+    if cfg.Stemmer != "" {
+        x.Stemmer = &xmlName{Name: cfg.Stemmer}
+    }
+    for _, c := range cfg.Corpora {
+        x.Corpora = append(x.Corpora, xmlIndexCorpus(c))
+    }
+    m := cfg.Metadata
+    if len(m.Fields) > 0 || len(m.Forward) > 0 || len(m.Backward) > 0 {
+        x.Metadata = &xmlIndexMetadata{Forward: m.Forward, Backward: m.Backward}
+        for _, f := range m.Fields {
+            x.Metadata.Fields = append(x.Metadata.Fields, xmlName{Name: f})
+        }
+    }
+    for _, f := range cfg.Fields {
+        field := xmlIndexField{Name: f.Name, ParserName: f.Parser}
+        if f.Numeric {
+            field.Numeric = "true"
+        }
+        if f.Ordinal {
+            field.Ordinal = "true"
+        }
+        if f.Parental {
+            field.Parental = "true"
+        }
+        x.Fields = append(x.Fields, field)
+    }
+
+    b, err := xml.MarshalIndent(x, "", "    ")
+    if err != nil {
+        return "", err
+    }
+    return string(b) + "\n", nil
+}
+
+
+
+
+//
+//  extend MetadataPair.i
+//
+
+type MetadataPair interface {
+    Wrapped_MetadataPair
+
+    WSetKey(arg2 string) (err error)
+	WGetKey() (_swig_ret string, err error)
+	WSetValue(arg2 []byte) (err error)
+	WGetValue() (_swig_ret []byte, err error)
+
+    WReset()
+    WClear() (err error)
+}
+
+func NewMetadataPair() (_swig_ret MetadataPair, err error) {
+    _swig_ret = (MetadataPair)(SwigcptrWrapped_MetadataPair(C._wrap_new_Wrapped_MetadataPair_indri_go_add17ee78870902e()))
+    if _swig_ret == nil {
+        return
+    }
+    _swig_ret.WReset()
+	return
+}
+
+func DeleteMetadataPair(arg1 MetadataPair) {
+
+    // don't leak resource
+    arg1.WClear()
+
+    DeleteWrapped_MetadataPair(arg1)
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WReset() {
+    var zvs string
+    var zvv uintptr = 0
+
+    // initialize key/value to zero values
+    m.SetKey(zvs)
+    m.SetValue(zvv)
+    m.SetValueLength(0)
+
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WClear() (err error) {
     defer catch(&err)
 
     // overrwrite reference to GO resource
     var zvs string
     m.SetKey(zvs)
 
-    // don't leak C++ resource
-    p := m.GetValue()
-    l := m.GetValueLength()
-    if l > 0 && p > 0 {
-        Swig_free(p)
+    // don't leak C++ resource
+    p := m.GetValue()
+    l := m.GetValueLength()
+    if l > 0 && p > 0 {
+        Swig_free(p)
+    }
+    m.WReset()
+
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WSetKey(arg2 string) (err error) {
+    defer catch(&err)
+    m.SetKey(arg2)
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WGetKey() (_swig_ret string, err error) {
+    defer catch(&err)
+    _swig_ret = m.GetKey()
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WSetValue(arg2 []byte)  (err error) {
+    defer catch(&err)
+
+    // don't leak C++ resource that may have already been set
+    p := m.GetValue()
+    l := m.GetValueLength()
+    if l > 0 && p > 0 {
+        Swig_free(p)
+    }
+
+    p = Swig_malloc(len(arg2))
+    s := (*[1<<30]byte)(unsafe.Pointer(p))[:len(arg2)]
+
+    i := copy(s, arg2)
+    if i != len(arg2) {
+        err = fmt.Errorf("Wrapped_MetadataPair.WSetValue: expected to copy %v but copied %v", len(arg2), i)
+        return
+    }
+
+    m.SetValue(uintptr(unsafe.Pointer(p)))
+    m.SetValueLength(len(arg2))
+
+    //fmt.Printf("WSetValue: len %v cap %v arg2 %v i %v\n", len(arg2), cap(arg2), arg2, i)
+    return
+}
+
+func (m SwigcptrWrapped_MetadataPair) WGetValue() (_swig_ret []byte, err error) {
+    defer catch(&err)
+
+    p := m.GetValue()
+    len := m.GetValueLength()
+    s := (*[1<<30]byte)(unsafe.Pointer(p))[:len]
+    _swig_ret = make([]byte, len, len)
+
+    i := copy(_swig_ret, s)
+    if i != len {
+        err = fmt.Errorf("Wrapped_MetadataPair.WGetValue: expected to copy %v but copied %v", len, i)
+    }
+
+	return
+}
+
+
+
+
+//
+//  extend MetadataPairVector.i
+//
+
+type Wrapped_MetadataPairVector interface {
+    MetadataPairVector
+
+    WReserve(arg2 int64) (err error)
+	WClear() (err error)
+	WAdd(arg2 MetadataPair) (err error)
+	WGet(arg2 int) (_swig_ret MetadataPair, err error)
+	WSet(arg2 int, arg3 MetadataPair) (err error)
+}
+
+func NewWrapped_MetadataPairVector(a ...interface{}) Wrapped_MetadataPairVector {
+	argc := len(a)
+	if argc == 0 {
+		return (Wrapped_MetadataPairVector)(SwigcptrMetadataPairVector(C._wrap_new_MetadataPairVector__SWIG_0_indri_go_add17ee78870902e()))
+	}
+	if argc == 1 {
+        _swig_i_0 := a[0].(int)
+    	return (Wrapped_MetadataPairVector)(SwigcptrMetadataPairVector(C._wrap_new_MetadataPairVector__SWIG_1_indri_go_add17ee78870902e(C.swig_type_128(_swig_i_0))))
+	}
+	panic("No match for overloaded function call")
+}
+
+func (arg1 SwigcptrMetadataPairVector) WReserve(arg2 int64) (err error) {
+    defer catch(&err)
+    arg1.Reserve(arg2)
+    return
+}
+
+func (arg1 SwigcptrMetadataPairVector) WClear() (err error) {
+    defer catch(&err)
+    arg1.Clear()
+    return
+}
+
+func (arg1 SwigcptrMetadataPairVector) WAdd(arg2 MetadataPair) (err error) {
+    defer catch(&err)
+    arg1.Add(arg2)
+    return
+}
+
+func (arg1 SwigcptrMetadataPairVector) WGet(arg2 int) (_swig_ret MetadataPair, err error) {
+    defer catch(&err)
+
+    //
+    // we want to return the extended wrapped pair interface instead
+    // (named MetadataPair). The C++ MetadataPair class is first renamed to
+    // Wrapped_MetadataPair so we can subclass it in GO using the director
+    // feature. When we wrap the (GO) MetadataPairVector specialization, it
+    // references the renamed C++ class type and not the GO subclass. So the
+    // generated code returns the renamed interface type Wrapped_MetadataPair
+    // instead of the GO MetadataPairVector vector interface type.
+    //_swig_ret = arg1.Get(arg2)
+    //return
+
+	_swig_i_0 := arg1
+	_swig_i_1 := arg2
+	_swig_ret = (MetadataPair)(SwigcptrWrapped_MetadataPair(C._wrap_MetadataPairVector_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))))
+	return
+}
+
+func (arg1 SwigcptrMetadataPairVector) WSet(arg2 int, arg3 MetadataPair) (err error) {
+    defer catch(&err)
+    arg1.Set(arg2, arg3)
+    return
+}
+
+func DeleteWrapped_MetadataPairVector(arg1 Wrapped_MetadataPairVector) {
+    DeleteMetadataPairVector(arg1)
+}
+
+
+
+
+//
+//  extend ScoredExtentResult
+//
+
+// ScoredResult is a go copy of an indri::api::ScoredExtentResult
+type ScoredResult struct {
+    Score float64
+    Document int
+    Begin int
+    End int
+    Number int64
+    Ordinal int
+    ParentOrdinal int
+}
+
+//
+// copyScoredResults copies the C++ result vector into a go slice. the vector
+// itself is left alone, use takeScoredResults for vectors returned by value.
+//
+func copyScoredResults(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) (_swig_ret []ScoredResult) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    n := int(C.indri_go_scored_results_size(_swig_i_0))
+    _swig_ret = make([]ScoredResult, n)
+    if n == 0 {
+        return
+    }
+    r := make([]C.indri_go_scored_result, n)
+    C.indri_go_scored_results_copy(_swig_i_0, &r[0])
+    for i := range r {
+        _swig_ret[i] = ScoredResult{
+            Score: float64(r[i].score),
+            Document: int(r[i].document),
+            Begin: int(r[i].begin),
+            End: int(r[i].end),
+            Number: int64(r[i].number),
+            Ordinal: int(r[i].ordinal),
+            ParentOrdinal: int(r[i].parentOrdinal),
+        }
+    }
+    return
+}
+
+//
+// newScoredResultVector copies a go slice into a new C++ result vector, the
+// caller must deleteScoredResultVector it when done.
+//
+func newScoredResultVector(arg1 []ScoredResult) Std_vector_Sl_indri_api_ScoredExtentResult_Sg_ {
+    var r []C.indri_go_scored_result = make([]C.indri_go_scored_result, len(arg1) + 1)
+    for i, sr := range arg1 {
+        r[i] = C.indri_go_scored_result{
+            score: C.double(sr.Score),
+            document: C.swig_intgo(sr.Document),
+            begin: C.swig_intgo(sr.Begin),
+            end: C.swig_intgo(sr.End),
+            number: C.longlong(sr.Number),
+            ordinal: C.swig_intgo(sr.Ordinal),
+            parentOrdinal: C.swig_intgo(sr.ParentOrdinal),
+        }
+    }
+    return SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(C.indri_go_scored_results_new(&r[0], C.swig_intgo(len(arg1))))
+}
+
+func deleteScoredResultVector(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) {
+    C.indri_go_scored_results_delete(C.uintptr_t(arg1.Swigcptr()))
+}
+
+//
+// takeScoredResults copies and then frees a result vector that swig
+// allocated to hold a C++ return by value.
+//
+func takeScoredResults(arg1 Std_vector_Sl_indri_api_ScoredExtentResult_Sg_) []ScoredResult {
+    defer deleteScoredResultVector(arg1)
+    return copyScoredResults(arg1)
+}
+
+
+
+
+//
+//  extend ParsedDocument.i
+//
+
+// TermExtent is a go copy of an indri::parse::TermExtent
+type TermExtent struct {
+    Begin int
+    End int
+}
+
+//
+// Document is a go copy of an indri::api::ParsedDocument. Terms holds an
+// empty string for each stopped term, Positions holds the byte extent of
+// each term in Text.
+//
+type Document struct {
+    Text string
+    Content string
+    Terms []string
+    Positions []TermExtent
+    Metadata map[string][]byte
+}
+
+//
+// copyDocument copies a C++ ParsedDocument into a go Document.
+// metadata values are stored with a trailing null, which is dropped.
+//
+func copyDocument(arg1 uintptr) (_swig_ret Document) {
+    _swig_i_0 := C.uintptr_t(arg1)
+    var n C.swig_intgo
+
+    p := C.indri_go_parsed_document_text(_swig_i_0, &n)
+    _swig_ret.Text = C.GoStringN(p, C.int(n))
+    p = C.indri_go_parsed_document_content(_swig_i_0, &n)
+    _swig_ret.Content = C.GoStringN(p, C.int(n))
+
+    n = C.indri_go_parsed_document_terms_size(_swig_i_0)
+    _swig_ret.Terms = make([]string, int(n))
+    for i := range _swig_ret.Terms {
+        if t := C.indri_go_parsed_document_term(_swig_i_0, C.swig_intgo(i)); t != nil {
+            _swig_ret.Terms[i] = C.GoString(t)
+        }
+    }
+
+    n = C.indri_go_parsed_document_positions_size(_swig_i_0)
+    _swig_ret.Positions = make([]TermExtent, int(n))
+    if n > 0 {
+        extents := make([]C.swig_intgo, 2 * int(n))
+        C.indri_go_parsed_document_positions(_swig_i_0, &extents[0])
+        for i := range _swig_ret.Positions {
+            _swig_ret.Positions[i] = TermExtent{Begin: int(extents[2*i]), End: int(extents[2*i+1])}
+        }
+    }
+
+    n = C.indri_go_parsed_document_metadata_size(_swig_i_0)
+    _swig_ret.Metadata = make(map[string][]byte, int(n))
+    for i := 0; i < int(n); i++ {
+        var value unsafe.Pointer
+        var length C.swig_intgo
+        key := C.indri_go_parsed_document_metadata(_swig_i_0, C.swig_intgo(i), &value, &length)
+        v := C.GoBytes(value, C.int(length))
+        if len(v) > 0 && v[len(v)-1] == 0 {
+            v = v[:len(v)-1]
+        }
+        _swig_ret.Metadata[C.GoString(key)] = v
+    }
+    return
+}
+
+//
+// takeDocuments copies and then frees a C++ ParsedDocument vector, including
+// the documents, returned by value.
+//
+func takeDocuments(arg1 Std_vector_Sl_indri_api_ParsedDocument_Sm__Sg_) (_swig_ret []Document) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    defer C.indri_go_parsed_documents_delete(_swig_i_0)
+    n := int(C.indri_go_parsed_documents_size(_swig_i_0))
+    _swig_ret = make([]Document, n)
+    for i := range _swig_ret {
+        _swig_ret[i] = copyDocument(uintptr(C.indri_go_parsed_documents_get(_swig_i_0, C.swig_intgo(i))))
+    }
+    return
+}
+
+
+
+
+//
+//  extend DocumentVector.i
+//
+
+// DocumentField is a go copy of an indri::api::DocumentVector::Field
+type DocumentField struct {
+    Name string
+    Begin int
+    End int
+    Number int64
+    Ordinal int
+    ParentOrdinal int
+}
+
+//
+// DocumentVector is a go copy of an indri::api::DocumentVector. Each entry
+// of Positions is an index into Stems, index 0 is the out of vocabulary stem
+// used for stopwords. Field Begin and End are indexes into Positions.
+//
+type DocumentVector struct {
+    Stems []string
+    Positions []int
+    Fields []DocumentField
+}
+
+//
+// TermFrequencies counts how often each stem occurs in the document,
+// stopwords are not counted.
+//
+func (v DocumentVector) TermFrequencies() map[string]int {
+    tf := make(map[string]int)
+    for _, p := range v.Positions {
+        if p > 0 {
+            tf[v.Stems[p]]++
+        }
+    }
+    return tf
+}
+
+func copyDocumentVector(arg1 uintptr) (_swig_ret DocumentVector) {
+    _swig_i_0 := C.uintptr_t(arg1)
+
+    n := int(C.indri_go_document_vector_stems_size(_swig_i_0))
+    _swig_ret.Stems = make([]string, n)
+    for i := range _swig_ret.Stems {
+        var length C.swig_intgo
+        p := C.indri_go_document_vector_stem(_swig_i_0, C.swig_intgo(i), &length)
+        _swig_ret.Stems[i] = C.GoStringN(p, C.int(length))
+    }
+
+    n = int(C.indri_go_document_vector_positions_size(_swig_i_0))
+    _swig_ret.Positions = make([]int, n)
+    if n > 0 {
+        positions := make([]C.swig_intgo, n)
+        C.indri_go_document_vector_positions(_swig_i_0, &positions[0])
+        for i, p := range positions {
+            _swig_ret.Positions[i] = int(p)
+        }
+    }
+
+    n = int(C.indri_go_document_vector_fields_size(_swig_i_0))
+    _swig_ret.Fields = make([]DocumentField, n)
+    for i := range _swig_ret.Fields {
+        var f C.indri_go_document_field
+        name := C.indri_go_document_vector_field(_swig_i_0, C.swig_intgo(i), &f)
+        _swig_ret.Fields[i] = DocumentField{
+            Name: C.GoString(name),
+            Begin: int(f.begin),
+            End: int(f.end),
+            Number: int64(f.number),
+            Ordinal: int(f.ordinal),
+            ParentOrdinal: int(f.parentOrdinal),
+        }
+    }
+    return
+}
+
+//
+// takeDocumentVectors copies and then frees a C++ DocumentVector vector,
+// including the document vectors, returned by value.
+//
+func takeDocumentVectors(arg1 Std_vector_Sl_indri_api_DocumentVector_Sm__Sg_) (_swig_ret []DocumentVector) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    defer C.indri_go_document_vectors_delete(_swig_i_0)
+    n := int(C.indri_go_document_vectors_size(_swig_i_0))
+    _swig_ret = make([]DocumentVector, n)
+    for i := range _swig_ret {
+        _swig_ret[i] = copyDocumentVector(uintptr(C.indri_go_document_vectors_get(_swig_i_0, C.swig_intgo(i))))
+    }
+    return
+}
+
+
+
+
+//
+//  extend QueryRequest.i and QueryResults.i
+//
+
+// QueryRequestOptions mirrors indri::api::QueryRequest::Options
+type QueryRequestOptions int
+
+const (
+    HTMLSnippet QueryRequestOptions = 1
+    TextSnippet QueryRequestOptions = 2
+)
+
+//
+// QueryRequest is a go copy of an indri::api::QueryRequest. Metadata lists
+// the metadata fields returned with each result, StartNum is the rank of
+// the first result returned.
+//
+type QueryRequest struct {
+    Query string
+    Formulators []string
+    Metadata []string
+    ResultsRequested int
+    StartNum int
+    Options QueryRequestOptions
+}
+
+// QueryResult is a go copy of an indri::api::QueryResult
+type QueryResult struct {
+    Snippet string
+    DocumentName string
+    Docid int
+    Score float64
+    Begin int
+    End int
+    Metadata map[string]string
+}
+
+// QueryResults is a go copy of an indri::api::QueryResults
+type QueryResults struct {
+    ParseTime float64
+    ExecuteTime float64
+    DocumentsTime float64
+    EstimatedMatches int
+    Results []QueryResult
+}
+
+// gostring passes a go string to C without a copy, as swig does
+func gostring(s string) C._gostring_ {
+    return *(*C._gostring_)(unsafe.Pointer(&s))
+}
+
+func newQueryRequest(arg1 QueryRequest) uintptr {
+    request := C.indri_go_query_request_new(gostring(arg1.Query), C.swig_intgo(arg1.ResultsRequested), C.swig_intgo(arg1.StartNum), C.swig_intgo(arg1.Options))
+    for _, f := range arg1.Formulators {
+        C.indri_go_query_request_add_formulator(request, gostring(f))
+    }
+    for _, m := range arg1.Metadata {
+        C.indri_go_query_request_add_metadata(request, gostring(m))
+    }
+    if Swig_escape_always_false {
+        Swig_escape_val = arg1
+    }
+    return uintptr(request)
+}
+
+//
+// takeQueryResults copies and then frees C++ query results.
+//
+func takeQueryResults(arg1 uintptr) (_swig_ret QueryResults) {
+    _swig_i_0 := C.uintptr_t(arg1)
+    defer C.indri_go_query_results_delete(_swig_i_0)
+
+    var parseTime, executeTime, documentsTime C.double
+    var estimatedMatches C.swig_intgo
+    C.indri_go_query_results_times(_swig_i_0, &parseTime, &executeTime, &documentsTime, &estimatedMatches)
+    _swig_ret.ParseTime = float64(parseTime)
+    _swig_ret.ExecuteTime = float64(executeTime)
+    _swig_ret.DocumentsTime = float64(documentsTime)
+    _swig_ret.EstimatedMatches = int(estimatedMatches)
+
+    n := int(C.indri_go_query_results_size(_swig_i_0))
+    _swig_ret.Results = make([]QueryResult, n)
+    for i := range _swig_ret.Results {
+        var r C.indri_go_query_result
+        C.indri_go_query_results_get(_swig_i_0, C.swig_intgo(i), &r)
+        m := int(C.indri_go_query_results_metadata_size(_swig_i_0, C.swig_intgo(i)))
+        metadata := make(map[string]string, m)
+        for j := 0; j < m; j++ {
+            var key, value C._gostring_
+            C.indri_go_query_results_metadata(_swig_i_0, C.swig_intgo(i), C.swig_intgo(j), &key, &value)
+            metadata[C.GoStringN(key.p, C.int(key.n))] = C.GoStringN(value.p, C.int(value.n))
+        }
+        _swig_ret.Results[i] = QueryResult{
+            Snippet: C.GoStringN(r.snippet.p, C.int(r.snippet.n)),
+            DocumentName: C.GoStringN(r.documentName.p, C.int(r.documentName.n)),
+            Docid: int(r.docid),
+            Score: float64(r.score),
+            Begin: int(r.begin),
+            End: int(r.end),
+            Metadata: metadata,
+        }
+    }
+    return
+}
+
+
+
+
+//
+//  extend QueryAnnotation.i and QueryAnnotationNode.i
+//
+
+// ScoredExtent is an extent matched by a query node
+type ScoredExtent = ScoredResult
+
+//
+// AnnotationNode is a go copy of an indri::api::QueryAnnotationNode. Name
+// is the key of the node's matches in Annotation.Matches, Type is the
+// query node class, e.g. IndexTerm or WeightedSumNode.
+//
+type AnnotationNode struct {
+    Name string
+    Type string
+    QueryText string
+    Children []*AnnotationNode
+}
+
+//
+// Walk calls fn for n and then for each of its descendants, depth first.
+// fn returns false to skip the children of a node.
+//
+func (n *AnnotationNode) Walk(fn func(node *AnnotationNode) bool) {
+    if n == nil || !fn(n) {
+        return
+    }
+    for _, c := range n.Children {
+        c.Walk(fn)
+    }
+}
+
+//
+// Annotation is a go copy of an indri::api::QueryAnnotation. Matches maps
+// the Name of each query node to the extents it matched in the result
+// documents.
+//
+type Annotation struct {
+    QueryTree *AnnotationNode
+    Matches map[string][]ScoredExtent
+    Results []ScoredResult
+}
+
+//
+// NodeMatches returns the extents matched by node in document.
+//
+func (a Annotation) NodeMatches(node *AnnotationNode, document int) (_swig_ret []ScoredExtent) {
+    for _, m := range a.Matches[node.Name] {
+        if m.Document == document {
+            _swig_ret = append(_swig_ret, m)
+        }
+    }
+    return
+}
+
+func copyAnnotationNode(arg1 QueryAnnotationNode) *AnnotationNode {
+    if arg1 == nil || arg1.Swigcptr() == 0 {
+        return nil
+    }
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    node := &AnnotationNode{
+        Name: arg1.GetName(),
+        Type: arg1.GetXtype(),
+        QueryText: arg1.GetQueryText(),
+    }
+    n := int(C.indri_go_annotation_node_children_size(_swig_i_0))
+    node.Children = make([]*AnnotationNode, n)
+    if n > 0 {
+        children := make([]C.uintptr_t, n)
+        C.indri_go_annotation_node_children(_swig_i_0, &children[0])
+        for i, c := range children {
+            node.Children[i] = copyAnnotationNode(SwigcptrQueryAnnotationNode(c))
+        }
+    }
+    return node
+}
+
+func copyAnnotations(arg1 Std_map_Sl_std_string_Sc_std_vector_Sl_indri_api_ScoredExtentResult_Sg__Sg_) (_swig_ret map[string][]ScoredExtent) {
+    _swig_i_0 := C.uintptr_t(arg1.Swigcptr())
+    n := int(C.indri_go_annotations_size(_swig_i_0))
+    _swig_ret = make(map[string][]ScoredExtent, n)
+    if n > 0 {
+        keys := make([]C._gostring_, n)
+        values := make([]C.uintptr_t, n)
+        C.indri_go_annotations_entries(_swig_i_0, &keys[0], &values[0])
+        for i := range keys {
+            key := C.GoStringN(keys[i].p, C.int(keys[i].n))
+            _swig_ret[key] = copyScoredResults(SwigcptrStd_vector_Sl_indri_api_ScoredExtentResult_Sg_(values[i]))
+        }
+    }
+    return
+}
+
+//
+// takeAnnotation copies and then frees a C++ QueryAnnotation returned by
+// runAnnotatedQuery.
+//
+func takeAnnotation(arg1 QueryAnnotation) (_swig_ret Annotation) {
+    defer DeleteQueryAnnotation(arg1)
+    _swig_ret.QueryTree = copyAnnotationNode(arg1.GetQueryTree())
+    _swig_ret.Matches = copyAnnotations(arg1.GetAnnotations())
+    _swig_ret.Results = copyScoredResults(arg1.GetResults())
+    return
+}
+
+
+
+
+//
+//  extend QueryEnvironment.i
+//
+type QueryEnvironment interface {
+    Wrapped_QueryEnvironment
+
+    AddServer(arg2 string) (err error)
+    AddIndex(arg2 string) (err error)
+    RemoveServer(arg2 string) (err error)
+    RemoveIndex(arg2 string) (err error)
+    Close() (err error)
+    SetMemory(arg2 int64) (err error)
+    SetScoringRules(arg2 []string) (err error)
+    SetStopwords(arg2 []string) (err error)
+    RunQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+    RunQueryRequest(arg2 QueryRequest) (_swig_ret QueryResults, err error)
+    RunAnnotatedQuery(a ...interface{}) (_swig_ret Annotation, err error)
+    RunQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret []ScoredResult, err error)
+    RunAnnotatedQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret Annotation, err error)
+    Documents(a ...interface{}) (_swig_ret []Document, err error)
+    DocumentMetadata(a ...interface{}) (_swig_ret []string, err error)
+    DocumentIDsFromMetadata(arg2 string, arg3 []string) (_swig_ret []int, err error)
+    DocumentsFromMetadata(arg2 string, arg3 []string) (_swig_ret []Document, err error)
+    TermCount(a ...interface{}) (_swig_ret int64, err error)
+    TermFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error)
+    FieldList() (_swig_ret []string, err error)
+    DocumentCount(a ...interface{}) (_swig_ret int64, err error)
+    DocumentVectors(arg2 []int) (_swig_ret []DocumentVector, err error)
+    ExpressionCount(a ...interface{}) (_swig_ret float64, err error)
+    DocumentExpressionCount(a ...interface{}) (_swig_ret float64, err error)
+    ExpressionList(a ...interface{}) (_swig_ret []ScoredResult, err error)
+    DocumentLength(arg2 int) (_swig_ret int, err error)
+    SetFormulationParameters(arg2 Wrapped_Parameters) (err error)
+    ReformulateQuery(arg2 string) (_swig_ret string, err error)
+    StemTerm(arg2 string) (_swig_ret string, err error)
+    TermCountUnique() (_swig_ret int64, err error)
+    StemCount(arg2 string) (_swig_ret int64, err error)
+    StemFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error)
+    DocumentStemCount(arg2 string) (_swig_ret int64, err error)
+    Documentsdocids(arg2 []int) (_swig_ret []Document, err error)
+    DocumentMetadatadocids(arg2 []int, arg3 string) (_swig_ret []string, err error)
+    OnetermCount(arg2 string) (_swig_ret int64, err error)
+    OnedocumentCount(arg2 string) (_swig_ret int64, err error)
+    Search(arg2 string, arg3 int, arg4 int) (_swig_ret SearchPage, err error)
+    SearchCursor(arg2 string) (_swig_ret SearchPage, err error)
+    Generation() uint64
+    DocumentExists(arg2 int) (_swig_ret bool, err error)
+}
+
+//
+// queryEnvironment overrides the methods adding and removing indexes and
+// servers to remember them, as indri does not give them back, see
+// IndexStats, and the generation of each index as it was opened, see
+// SearchCursor.
+//
+type queryEnvironment struct {
+    SwigcptrWrapped_QueryEnvironment
+
+    mu sync.Mutex
+    indexes []string
+    servers []string
+    generations map[string]uint64
+}
+
+func NewQueryEnvironment() QueryEnvironment {
+    return &queryEnvironment{SwigcptrWrapped_QueryEnvironment: SwigcptrWrapped_QueryEnvironment(C._wrap_new_Wrapped_QueryEnvironment_indri_go_add17ee78870902e())}
+}
+
+func DeleteQueryEnvironment(arg1 QueryEnvironment) {
+    DeleteWrapped_QueryEnvironment(arg1)
+}
+
+func (e *queryEnvironment) AddServer(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_addServer(arg2)
+    e.mu.Lock()
+    e.servers = append(e.servers, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) AddIndex(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_addIndex(arg2)
+    generation := repositoryGeneration(arg2)
+    e.mu.Lock()
+    e.indexes = append(e.indexes, arg2)
+    if e.generations == nil {
+        e.generations = make(map[string]uint64)
+    }
+    e.generations[arg2] = generation
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) RemoveServer(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_removeServer(arg2)
+    e.mu.Lock()
+    e.servers = removeString(e.servers, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) RemoveIndex(arg2 string) (err error) {
+    defer catch(&err)
+    e.Wrapped_removeIndex(arg2)
+    e.mu.Lock()
+    e.indexes = removeString(e.indexes, arg2)
+    delete(e.generations, arg2)
+    e.mu.Unlock()
+    return
+}
+
+func (e *queryEnvironment) Close() (err error) {
+    defer catch(&err)
+    e.Wrapped_close()
+    e.mu.Lock()
+    e.indexes, e.servers, e.generations = nil, nil, nil
+    e.mu.Unlock()
+    return
+}
+
+//
+// DocumentExists reports whether arg2 is the id of a document of e that is
+// not deleted. Ids are only tied to a repository when e has a single index
+// and no server, otherwise it reports whether the document can be
+// retrieved, deleted or not.
+//
+func (e *queryEnvironment) DocumentExists(arg2 int) (_swig_ret bool, err error) {
+    defer catch(&err)
+    indexes, servers := e.sources()
+    if len(indexes) == 1 && len(servers) == 0 {
+        _swig_ret = C.indri_go_query_environment_document_live(C.uintptr_t(e.Swigcptr()), 0, C.swig_intgo(arg2)) != 0
+        return
+    }
+    documents, err := e.Documentsdocids([]int{arg2})
+    _swig_ret = err == nil && len(documents) > 0
+    return
+}
+
+// sources returns copies of the indexes and servers added to e
+func (e *queryEnvironment) sources() (indexes []string, servers []string) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    return append([]string(nil), e.indexes...), append([]string(nil), e.servers...)
+}
+
+func removeString(values []string, value string) []string {
+    for i, v := range values {
+        if v == value {
+            return append(values[:i:i], values[i+1:]...)
+        }
+    }
+    return values
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetMemory(arg2 int64) (err error) {
+    defer catch(&err)
+    e.Wrapped_setMemory(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetScoringRules(arg2 []string) (err error) {
+    defer catch(&err)
+    rules := newStringVector(arg2)
+    defer DeleteStringVector(rules)
+    e.Wrapped_setScoringRules(rules)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetStopwords(arg2 []string) (err error) {
+    defer catch(&err)
+    stopwords := newStringVector(arg2)
+    defer DeleteStringVector(stopwords)
+    e.Wrapped_setStopwords(stopwords)
+    return
+}
+
+//
+// RunQuery runs query against the open indexes and returns at most
+// resultsRequested results. An optional document set restricts the
+// query to the listed documents:
+//
+//   RunQuery(query string, resultsRequested int)
+//   RunQuery(query string, documentSet []int, resultsRequested int)
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        _swig_ret = takeScoredResults(e.Wrapped_runQuery(a[0].(string), a[1].(int)))
+        return
+    }
+    if argc == 3 {
+        return e.RunQuerydocset(a[0].(string), a[1].([]int), a[2].(int))
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// RunQueryRequest runs a query request and returns the requested page of
+// results with their snippets and metadata.
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunQueryRequest(arg2 QueryRequest) (_swig_ret QueryResults, err error) {
+    defer catch(&err)
+    request := newQueryRequest(arg2)
+    defer C.indri_go_query_request_delete(C.uintptr_t(request))
+    _swig_ret = takeQueryResults(uintptr(C.indri_go_query_request_run(C.uintptr_t(e), C.uintptr_t(request))))
+    return
+}
+
+//
+// RunAnnotatedQuery takes the same arguments as RunQuery and also returns
+// the parsed query tree and the extents matched by each of its nodes.
+//
+func (e SwigcptrWrapped_QueryEnvironment) RunAnnotatedQuery(a ...interface{}) (_swig_ret Annotation, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        _swig_ret = takeAnnotation(e.Wrapped_runAnnotatedQuery(a[0].(string), a[1].(int)))
+        return
+    }
+    if argc == 3 {
+        return e.RunAnnotatedQuerydocset(a[0].(string), a[1].([]int), a[2].(int))
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) RunQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    docset := newIntVector(arg3)
+    defer DeleteIntVector(docset)
+    //
+    // runQuerydocset is the same C++ call as runQuery with a document set,
+    // the lemur DOCID_T vector is an opaque type in go so we use the int
+    // vector overload.
+    //
+    _swig_ret = takeScoredResults(e.Wrapped_runQuery(arg2, docset, arg4))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) RunAnnotatedQuerydocset(arg2 string, arg3 []int, arg4 int) (_swig_ret Annotation, err error) {
+    defer catch(&err)
+    docset := newIntVector(arg3)
+    defer DeleteIntVector(docset)
+    _swig_ret = takeAnnotation(e.Wrapped_runAnnotatedQuery(arg2, docset, arg4))
+    return
+}
+
+//
+// Documents fetches the parsed documents for a list of document ids, or
+// for the documents of a result list:
+//
+//   Documents(documentIDs []int)
+//   Documents(results []ScoredResult)
+//
+func (e SwigcptrWrapped_QueryEnvironment) Documents(a ...interface{}) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        switch arg2 := a[0].(type) {
+        case []int:
+            ids := newIntVector(arg2)
+            defer DeleteIntVector(ids)
+            _swig_ret = takeDocuments(e.Wrapped_documents(ids))
+            return
+        case []ScoredResult:
+            results := newScoredResultVector(arg2)
+            defer deleteScoredResultVector(results)
+            _swig_ret = takeDocuments(e.Wrapped_documents(results))
+            return
+        }
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// DocumentMetadata fetches one metadata field for a list of document ids,
+// or for the documents of a result list:
+//
+//   DocumentMetadata(documentIDs []int, attributeName string)
+//   DocumentMetadata(results []ScoredResult, attributeName string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) DocumentMetadata(a ...interface{}) (_swig_ret []string, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 2 {
+        switch arg2 := a[0].(type) {
+        case []int:
+            ids := newIntVector(arg2)
+            defer DeleteIntVector(ids)
+            _swig_ret = takeStrings(e.Wrapped_documentMetadata(ids, a[1].(string)))
+            return
+        case []ScoredResult:
+            results := newScoredResultVector(arg2)
+            defer deleteScoredResultVector(results)
+            _swig_ret = takeStrings(e.Wrapped_documentMetadata(results, a[1].(string)))
+            return
+        }
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentIDsFromMetadata(arg2 string, arg3 []string) (_swig_ret []int, err error) {
+    defer catch(&err)
+    values := newStringVector(arg3)
+    defer DeleteStringVector(values)
+    _swig_ret = takeInts(e.Wrapped_documentIDsFromMetadata(arg2, values))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentsFromMetadata(arg2 string, arg3 []string) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    values := newStringVector(arg3)
+    defer DeleteStringVector(values)
+    _swig_ret = takeDocuments(e.Wrapped_documentsFromMetadata(arg2, values))
+    return
+}
+
+//
+// TermCount returns the total number of terms in the collection, or the
+// number of occurrences of a term:
+//
+//   TermCount()
+//   TermCount(term string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) TermCount(a ...interface{}) (_swig_ret int64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 0 {
+        _swig_ret = e.Wrapped_termCount()
+        return
+    }
+    if argc == 1 {
+        _swig_ret = e.Wrapped_termCount(a[0].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) TermFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_termFieldCount(arg2, arg3)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) FieldList() (_swig_ret []string, err error) {
+    defer catch(&err)
+    _swig_ret = takeStrings(e.Wrapped_fieldList())
+    return
+}
+
+//
+// DocumentCount returns the number of documents in the collection, or the
+// number of documents containing a term:
+//
+//   DocumentCount()
+//   DocumentCount(term string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) DocumentCount(a ...interface{}) (_swig_ret int64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 0 {
+        _swig_ret = e.Wrapped_documentCount()
+        return
+    }
+    if argc == 1 {
+        _swig_ret = e.Wrapped_documentCount(a[0].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentVectors(arg2 []int) (_swig_ret []DocumentVector, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeDocumentVectors(e.Wrapped_documentVectors(ids))
+    return
+}
+
+//
+// ExpressionCount returns the number of matches of a query expression:
+//
+//   ExpressionCount(expression string)
+//   ExpressionCount(expression string, queryType string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) ExpressionCount(a ...interface{}) (_swig_ret float64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = e.Wrapped_expressionCount(a[0].(string))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = e.Wrapped_expressionCount(a[0].(string), a[1].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+// see ExpressionCount
+func (e SwigcptrWrapped_QueryEnvironment) DocumentExpressionCount(a ...interface{}) (_swig_ret float64, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = e.Wrapped_documentExpressionCount(a[0].(string))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = e.Wrapped_documentExpressionCount(a[0].(string), a[1].(string))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+//
+// ExpressionList returns the matches of a query expression:
+//
+//   ExpressionList(expression string)
+//   ExpressionList(expression string, queryType string)
+//
+func (e SwigcptrWrapped_QueryEnvironment) ExpressionList(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    argc := len(a)
+    if argc == 1 {
+        _swig_ret = takeScoredResults(e.Wrapped_expressionList(a[0].(string)))
+        return
+    }
+    if argc == 2 {
+        _swig_ret = takeScoredResults(e.Wrapped_expressionList(a[0].(string), a[1].(string)))
+        return
+    }
+    panic("No match for overloaded function call")
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentLength(arg2 int) (_swig_ret int, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_documentLength(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) SetFormulationParameters(arg2 Wrapped_Parameters) (err error) {
+    defer catch(&err)
+    e.Wrapped_setFormulationParameters(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) ReformulateQuery(arg2 string) (_swig_ret string, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_reformulateQuery(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemTerm(arg2 string) (_swig_ret string, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemTerm(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) TermCountUnique() (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_termCountUnique()
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) StemFieldCount(arg2 string, arg3 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_stemFieldCount(arg2, arg3)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentStemCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_documentStemCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) Documentsdocids(arg2 []int) (_swig_ret []Document, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeDocuments(e.Wrapped_documentsdocids(docidVector(ids)))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) DocumentMetadatadocids(arg2 []int, arg3 string) (_swig_ret []string, err error) {
+    defer catch(&err)
+    ids := newIntVector(arg2)
+    defer DeleteIntVector(ids)
+    _swig_ret = takeStrings(e.Wrapped_documentMetadatadocids(docidVector(ids), arg3))
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) OnetermCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_onetermCount(arg2)
+    return
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) OnedocumentCount(arg2 string) (_swig_ret int64, err error) {
+    defer catch(&err)
+    _swig_ret = e.Wrapped_onedocumentCount(arg2)
+    return
+}
+
+//
+// newIntVector copies a go int slice into a new C++ int vector, the caller
+// must DeleteIntVector it when done.
+//
+func newIntVector(arg1 []int) IntVector {
+    v := NewIntVector()
+    v.Reserve(int64(len(arg1)))
+    for _, i := range arg1 {
+        v.Add(i)
+    }
+    return v
+}
+
+//
+// docidVector views an int vector as a lemur DOCID_T vector, DOCID_T is a
+// typedef of int so the two C++ types share a layout.
+//
+func docidVector(arg1 IntVector) Std_vector_Sl_lemur_api_DOCID_T_Sg_ {
+    return SwigcptrStd_vector_Sl_lemur_api_DOCID_T_Sg_(arg1.Swigcptr())
+}
+
+// takeInts copies and then frees a C++ int vector returned by value.
+func takeInts(arg1 IntVector) (_swig_ret []int) {
+    defer DeleteIntVector(arg1)
+    n := int(arg1.Size())
+    _swig_ret = make([]int, n)
+    for i := 0; i < n; i++ {
+        _swig_ret[i] = arg1.Get(i)
+    }
+    return
+}
+
+//
+// newStringVector copies a go string slice into a new C++ string vector, the
+// caller must DeleteStringVector it when done.
+//
+func newStringVector(arg1 []string) StringVector {
+    v := NewStringVector()
+    v.Reserve(int64(len(arg1)))
+    for _, s := range arg1 {
+        v.Add(s)
+    }
+    return v
+}
+
+// takeStrings copies and then frees a C++ string vector returned by value.
+func takeStrings(arg1 StringVector) (_swig_ret []string) {
+    defer DeleteStringVector(arg1)
+    n := int(arg1.Size())
+    _swig_ret = make([]string, n)
+    for i := 0; i < n; i++ {
+        _swig_ret[i] = arg1.Get(i)
+    }
+    return
+}
+
+
+
+
+//
+//  collection statistics
+//
+
+// FieldStats counts the extents of an indexed field and the terms in them
+type FieldStats struct {
+    Name string
+    Extents int64
+    Terms int64
+}
+
+// LengthBucket counts the documents with Min to Max terms
+type LengthBucket struct {
+    Min int
+    Max int
+    Documents int64
+}
+
+//
+// IndexStatistics describes the collection of a QueryEnvironment. The
+// MetadataFields are the forward and backward lookup fields read from the
+// manifest of each index, any other metadata is only stored.
+//
+type IndexStatistics struct {
+    Documents int64
+    Terms int64
+    UniqueTerms int64
+    AverageDocumentLength float64
+    Fields []FieldStats
+    DocumentLengths []LengthBucket
+    MetadataFields []string
+}
+
+//
+// IndexStats collects the statistics of the indexes and servers added to
+// env, which must come from NewQueryEnvironment as the indexes it keeps
+// track of are read from their manifests. Field extents are counted by
+// the query #any:field, and field terms from the statistics of the local
+// indexes. DocumentLengths come from the length of every document not
+// deleted, in power of two buckets. Document ids are only contiguous over
+// a single index, so DocumentLengths is left empty for an environment with
+// more than one index or any server.
+//
+func IndexStats(env QueryEnvironment) (stats IndexStatistics, err error) {
+    defer catch(&err)
+
+    qe, ok := env.(*queryEnvironment)
+    if !ok {
+        err = fmt.Errorf("IndexStats of a %T, expected a QueryEnvironment from NewQueryEnvironment", env)
+        return
+    }
+
+    if stats.Documents, err = env.DocumentCount(); err != nil {
+        return
+    }
+    if stats.Terms, err = env.TermCount(); err != nil {
+        return
+    }
+    if stats.UniqueTerms, err = env.TermCountUnique(); err != nil {
+        return
+    }
+    if stats.Documents > 0 {
+        stats.AverageDocumentLength = float64(stats.Terms) / float64(stats.Documents)
+    }
+
+    fields, err := env.FieldList()
+    if err != nil {
+        return
+    }
+    for _, name := range fields {
+        extents, e := env.ExpressionCount("#any:" + name)
+        if e != nil {
+            err = e
+            return
+        }
+        f := FieldStats{Name: name, Extents: int64(extents)}
+        if f.Terms, err = fieldTermCount(env, name); err != nil {
+            return
+        }
+        stats.Fields = append(stats.Fields, f)
+    }
+
+    indexes, servers := qe.sources()
+    if len(indexes) == 1 && len(servers) == 0 {
+        if stats.DocumentLengths, err = documentLengthHistogram(env); err != nil {
+            return
+        }
+    }
+
+    seen := make(map[string]bool)
+    for _, index := range indexes {
+        names, e := manifestMetadataFields(index)
+        if e != nil {
+            err = e
+            return
+        }
+        for _, name := range names {
+            if !seen[name] {
+                seen[name] = true
+                stats.MetadataFields = append(stats.MetadataFields, name)
+            }
+        }
+    }
+    return
+}
+
+// documentLengthHistogram buckets the document lengths of the single index of env
+func documentLengthHistogram(env QueryEnvironment) (buckets []LengthBucket, err error) {
+    defer catch(&err)
+    counts := takeInts(SwigcptrIntVector(C.indri_go_document_length_histogram(C.uintptr_t(env.Swigcptr()), 0)))
+    for i, n := range counts {
+        min, max := 0, 0
+        if i > 0 {
+            min, max = 1 << uint(i-1), 1 << uint(i) - 1
+        }
+        buckets = append(buckets, LengthBucket{Min: min, Max: max, Documents: int64(n)})
+    }
+    return
+}
+
+// fieldTermCount counts the terms in the extents of a field over the local indexes of env
+func fieldTermCount(env QueryEnvironment, name string) (count int64, err error) {
+    defer catch(&err)
+    count = int64(C.indri_go_field_term_count(C.uintptr_t(env.Swigcptr()), gostring(name)))
+    return
+}
+
+// manifestMetadataFields reads the metadata lookup fields of a repository
+func manifestMetadataFields(repositoryPath string) (names []string, err error) {
+    b, err := ioutil.ReadFile(filepath.Join(repositoryPath, "manifest"))
+    if err != nil {
+        return
+    }
+    var manifest struct {
+        Metadata xmlIndexMetadata `xml:"metadata"`
+    }
+    if err = xml.Unmarshal(b, &manifest); err != nil {
+        err = fmt.Errorf("failed to parse %v manifest: %v", repositoryPath, err)
+        return
+    }
+    seen := make(map[string]bool)
+    for _, name := range append(manifest.Metadata.Forward, manifest.Metadata.Backward...) {
+        name = strings.TrimSpace(name)
+        if name != "" && !seen[name] {
+            seen[name] = true
+            names = append(names, name)
+        }
+    }
+    return
+}
+
+
+
+
+//
+//  paged search
+//
+
+var (
+    // ErrInvalidCursor is returned by SearchCursor for a token it did not make
+    ErrInvalidCursor = errors.New("indri_go: invalid search cursor")
+    // ErrStaleCursor is returned by SearchCursor once the collection changed
+    ErrStaleCursor = errors.New("indri_go: stale search cursor")
+)
+
+//
+// SearchPage is a page of ranked results. Total is the number of matching
+// documents, exact when TotalExact and otherwise an estimate. Next and Prev
+// are cursor tokens for the neighbouring pages, empty when there is none.
+//
+type SearchPage struct {
+    Results []QueryResult
+    Offset int
+    Limit int
+    Total int
+    TotalExact bool
+    Next string
+    Prev string
+}
+
+//
+// searchCursor is the content of a cursor token, the page to run and the
+// document count and generation of the collection it was made for.
+//
+type searchCursor struct {
+    Query string `json:"q"`
+    Offset int `json:"o"`
+    Limit int `json:"l"`
+    Documents int64 `json:"n"`
+    Generation uint64 `json:"g,omitempty"`
+}
+
+//
+// searchCursorKey signs the cursor tokens of this process, so that a
+// client cannot rewrite the offset or limit of a page it was given. Tokens
+// are only valid in the process that made them.
+//
+var searchCursorKey = func() []byte {
+    key := make([]byte, 32)
+    if _, err := rand.Read(key); err != nil {
+        panic(fmt.Sprintf("indri_go: no random search cursor key: %v", err))
+    }
+    return key
+}()
+
+func searchCursorMAC(payload []byte) []byte {
+    mac := hmac.New(sha256.New, searchCursorKey)
+    mac.Write(payload)
+    return mac.Sum(nil)
+}
+
+// token is the json of c and its signature, each base64 encoded and joined by a '.'
+func (c searchCursor) token() string {
+    b, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(b) + "." + base64.RawURLEncoding.EncodeToString(searchCursorMAC(b))
+}
+
+func parseSearchCursor(token string) (c searchCursor, err error) {
+    var b, sig []byte
+    payload, signature := token, ""
+    if i := strings.IndexByte(token, '.'); i >= 0 {
+        payload, signature = token[:i], token[i+1:]
+    }
+    b, err = base64.RawURLEncoding.DecodeString(payload)
+    if err == nil {
+        sig, err = base64.RawURLEncoding.DecodeString(signature)
+    }
+    if err == nil && !hmac.Equal(sig, searchCursorMAC(b)) {
+        err = ErrInvalidCursor
+    }
+    if err == nil {
+        err = json.Unmarshal(b, &c)
+    }
+    if err != nil || c.Query == "" || c.Offset < 0 || c.Limit < 1 {
+        err = ErrInvalidCursor
+    }
+    return
+}
+
+//
+// Search returns the limit results of query ranked after the first offset.
+// One extra result is requested to tell whether another page follows; when
+// none does, and the page is not past the last result, Total is exact.
+// Otherwise it is the largest of the estimatedMatches of indri and the
+// number of documents holding any extent of query, see query.Extents.
+//
+func (e *queryEnvironment) Search(arg2 string, arg3 int, arg4 int) (_swig_ret SearchPage, err error) {
+    defer catch(&err)
+    documents, err := e.DocumentCount()
+    if err != nil {
+        return
+    }
+    return e.search(searchCursor{Query: arg2, Offset: arg3, Limit: arg4, Documents: documents, Generation: e.Generation()})
+}
+
+//
+// SearchCursor returns the page of a Next or Prev token of a SearchPage.
+// Tokens are signed, one that was altered or made by another process
+// returns ErrInvalidCursor. A token made before documents were added to or removed from the collection
+// returns ErrStaleCursor, since ranks may have moved. The document count
+// and the generations of the indexes, as they were opened, must both match.
+//
+func (e *queryEnvironment) SearchCursor(arg2 string) (_swig_ret SearchPage, err error) {
+    defer catch(&err)
+    c, err := parseSearchCursor(arg2)
+    if err != nil {
+        return
+    }
+    documents, err := e.DocumentCount()
+    if err != nil {
+        return
+    }
+    if documents != c.Documents || e.Generation() != c.Generation {
+        err = ErrStaleCursor
+        return
+    }
+    return e.search(c)
+}
+
+//
+// Generation identifies the collection of e as its indexes were opened,
+// combining the generations of the indexes, in their order, and the
+// servers. It changes once documents are added to or deleted from an
+// index and the index is opened again. Servers are identified by name only.
+//
+func (e *queryEnvironment) Generation() uint64 {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    h := fnv.New64a()
+    for _, index := range e.indexes {
+        fmt.Fprintf(h, "%v\x00%v\x00", index, e.generations[index])
+    }
+    for _, server := range e.servers {
+        fmt.Fprintf(h, "%v\x00", server)
+    }
+    return h.Sum64()
+}
+
+//
+// repositoryGeneration fingerprints the state of the repository at path:
+// the names of its index directories, which indri numbers anew on every
+// flush and merge, and its deleted list. Adding documents makes a new
+// index directory and deleting them changes the deleted list, so any
+// change a reader opening the repository would see changes it.
+//
+func repositoryGeneration(path string) uint64 {
+    h := fnv.New64a()
+    if infos, err := ioutil.ReadDir(filepath.Join(path, "index")); err == nil {
+        for _, info := range infos {
+            fmt.Fprintf(h, "%v\x00", info.Name())
+        }
+    }
+    if b, err := ioutil.ReadFile(filepath.Join(path, "deleted")); err == nil {
+        h.Write(b)
+    }
+    return h.Sum64()
+}
+
+func (e SwigcptrWrapped_QueryEnvironment) search(c searchCursor) (page SearchPage, err error) {
+    if c.Offset < 0 {
+        err = fmt.Errorf("negative search offset %v", c.Offset)
+        return
+    }
+    if c.Limit < 1 {
+        err = fmt.Errorf("search limit %v is less than 1", c.Limit)
+        return
+    }
+    results, err := e.RunQueryRequest(QueryRequest{Query: c.Query, ResultsRequested: c.Limit + 1, StartNum: c.Offset})
+    if err != nil {
+        return
+    }
+    page = SearchPage{Results: results.Results, Offset: c.Offset, Limit: c.Limit}
+    more := len(page.Results) > c.Limit
+    if more {
+        page.Results = page.Results[:c.Limit]
+    }
+    // past the last result the offset says nothing of the total
+    past := len(page.Results) == 0 && c.Offset > 0
+    if !past {
+        page.Total = c.Offset + len(page.Results)
+    }
+    if !more && !past {
+        page.TotalExact = true
+    } else {
+        if results.EstimatedMatches > page.Total {
+            page.Total = results.EstimatedMatches
+        }
+        if n, e := e.extentDocumentCount(c.Query); e == nil && n > page.Total {
+            page.Total = n
+        }
+    }
+    if more {
+        next := c
+        next.Offset += c.Limit
+        page.Next = next.token()
+    }
+    if c.Offset > 0 {
+        prev := c
+        prev.Offset -= c.Limit
+        if prev.Offset < 0 {
+            prev.Offset = 0
+        }
+        page.Prev = prev.token()
+    }
+    return
+}
+
+//
+// extentDocumentCount counts the documents holding any extent of text, an
+// upper bound of the documents a #combine of them ranks.
+//
+func (e SwigcptrWrapped_QueryEnvironment) extentDocumentCount(text string) (n int, err error) {
+    node, err := query.Parse(text)
+    if err != nil {
+        return
+    }
+    extents := query.Extents(node)
+    if len(extents) == 0 {
+        err = fmt.Errorf("query %q has no extents", text)
+        return
+    }
+    s, err := query.Render(query.Syn(extents...))
+    if err != nil {
+        return
+    }
+    count, err := e.DocumentExpressionCount(s)
+    n = int(count)
+    return
+}
+
+
+
+
+//
+//  extend QueryExpander.i
+//
+type RMExpander interface {
+    Wrapped_RMExpander
+
+    RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+}
+
+func NewRMExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) RMExpander {
+    return (RMExpander)(SwigcptrWrapped_RMExpander(C._wrap_new_Wrapped_RMExpander_indri_go_add17ee78870902e(C.uintptr_t(arg1.Swigcptr()), C.uintptr_t(arg2.Swigcptr()))))
+}
+
+func DeleteRMExpander(arg1 RMExpander) {
+    DeleteWrapped_RMExpander(arg1)
+}
+
+//
+// RunExpandedQuery runs the original query, expands it from the top
+// results and runs the expanded query:
+//
+//   RunExpandedQuery(originalQuery string, resultsRequested int)
+//   RunExpandedQuery(originalQuery string, resultsRequested int, verbose bool)
+//
+func (e SwigcptrWrapped_RMExpander) RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    _swig_ret = takeScoredResults(e.Wrapped_runExpandedQuery(a...))
+    return
+}
+
+type PonteExpander interface {
+    Wrapped_PonteExpander
+
+    RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error)
+}
+
+func NewPonteExpander(arg1 Wrapped_QueryEnvironment, arg2 Wrapped_Parameters) PonteExpander {
+    return (PonteExpander)(SwigcptrWrapped_PonteExpander(C._wrap_new_Wrapped_PonteExpander_indri_go_add17ee78870902e(C.uintptr_t(arg1.Swigcptr()), C.uintptr_t(arg2.Swigcptr()))))
+}
+
+func DeletePonteExpander(arg1 PonteExpander) {
+    DeleteWrapped_PonteExpander(arg1)
+}
+
+// see RMExpander RunExpandedQuery
+func (e SwigcptrWrapped_PonteExpander) RunExpandedQuery(a ...interface{}) (_swig_ret []ScoredResult, err error) {
+    defer catch(&err)
+    _swig_ret = takeScoredResults(e.Wrapped_runExpandedQuery(a...))
+    return
+}
+
+
+
+
+//
+//  go owned wrappers
+//
+// each Owned* type holds one swig wrapper and frees it on Close, or from a
+// finalizer when it is garbage collected without Close. The wrapper is
+// only reachable inside Do, which returns ErrClosed once the owner is
+// closed, so it cannot be used after it is freed. Calls to Do run one at
+// a time, as the wrapped objects are not safe for concurrent use, and
+// Close waits for the call in progress. fn must not call Do or Close on
+// the same owner.
+//
+
+// ErrClosed is returned by Do on a closed Owned* wrapper
+var ErrClosed = errors.New("indri_go: use of closed handle")
+
+type owned struct {
+    mu sync.Mutex
+    free func() error
+}
+
+func (o *owned) do(fn func() error) (err error) {
+    defer catch(&err)
+    o.mu.Lock()
+    defer o.mu.Unlock()
+    if o.free == nil {
+        return ErrClosed
+    }
+    return fn()
+}
+
+func (o *owned) close() (err error) {
+    defer catch(&err)
+    o.mu.Lock()
+    defer o.mu.Unlock()
+    if o.free == nil {
+        return nil
+    }
+    free := o.free
+    o.free = nil
+    return free()
+}
+
+// OwnedParameters owns a Parameters
+type OwnedParameters struct {
+    owned
+    p Parameters
+}
+
+func NewOwnedParameters() *OwnedParameters {
+    p := NewParameters()
+    o := &OwnedParameters{p: p}
+    o.free = func() error {
+        DeleteWrapped_Parameters(p)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedParameters).Close)
+    return o
+}
+
+func (o *OwnedParameters) Do(fn func(p Parameters) error) error {
+    return o.do(func() error { return fn(o.p) })
+}
+
+func (o *OwnedParameters) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+// OwnedStringVector owns a StringVector
+type OwnedStringVector struct {
+    owned
+    v StringVector
+}
+
+func NewOwnedStringVector(values []string) *OwnedStringVector {
+    v := newStringVector(values)
+    o := &OwnedStringVector{v: v}
+    o.free = func() error {
+        DeleteStringVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedStringVector).Close)
+    return o
+}
+
+func (o *OwnedStringVector) Do(fn func(v StringVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedStringVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+// OwnedIntVector owns an IntVector
+type OwnedIntVector struct {
+    owned
+    v IntVector
+}
+
+func NewOwnedIntVector(values []int) *OwnedIntVector {
+    v := newIntVector(values)
+    o := &OwnedIntVector{v: v}
+    o.free = func() error {
+        DeleteIntVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedIntVector).Close)
+    return o
+}
+
+func (o *OwnedIntVector) Do(fn func(v IntVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedIntVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedMetadataPairVector owns a MetadataPairVector. The MetadataPairs
+// added to it are still owned by the caller.
+//
+type OwnedMetadataPairVector struct {
+    owned
+    v Wrapped_MetadataPairVector
+}
+
+func NewOwnedMetadataPairVector() *OwnedMetadataPairVector {
+    v := NewWrapped_MetadataPairVector()
+    o := &OwnedMetadataPairVector{v: v}
+    o.free = func() error {
+        DeleteWrapped_MetadataPairVector(v)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedMetadataPairVector).Close)
+    return o
+}
+
+func (o *OwnedMetadataPairVector) Do(fn func(v Wrapped_MetadataPairVector) error) error {
+    return o.do(func() error { return fn(o.v) })
+}
+
+func (o *OwnedMetadataPairVector) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedQueryEnvironment owns a QueryEnvironment, Close closes its indexes
+// and servers before it is freed.
+//
+type OwnedQueryEnvironment struct {
+    owned
+    env QueryEnvironment
+}
+
+func NewOwnedQueryEnvironment() *OwnedQueryEnvironment {
+    env := NewQueryEnvironment()
+    o := &OwnedQueryEnvironment{env: env}
+    o.free = func() error {
+        defer DeleteQueryEnvironment(env)
+        return env.Close()
+    }
+    runtime.SetFinalizer(o, (*OwnedQueryEnvironment).Close)
+    return o
+}
+
+func (o *OwnedQueryEnvironment) Do(fn func(env QueryEnvironment) error) error {
+    return o.do(func() error { return fn(o.env) })
+}
+
+func (o *OwnedQueryEnvironment) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedIndexEnvironment owns an IndexEnvironment, Close closes the
+// repository before it is freed. Closing flushes the repository to disk,
+// so its finalizer closes on a goroutine of its own rather than on the
+// finalizer goroutine.
+//
+// monitor, which may be nil, is the status monitor to give Create or Open
+// from inside Do. The environment holds it and closes it after the
+// repository, so it cannot be freed while the repository still calls it.
+//
+type OwnedIndexEnvironment struct {
+    owned
+    env IndexEnvironment
+    monitor *OwnedStatusMonitor
+}
+
+func NewOwnedIndexEnvironment(monitor *OwnedStatusMonitor) *OwnedIndexEnvironment {
+    env := NewIndexEnvironment()
+    o := &OwnedIndexEnvironment{env: env, monitor: monitor}
+    if monitor != nil {
+        monitor.mu.Lock()
+        monitor.held = true
+        monitor.mu.Unlock()
+        runtime.SetFinalizer(monitor, nil)
+    }
+    o.free = func() error {
+        if monitor != nil {
+            defer monitor.close()
+        }
+        defer DeleteWrapped_IndexEnvironment(env)
+        return env.Close()
+    }
+    runtime.SetFinalizer(o, func(o *OwnedIndexEnvironment) { go o.Close() })
+    return o
+}
+
+func (o *OwnedIndexEnvironment) Do(fn func(env IndexEnvironment) error) error {
+    return o.do(func() error { return fn(o.env) })
+}
+
+func (o *OwnedIndexEnvironment) Close() error {
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+//
+// OwnedStatusMonitor owns a MyStatusMonitor. Once given to
+// NewOwnedIndexEnvironment it is closed with that environment, and its own
+// Close does nothing.
+//
+type OwnedStatusMonitor struct {
+    owned
+    m MyStatusMonitor
+    held bool
+}
+
+func NewOwnedStatusMonitor() *OwnedStatusMonitor {
+    m := NewMyStatusMonitor()
+    o := &OwnedStatusMonitor{m: m}
+    o.free = func() error {
+        DeleteMyStatusMonitor(m)
+        return nil
+    }
+    runtime.SetFinalizer(o, (*OwnedStatusMonitor).Close)
+    return o
+}
+
+func (o *OwnedStatusMonitor) Do(fn func(m MyStatusMonitor) error) error {
+    return o.do(func() error { return fn(o.m) })
+}
+
+func (o *OwnedStatusMonitor) Close() error {
+    o.mu.Lock()
+    held := o.held
+    o.mu.Unlock()
+    if held {
+        return nil
+    }
+    runtime.SetFinalizer(o, nil)
+    return o.close()
+}
+
+var (
+    _ io.Closer = (*OwnedParameters)(nil)
+    _ io.Closer = (*OwnedStringVector)(nil)
+    _ io.Closer = (*OwnedIntVector)(nil)
+    _ io.Closer = (*OwnedMetadataPairVector)(nil)
+    _ io.Closer = (*OwnedQueryEnvironment)(nil)
+    _ io.Closer = (*OwnedIndexEnvironment)(nil)
+    _ io.Closer = (*OwnedStatusMonitor)(nil)
+)
+
+
+
+
+//
+//  pool of QueryEnvironments for concurrent queries
+//
+
+//
+// QueryPoolStats is a snapshot of a QueryPool. WaitTime and HoldTime are
+// totals over all Acquires, see AverageWait and AverageHold.
+//
+type QueryPoolStats struct {
+    Size int
+    InUse int
+    Waiters int
+    Acquires int64
+    Errors int64
+    Reopens int64
+    WaitTime time.Duration
+    HoldTime time.Duration
+}
+
+// AverageWait is the mean time Acquire waited for an environment
+func (s QueryPoolStats) AverageWait() time.Duration {
+    if s.Acquires == 0 {
+        return 0
     }
-    m.WReset()
+    return s.WaitTime / time.Duration(s.Acquires)
+}
 
-    return
+// AverageHold is the mean time an environment was held before Release
+func (s QueryPoolStats) AverageHold() time.Duration {
+    released := s.Acquires - int64(s.InUse)
+    if released <= 0 {
+        return 0
+    }
+    return s.HoldTime / time.Duration(released)
 }
 
-func (m SwigcptrWrapped_MetadataPair) WSetKey(arg2 string) (err error) {
-    defer catch(&err)
-    m.SetKey(arg2)
-    return
+//
+// QueryPool holds size QueryEnvironments over the same indexes and servers.
+// A QueryEnvironment is used by one goroutine at a time, between Acquire
+// and Release.
+//
+type QueryPool struct {
+    indexes []string
+    servers []string
+
+    // idle holds size slots, a nil slot is an environment to reopen
+    idle chan QueryEnvironment
+    done chan struct{}
+
+    mu sync.Mutex
+    closed bool
+    acquired map[uintptr]time.Time
+    stats QueryPoolStats
+
+    // generation counts the Refreshes, generations holds the generation
+    // each open environment was opened in
+    generation int64
+    generations map[uintptr]int64
 }
 
-func (m SwigcptrWrapped_MetadataPair) WGetKey() (_swig_ret string, err error) {
-    defer catch(&err)
-    _swig_ret = m.GetKey()
+//
+// NewQueryPool opens size QueryEnvironments, each with every index and
+// server added. It fails if any of them cannot be opened.
+//
+func NewQueryPool(size int, indexes []string, servers []string) (*QueryPool, error) {
+    if size < 1 {
+        return nil, fmt.Errorf("query pool size %v is less than 1", size)
+    }
+    p := &QueryPool{
+        indexes: indexes,
+        servers: servers,
+        idle: make(chan QueryEnvironment, size),
+        done: make(chan struct{}),
+        acquired: make(map[uintptr]time.Time),
+        generations: make(map[uintptr]int64),
+    }
+    p.stats.Size = size
+    for i := 0; i < size; i++ {
+        env, err := p.open()
+        if err != nil {
+            p.Close()
+            return nil, err
+        }
+        p.idle <- env
+    }
+    return p, nil
+}
+
+func (p *QueryPool) open() (env QueryEnvironment, err error) {
+    p.mu.Lock()
+    generation := p.generation
+    p.mu.Unlock()
+
+    env = NewQueryEnvironment()
+    for _, index := range p.indexes {
+        if err = env.AddIndex(index); err != nil {
+            break
+        }
+    }
+    for _, server := range p.servers {
+        if err != nil {
+            break
+        }
+        err = env.AddServer(server)
+    }
+    if err != nil {
+        closeQueryEnvironment(env)
+        env = nil
+        return
+    }
+    p.mu.Lock()
+    p.generations[env.Swigcptr()] = generation
+    p.mu.Unlock()
     return
 }
 
-func (m SwigcptrWrapped_MetadataPair) WSetValue(arg2 []byte)  (err error) {
-    defer catch(&err)
+func closeQueryEnvironment(env QueryEnvironment) {
+    env.Close()
+    DeleteQueryEnvironment(env)
+}
 
-    // don't leak C++ resource that may have already been set
-    p := m.GetValue()
-    l := m.GetValueLength()
-    if l > 0 && p > 0 {
-        Swig_free(p)
+//
+// Acquire waits for an idle QueryEnvironment. It returns ctx.Err() if ctx
+// is done first, and ErrClosed once the pool is closed. An environment
+// that failed to reopen is reopened here, if that fails again the error
+// is returned.
+//
+func (p *QueryPool) Acquire(ctx context.Context) (env QueryEnvironment, err error) {
+    start := time.Now()
+    p.mu.Lock()
+    if p.closed {
+        p.mu.Unlock()
+        return nil, ErrClosed
+    }
+    p.stats.Waiters++
+    p.mu.Unlock()
+
+    var slot bool
+    select {
+    case env = <-p.idle:
+        slot = true
+    case <-p.done:
+        err = ErrClosed
+    case <-ctx.Done():
+        err = ctx.Err()
     }
 
-    p = Swig_malloc(len(arg2))
-    s := (*[1<<30]byte)(unsafe.Pointer(p))[:len(arg2)]
+    if slot && env == nil {
+        if env, err = p.open(); err != nil {
+            p.idle <- nil
+        } else {
+            p.mu.Lock()
+            p.stats.Reopens++
+            p.mu.Unlock()
+        }
+    }
 
-    i := copy(s, arg2)
-    if i != len(arg2) {
-        err = fmt.Errorf("Wrapped_MetadataPair.WSetValue: expected to copy %v but copied %v", len(arg2), i)
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.stats.Waiters--
+    if err == nil && p.closed {
+        closeQueryEnvironment(env)
+        env, err = nil, ErrClosed
+    }
+    if err != nil {
         return
     }
+    now := time.Now()
+    p.acquired[env.Swigcptr()] = now
+    p.stats.InUse++
+    p.stats.Acquires++
+    p.stats.WaitTime += now.Sub(start)
+    return
+}
 
-    m.SetValue(uintptr(unsafe.Pointer(p)))
-    m.SetValueLength(len(arg2))
+//
+// Release returns env to the pool. A non nil queryErr is the error env
+// returned while it was held. When it may have left env broken, an i/o
+// error or a repository not found, env is closed and reopened so that a
+// broken connection is not reused. Other errors, such as a parse error of
+// the query itself, keep env. An environment opened before the last
+// Refresh is reopened too. Releasing a nil environment or one not acquired
+// from the pool is an error.
+//
+func (p *QueryPool) Release(env QueryEnvironment, queryErr error) error {
+    if env == nil {
+        return fmt.Errorf("QueryPool.Release of a nil environment")
+    }
+    broken := brokenEnvironment(queryErr)
+    p.mu.Lock()
+    acquired, ok := p.acquired[env.Swigcptr()]
+    if !ok {
+        p.mu.Unlock()
+        return fmt.Errorf("QueryPool.Release of an environment not acquired from the pool")
+    }
+    delete(p.acquired, env.Swigcptr())
+    p.stats.InUse--
+    p.stats.HoldTime += time.Since(acquired)
+    if queryErr != nil {
+        p.stats.Errors++
+    }
+    broken = broken || p.generations[env.Swigcptr()] != p.generation
+    closed := p.closed
+    if closed || broken {
+        delete(p.generations, env.Swigcptr())
+    }
+    p.mu.Unlock()
 
-    //fmt.Printf("WSetValue: len %v cap %v arg2 %v i %v\n", len(arg2), cap(arg2), arg2, i)
-    return
+    if closed || broken {
+        closeQueryEnvironment(env)
+        env = nil
+    }
+    if broken && !closed {
+        var err error
+        if env, err = p.open(); err == nil {
+            p.mu.Lock()
+            p.stats.Reopens++
+            p.mu.Unlock()
+        }
+    }
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        if env != nil {
+            closeQueryEnvironment(env)
+        }
+        return nil
+    }
+    // never blocks, there are only size slots
+    p.idle <- env
+    return nil
 }
 
-func (m SwigcptrWrapped_MetadataPair) WGetValue() (_swig_ret []byte, err error) {
-    defer catch(&err)
+//
+// brokenEnvironment reports whether err, returned by a QueryEnvironment,
+// may have left it unusable: a lemur i/o error, such as a lost server
+// connection, or a repository that is no longer found.
+//
+func brokenEnvironment(err error) bool {
+    var le *LemurError
+    if !errors.As(err, &le) {
+        return false
+    }
+    return errors.Is(le.Kind, ErrIO) || errors.Is(le.Kind, ErrRepositoryNotFound)
+}
 
-    p := m.GetValue()
-    len := m.GetValueLength()
-    s := (*[1<<30]byte)(unsafe.Pointer(p))[:len]
-    _swig_ret = make([]byte, len, len)
+//
+// Refresh has every environment of the pool reopened, so that the queries
+// run after it see the documents committed to its indexes since. Idle
+// environments are closed now and reopened by the next Acquire, held ones
+// when they are released.
+//
+func (p *QueryPool) Refresh() {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return
+    }
+    p.generation++
+    // never blocks, a slot is taken out before one is put back
+    for n := len(p.idle); n > 0; n-- {
+        select {
+        case env := <-p.idle:
+            if env != nil {
+                delete(p.generations, env.Swigcptr())
+                closeQueryEnvironment(env)
+            }
+            p.idle <- nil
+        default:
+        }
+    }
+}
 
-    i := copy(_swig_ret, s)
-    if i != len {
-        err = fmt.Errorf("Wrapped_MetadataPair.WGetValue: expected to copy %v but copied %v", len, i)
+// Stats returns a snapshot of the pool statistics
+func (p *QueryPool) Stats() QueryPoolStats {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.stats
+}
+
+//
+// Close closes the idle environments and fails waiting Acquires.
+// Environments still held are closed when they are released.
+//
+func (p *QueryPool) Close() error {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return nil
     }
+    p.closed = true
+    close(p.done)
+    for {
+        select {
+        case env := <-p.idle:
+            if env != nil {
+                closeQueryEnvironment(env)
+            }
+        default:
+            return nil
+        }
+    }
+}
 
-	return
+
+
+
+//
+//  single writer index
+//
+// an IndexEnvironment is opened once and written by one thread at a time.
+// IndexWriter owns one on a goroutine locked to its OS thread, and runs
+// the operations queued by any goroutine in order, in batches.
+//
+
+//
+// IndexWriterOptions of an IndexWriter, a zero value uses the defaults.
+// QueueSize operations wait to run before adding another blocks, and up to
+// BatchSize are run together. Writes are committed, the repository closed
+// and reopened so that QueryEnvironments opened after see them, every
+// CommitInterval and after CommitDocuments writes, zero disables either.
+// Commit and Close always commit. Committed, when not nil, is called on
+// the writer goroutine after each commit that succeeds, to refresh the
+// readers of the repository, and must not wait for the writer. Once a
+// commit fails, the operations queued fail with its error until the
+// repository is reopened, by a Commit or by the next CommitInterval tick
+// retrying it.
+//
+type IndexWriterOptions struct {
+    QueueSize int
+    BatchSize int
+    CommitInterval time.Duration
+    CommitDocuments int
+    Committed func()
+}
+
+const (
+    DefaultIndexWriterQueueSize = 1024
+    DefaultIndexWriterBatchSize = 100
+)
+
+//
+// IndexResult is the result of an IndexWriter operation, Docid is the id
+// of a document added by AddString, Deleted the ids of documents deleted.
+//
+type IndexResult struct {
+    Docid int
+    Deleted []int
+}
+
+//
+// IndexFuture is the pending result of an operation, Done is closed once
+// it has run.
+//
+type IndexFuture struct {
+    done chan struct{}
+    result IndexResult
+    err error
+}
+
+func newIndexFuture() *IndexFuture {
+    return &IndexFuture{done: make(chan struct{})}
 }
 
+func (f *IndexFuture) resolve(result IndexResult, err error) {
+    f.result, f.err = result, err
+    close(f.done)
+}
 
+// Done is closed once the operation has run
+func (f *IndexFuture) Done() <-chan struct{} {
+    return f.done
+}
 
+// Wait waits for the operation and returns its result
+func (f *IndexFuture) Wait() (IndexResult, error) {
+    <-f.done
+    return f.result, f.err
+}
 
 //
-//  extend MetadataPairVector.i
+// WaitContext is Wait returning ctx.Err() if ctx is done first. The
+// operation still runs.
 //
+func (f *IndexFuture) WaitContext(ctx context.Context) (IndexResult, error) {
+    select {
+    case <-f.done:
+        return f.result, f.err
+    case <-ctx.Done():
+        return IndexResult{}, ctx.Err()
+    }
+}
 
-type Wrapped_MetadataPairVector interface {
-    MetadataPairVector
+// IndexWriterStats counts the work of an IndexWriter
+type IndexWriterStats struct {
+    Queued int
+    Operations int64
+    Errors int64
+    Batches int64
+    Commits int64
+    LastCommit time.Time
+}
 
-    WReserve(arg2 int64) (err error)
-	WClear() (err error)
-	WAdd(arg2 MetadataPair) (err error)
-	WGet(arg2 int) (_swig_ret MetadataPair, err error)
-	WSet(arg2 int, arg3 MetadataPair) (err error)
+// indexOp is a queued operation, a nil apply is a commit
+type indexOp struct {
+    apply func(env IndexEnvironment) (IndexResult, error)
+    future *IndexFuture
 }
 
-func NewWrapped_MetadataPairVector(a ...interface{}) Wrapped_MetadataPairVector {
-	argc := len(a)
-	if argc == 0 {
-		return (Wrapped_MetadataPairVector)(SwigcptrMetadataPairVector(C._wrap_new_MetadataPairVector__SWIG_0_indri_go_add17ee78870902e()))
-	}
-	if argc == 1 {
-        _swig_i_0 := a[0].(int)
-    	return (Wrapped_MetadataPairVector)(SwigcptrMetadataPairVector(C._wrap_new_MetadataPairVector__SWIG_1_indri_go_add17ee78870902e(C.swig_type_128(_swig_i_0))))
-	}
-	panic("No match for overloaded function call")
+//
+// IndexWriter serializes the writes to a repository through one goroutine.
+//
+type IndexWriter struct {
+    cfg IndexConfig
+    opts IndexWriterOptions
+
+    // mu is held to queue, Close holds it exclusively to close ops
+    mu sync.RWMutex
+    closed bool
+    ops chan indexOp
+    stopped chan struct{}
+    closeErr error
+
+    statsMu sync.Mutex
+    stats IndexWriterStats
 }
 
-func (arg1 SwigcptrMetadataPairVector) WReserve(arg2 int64) (err error) {
-    defer catch(&err)
-    arg1.Reserve(arg2)
-    return
+//
+// NewIndexWriter configures an IndexEnvironment with cfg, opens or
+// creates the repository cfg.Index and starts the writer goroutine. The
+// corpora of cfg are not added. It fails if the repository cannot be
+// opened.
+//
+func NewIndexWriter(cfg IndexConfig, opts IndexWriterOptions) (*IndexWriter, error) {
+    if cfg.Index == "" {
+        return nil, fmt.Errorf("Must specify a index parameter.")
+    }
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = DefaultIndexWriterQueueSize
+    }
+    if opts.BatchSize <= 0 {
+        opts.BatchSize = DefaultIndexWriterBatchSize
+    }
+    w := &IndexWriter{
+        cfg: cfg,
+        opts: opts,
+        ops: make(chan indexOp, opts.QueueSize),
+        stopped: make(chan struct{}),
+    }
+    opened := make(chan error, 1)
+    go w.run(opened)
+    if err := <-opened; err != nil {
+        return nil, err
+    }
+    return w, nil
 }
 
-func (arg1 SwigcptrMetadataPairVector) WClear() (err error) {
+// open returns a new environment on the repository
+func (w *IndexWriter) open() (env IndexEnvironment, err error) {
     defer catch(&err)
-    arg1.Clear()
+    env = NewIndexEnvironment()
+    if err = Configure(env, w.cfg); err == nil {
+        err = openRepository(env, w.cfg.Index, nil)
+    }
+    if err != nil {
+        DeleteWrapped_IndexEnvironment(env)
+        env = nil
+    }
     return
 }
 
-func (arg1 SwigcptrMetadataPairVector) WAdd(arg2 MetadataPair) (err error) {
-    defer catch(&err)
-    arg1.Add(arg2)
-    return
+func closeIndexEnvironment(env IndexEnvironment) error {
+    defer DeleteWrapped_IndexEnvironment(env)
+    return env.Close()
 }
 
-func (arg1 SwigcptrMetadataPairVector) WGet(arg2 int) (_swig_ret MetadataPair, err error) {
-    defer catch(&err)
+//
+// run is the writer goroutine. It stays on one OS thread, as indri only
+// expects a single thread to use the environment.
+//
+func (w *IndexWriter) run(opened chan<- error) {
+    runtime.LockOSThread()
+    defer runtime.UnlockOSThread()
+    defer close(w.stopped)
+
+    env, err := w.open()
+    opened <- err
+    if err != nil {
+        return
+    }
 
-    //
-    // we want to return the extended wrapped pair interface instead
-    // (named MetadataPair). The C++ MetadataPair class is first renamed to
-    // Wrapped_MetadataPair so we can subclass it in GO using the director
-    // feature. When we wrap the (GO) MetadataPairVector specialization, it
-    // references the renamed C++ class type and not the GO subclass. So the
-    // generated code returns the renamed interface type Wrapped_MetadataPair
-    // instead of the GO MetadataPairVector vector interface type.
-    //_swig_ret = arg1.Get(arg2)
-    //return
+    var tick <-chan time.Time
+    if w.opts.CommitInterval > 0 {
+        ticker := time.NewTicker(w.opts.CommitInterval)
+        defer ticker.Stop()
+        tick = ticker.C
+    }
 
-	_swig_i_0 := arg1
-	_swig_i_1 := arg2
-	_swig_ret = (MetadataPair)(SwigcptrWrapped_MetadataPair(C._wrap_MetadataPairVector_get_indri_go_add17ee78870902e(C.uintptr_t(_swig_i_0), C.swig_intgo(_swig_i_1))))
-	return
+    // writes since the last commit
+    writes := 0
+    // the error of a failed commit, writes fail with it until a Commit or
+    // a tick reopens the environment
+    var failed error
+    commit := func() error {
+        var err error
+        if env != nil {
+            err = closeIndexEnvironment(env)
+        }
+        env = nil
+        if err == nil {
+            env, err = w.open()
+        }
+        if err != nil {
+            failed = fmt.Errorf("index writer commit failed, writes are refused until it is reopened: %w", err)
+            return failed
+        }
+        failed = nil
+        writes = 0
+        w.statsMu.Lock()
+        w.stats.Commits++
+        w.stats.LastCommit = time.Now()
+        w.statsMu.Unlock()
+        if w.opts.Committed != nil {
+            w.opts.Committed()
+        }
+        return nil
+    }
+
+    for {
+        var batch []indexOp
+        select {
+        case op, ok := <-w.ops:
+            if !ok {
+                if env != nil {
+                    w.closeErr = closeIndexEnvironment(env)
+                } else {
+                    w.closeErr = failed
+                }
+                return
+            }
+            batch = append(batch, op)
+        case <-tick:
+            if writes > 0 || failed != nil {
+                commit()
+            }
+            continue
+        }
+    gather:
+        for len(batch) < w.opts.BatchSize {
+            select {
+            case op, ok := <-w.ops:
+                if !ok {
+                    break gather
+                }
+                batch = append(batch, op)
+            default:
+                break gather
+            }
+        }
+
+        var commits []*IndexFuture
+        var operations, errs int64
+        for _, op := range batch {
+            if op.apply == nil {
+                if failed != nil {
+                    // retried at once, so that the writes after it run
+                    op.future.resolve(IndexResult{}, commit())
+                } else {
+                    commits = append(commits, op.future)
+                }
+                continue
+            }
+            operations++
+            var result IndexResult
+            err := failed
+            if err == nil {
+                result, err = op.apply(env)
+                writes++
+            }
+            if err != nil {
+                errs++
+            }
+            op.future.resolve(result, err)
+        }
+        w.statsMu.Lock()
+        w.stats.Operations += operations
+        w.stats.Errors += errs
+        w.stats.Batches++
+        w.statsMu.Unlock()
+
+        if failed == nil && (len(commits) > 0 || w.opts.CommitDocuments > 0 && writes >= w.opts.CommitDocuments) {
+            err := commit()
+            for _, f := range commits {
+                f.resolve(IndexResult{}, err)
+            }
+        } else {
+            for _, f := range commits {
+                f.resolve(IndexResult{}, failed)
+            }
+        }
+    }
 }
 
-func (arg1 SwigcptrMetadataPairVector) WSet(arg2 int, arg3 MetadataPair) (err error) {
-    defer catch(&err)
-    arg1.Set(arg2, arg3)
-    return
+// queue adds op to the queue, blocking while it is full
+func (w *IndexWriter) queue(apply func(env IndexEnvironment) (IndexResult, error)) *IndexFuture {
+    f := newIndexFuture()
+    w.mu.RLock()
+    defer w.mu.RUnlock()
+    if w.closed {
+        f.resolve(IndexResult{}, ErrClosed)
+        return f
+    }
+    w.ops <- indexOp{apply: apply, future: f}
+    return f
 }
 
-func DeleteWrapped_MetadataPairVector(arg1 Wrapped_MetadataPairVector) {
-    DeleteMetadataPairVector(arg1)
+// AddString queues adding text, of fileClass, with metadata
+func (w *IndexWriter) AddString(text string, fileClass string, metadata map[string]string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        r.Docid, err = env.AddDocument(text, fileClass, metadata)
+        return
+    })
+}
+
+//
+// AddFile queues adding the documents of the file at path, an empty
+// fileClass is chosen from the file extension. No Docid is returned.
+//
+func (w *IndexWriter) AddFile(path string, fileClass string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        if fileClass == "" {
+            err = env.AddFile(path)
+        } else {
+            err = env.AddFile(path, fileClass)
+        }
+        return
+    })
+}
+
+// DeleteDocument queues deleting the document docid
+func (w *IndexWriter) DeleteDocument(docid int) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        if err = env.DeleteDocument(docid); err == nil {
+            r.Deleted = []int{docid}
+        }
+        return
+    })
+}
+
+// DeleteByDocno queues deleting the documents named docno
+func (w *IndexWriter) DeleteByDocno(docno string) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        r.Deleted, err = env.DeleteByDocno(docno)
+        return
+    })
+}
+
+//
+// Do queues f, run with the environment on the writer goroutine. env must
+// not be used once f returns, and f must not wait for the writer.
+//
+func (w *IndexWriter) Do(f func(env IndexEnvironment) (IndexResult, error)) *IndexFuture {
+    return w.queue(func(env IndexEnvironment) (r IndexResult, err error) {
+        defer catch(&err)
+        return f(env)
+    })
+}
+
+//
+// Commit queues a commit of the operations queued before it, its future
+// is done once they are visible to QueryEnvironments opened after. After
+// a failed commit it reopens the repository, and the writes queued after
+// it run again.
+//
+func (w *IndexWriter) Commit() *IndexFuture {
+    return w.queue(nil)
+}
+
+// Stats returns a snapshot of the writer statistics
+func (w *IndexWriter) Stats() IndexWriterStats {
+    w.statsMu.Lock()
+    defer w.statsMu.Unlock()
+    stats := w.stats
+    stats.Queued = len(w.ops)
+    return stats
+}
+
+//
+// Close runs the operations queued, closes the repository and returns the
+// error closing it, or that of a failed commit not reopened. Operations
+// queued after fail with ErrClosed.
+//
+func (w *IndexWriter) Close() error {
+    w.mu.Lock()
+    if !w.closed {
+        w.closed = true
+        close(w.ops)
+    }
+    w.mu.Unlock()
+    <-w.stopped
+    return w.closeErr
 }
 
 
@@ -1569,6 +1569,574 @@ SWIGINTERN void std_vector_Sl_indri_parse_MetadataPair_Sg__set(std::vector< indr
 #include "indri/TagList.hpp"
 
 
+static void indri_go_lemur_exception( lemur::api::Exception& e ) {
+  const char* code = "LEMUR_GENERIC_ERROR";
+  switch( e.code() ) {
+  case LEMUR_MISSING_PARAMETER_ERROR: code = "LEMUR_MISSING_PARAMETER_ERROR"; break;
+  case LEMUR_BAD_PARAMETER_ERROR: code = "LEMUR_BAD_PARAMETER_ERROR"; break;
+  case LEMUR_PARSE_ERROR: code = "LEMUR_PARSE_ERROR"; break;
+  case LEMUR_KEYFILE_IO_ERROR: code = "LEMUR_KEYFILE_IO_ERROR"; break;
+  case LEMUR_IO_ERROR: code = "LEMUR_IO_ERROR"; break;
+  case LEMUR_RUNTIME_ERROR: code = "LEMUR_RUNTIME_ERROR"; break;
+  case LEMUR_NETWORK_ERROR: code = "LEMUR_NETWORK_ERROR"; break;
+  case LEMUR_INTERNAL_ERROR: code = "LEMUR_INTERNAL_ERROR"; break;
+  }
+  std::string message = std::string( "lemur exception " ) + code + ": " + e.what();
+  SWIG_exception( SWIG_RuntimeError, message.c_str() );
+}
+
+
+//
+// every repository created or opened from go gets an indri_go_cancel_status
+// as its callback. it passes status calls on to the caller's IndexStatus,
+// then to the check set by AddFileContext, and throws once cancelled so
+// that addFile stops after the current document. without a caller
+// IndexStatus it throws file errors, as indri does when there is no
+// callback at all.
+//
+class indri_go_cancel_status : public indri::api::IndexStatus {
+public:
+  indri::api::IndexStatus* next;
+  indri::api::IndexStatus* check;
+  bool cancelled;
+
+  indri_go_cancel_status( indri::api::IndexStatus* n ) : next(n), check(0), cancelled(false) {}
+
+  void status( int code, const std::string& documentPath, const std::string& error, int documentsIndexed, int documentsSeen ) {
+    if( next )
+      (*next)( code, documentPath, error, documentsIndexed, documentsSeen );
+    if( check )
+      (*check)( code, documentPath, error, documentsIndexed, documentsSeen );
+    if( cancelled )
+      LEMUR_THROW( LEMUR_RUNTIME_ERROR, "indexing of " + documentPath + " cancelled" );
+    if( !next && code == indri::api::IndexStatus::FileError )
+      LEMUR_THROW( LEMUR_IO_ERROR, error );
+  }
+};
+
+//
+// IndexEnvironment keeps its Repository private, QueryEnvironment being its
+// only friend. an explicit instantiation may name a private member, so
+// indri_go_repository_of instantiates indri_go_repository_member with the
+// member pointer and the friend function it defines hands it back.
+//
+struct indri_go_repository_tag {
+  typedef indri::collection::Repository indri::api::IndexEnvironment::*type;
+  friend type indri_go_repository_member( indri_go_repository_tag );
+};
+
+template<typename Tag, typename Tag::type member>
+struct indri_go_repository_of {
+  friend typename Tag::type indri_go_repository_member( Tag ) { return member; }
+};
+
+template struct indri_go_repository_of<indri_go_repository_tag, &indri::api::IndexEnvironment::_repository>;
+
+extern "C" {
+
+indri::api::IndexStatus* indri_go_cancel_status_new( indri::api::IndexStatus* next ) {
+  return new indri_go_cancel_status( next );
+}
+
+void indri_go_cancel_status_check( indri::api::IndexStatus* status, indri::api::IndexStatus* check ) {
+  static_cast<indri_go_cancel_status*>(status)->check = check;
+  static_cast<indri_go_cancel_status*>(status)->cancelled = false;
+}
+
+void indri_go_cancel_status_cancel( indri::api::IndexStatus* status ) {
+  static_cast<indri_go_cancel_status*>(status)->cancelled = true;
+}
+
+void indri_go_cancel_status_delete( indri::api::IndexStatus* status ) {
+  delete static_cast<indri_go_cancel_status*>(status);
+}
+
+intgo indri_go_index_environment_add_document( indri::api::IndexEnvironment* env, _gostring_ text, _gostring_ fileClass, _gostring_ metadata, intgo* lengths, intgo count ) {
+  intgo id = 0;
+  lemur::api::Exception* error = 0;
+  {
+    std::vector<std::string> strings;
+    std::vector<indri::parse::MetadataPair> pairs;
+    const char* p = metadata.p;
+
+    strings.reserve( 2*count );
+    for( intgo i=0; i<2*count; i++ ) {
+      strings.push_back( std::string( p, lengths[i] ) );
+      p += lengths[i];
+    }
+
+    // values are stored with their trailing null, as the indri parsers do
+    for( intgo i=0; i<count; i++ ) {
+      indri::parse::MetadataPair pair;
+      pair.key = strings[2*i].c_str();
+      pair.value = strings[2*i+1].c_str();
+      pair.valueLength = strings[2*i+1].length() + 1;
+      pairs.push_back( pair );
+    }
+
+    try {
+      id = env->addString( std::string( text.p, text.n ), std::string( fileClass.p, fileClass.n ), pairs );
+    } catch( lemur::api::Exception& e ) {
+      error = new lemur::api::Exception( e );
+    }
+  }
+  // raised once strings and pairs are destroyed, as the go panic skips
+  // their destructors
+  if( error ) {
+    indri_go_lemur_exception( *error );
+  }
+  return id;
+}
+
+//
+// the live documents of an open repository with the given metadata value,
+// looked up through a QueryEnvironment over the IndexEnvironment itself, as
+// the repository is not shared with any other reader. deleted documents
+// keep their metadata until the repository is compacted, so the ids in the
+// repository's deleted list are dropped. indri_go_lemur_exception unwinds
+// as a go panic, which skips C++ destructors, so it is only called once qe
+// is closed and destroyed.
+//
+std::vector<int>* indri_go_index_environment_document_ids( indri::api::IndexEnvironment* env, _gostring_ field, _gostring_ value ) {
+  std::vector<int>* ids = new std::vector<int>();
+  lemur::api::Exception* error = 0;
+  {
+    indri::api::QueryEnvironment qe;
+    try {
+      std::vector<std::string> values( 1, std::string( value.p, value.n ) );
+      qe.addIndex( *env );
+      std::vector<lemur::api::DOCID_T> found = qe.documentIDsFromMetadata( std::string( field.p, field.n ), values );
+
+      indri::index::DeletedDocumentList& deleted = ( env->*indri_go_repository_member( indri_go_repository_tag() ) ).deletedList();
+      for( size_t i = 0; i < found.size(); i++ ) {
+        if( !deleted.isDeleted( found[i] ) )
+          ids->push_back( found[i] );
+      }
+    } catch( lemur::api::Exception& e ) {
+      error = new lemur::api::Exception( e );
+    }
+    try {
+      qe.close();
+    } catch( lemur::api::Exception& e ) {
+      if( !error )
+        error = new lemur::api::Exception( e );
+    }
+  }
+  if( error ) {
+    delete ids;
+    indri_go_lemur_exception( *error );
+  }
+  return ids;
+}
+
+//
+// 1 when docid is a document of the open repository of env that is not
+// deleted, 0 for a deleted document or an id the repository never had.
+//
+intgo indri_go_index_environment_document_live( indri::api::IndexEnvironment* env, intgo docid ) {
+  try {
+    indri::collection::Repository& repository = env->*indri_go_repository_member( indri_go_repository_tag() );
+    return repository.collection()->exists( (lemur::api::DOCID_T) docid ) && !repository.deletedList().isDeleted( (lemur::api::DOCID_T) docid );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+
+
+extern "C" {
+
+typedef struct indri_go_scored_result {
+  double score;
+  intgo document;
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_scored_result;
+
+intgo indri_go_scored_results_size( std::vector<indri::api::ScoredExtentResult>* results ) {
+  return (intgo) results->size();
+}
+
+void indri_go_scored_results_copy( std::vector<indri::api::ScoredExtentResult>* results, indri_go_scored_result* out ) {
+  for( size_t i=0; i<results->size(); i++ ) {
+    const indri::api::ScoredExtentResult& r = (*results)[i];
+    out[i].score = r.score;
+    out[i].document = r.document;
+    out[i].begin = r.begin;
+    out[i].end = r.end;
+    out[i].number = r.number;
+    out[i].ordinal = r.ordinal;
+    out[i].parentOrdinal = r.parentOrdinal;
+  }
+}
+
+std::vector<indri::api::ScoredExtentResult>* indri_go_scored_results_new( const indri_go_scored_result* in, intgo n ) {
+  std::vector<indri::api::ScoredExtentResult>* results = new std::vector<indri::api::ScoredExtentResult>( n );
+  for( intgo i=0; i<n; i++ ) {
+    indri::api::ScoredExtentResult& r = (*results)[i];
+    r.score = in[i].score;
+    r.document = in[i].document;
+    r.begin = in[i].begin;
+    r.end = in[i].end;
+    r.number = in[i].number;
+    r.ordinal = in[i].ordinal;
+    r.parentOrdinal = in[i].parentOrdinal;
+  }
+  return results;
+}
+
+void indri_go_scored_results_delete( std::vector<indri::api::ScoredExtentResult>* results ) {
+  delete results;
+}
+
+}
+
+
+extern "C" {
+
+intgo indri_go_parsed_documents_size( std::vector<indri::api::ParsedDocument*>* documents ) {
+  return (intgo) documents->size();
+}
+
+indri::api::ParsedDocument* indri_go_parsed_documents_get( std::vector<indri::api::ParsedDocument*>* documents, intgo i ) {
+  return (*documents)[i];
+}
+
+void indri_go_parsed_documents_delete( std::vector<indri::api::ParsedDocument*>* documents ) {
+  for( size_t i=0; i<documents->size(); i++ )
+    delete (*documents)[i];
+  delete documents;
+}
+
+// text length counts the trailing null, which go does not want
+const char* indri_go_parsed_document_text( indri::api::ParsedDocument* document, intgo* length ) {
+  size_t n = document->textLength;
+  if( n && document->text[n-1] == 0 )
+    n--;
+  *length = (intgo) n;
+  return document->text;
+}
+
+const char* indri_go_parsed_document_content( indri::api::ParsedDocument* document, intgo* length ) {
+  *length = (intgo) document->contentLength;
+  return document->content;
+}
+
+intgo indri_go_parsed_document_terms_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->terms.size();
+}
+
+// stopped terms are null
+const char* indri_go_parsed_document_term( indri::api::ParsedDocument* document, intgo i ) {
+  return document->terms[i];
+}
+
+intgo indri_go_parsed_document_positions_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->positions.size();
+}
+
+// out holds begin, end pairs
+void indri_go_parsed_document_positions( indri::api::ParsedDocument* document, intgo* out ) {
+  for( size_t i=0; i<document->positions.size(); i++ ) {
+    out[2*i] = document->positions[i].begin;
+    out[2*i+1] = document->positions[i].end;
+  }
+}
+
+intgo indri_go_parsed_document_metadata_size( indri::api::ParsedDocument* document ) {
+  return (intgo) document->metadata.size();
+}
+
+const char* indri_go_parsed_document_metadata( indri::api::ParsedDocument* document, intgo i, const void** value, intgo* length ) {
+  indri::parse::MetadataPair& pair = document->metadata[i];
+  *value = pair.value;
+  *length = (intgo) pair.valueLength;
+  return pair.key;
+}
+
+}
+
+
+extern "C" {
+
+typedef struct indri_go_document_field {
+  intgo begin;
+  intgo end;
+  long long number;
+  intgo ordinal;
+  intgo parentOrdinal;
+} indri_go_document_field;
+
+intgo indri_go_document_vectors_size( std::vector<indri::api::DocumentVector*>* vectors ) {
+  return (intgo) vectors->size();
+}
+
+indri::api::DocumentVector* indri_go_document_vectors_get( std::vector<indri::api::DocumentVector*>* vectors, intgo i ) {
+  return (*vectors)[i];
+}
+
+void indri_go_document_vectors_delete( std::vector<indri::api::DocumentVector*>* vectors ) {
+  for( size_t i=0; i<vectors->size(); i++ )
+    delete (*vectors)[i];
+  delete vectors;
+}
+
+intgo indri_go_document_vector_stems_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->stems().size();
+}
+
+const char* indri_go_document_vector_stem( indri::api::DocumentVector* vector, intgo i, intgo* length ) {
+  const std::string& stem = vector->stems()[i];
+  *length = (intgo) stem.length();
+  return stem.data();
+}
+
+intgo indri_go_document_vector_positions_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->positions().size();
+}
+
+void indri_go_document_vector_positions( indri::api::DocumentVector* vector, intgo* out ) {
+  for( size_t i=0; i<vector->positions().size(); i++ )
+    out[i] = vector->positions()[i];
+}
+
+intgo indri_go_document_vector_fields_size( indri::api::DocumentVector* vector ) {
+  return (intgo) vector->fields().size();
+}
+
+const char* indri_go_document_vector_field( indri::api::DocumentVector* vector, intgo i, indri_go_document_field* out ) {
+  const indri::api::DocumentVector::Field& field = vector->fields()[i];
+  out->begin = field.begin;
+  out->end = field.end;
+  out->number = field.number;
+  out->ordinal = field.ordinal;
+  out->parentOrdinal = field.parentOrdinal;
+  return field.name.c_str();
+}
+
+}
+
+
+extern "C" {
+
+typedef struct indri_go_query_result {
+  _gostring_ snippet;
+  _gostring_ documentName;
+  intgo docid;
+  double score;
+  intgo begin;
+  intgo end;
+} indri_go_query_result;
+
+indri::api::QueryRequest* indri_go_query_request_new( _gostring_ query, intgo resultsRequested, intgo startNum, intgo options ) {
+  indri::api::QueryRequest* request = new indri::api::QueryRequest();
+  request->query.assign( query.p, query.n );
+  request->resultsRequested = resultsRequested;
+  request->startNum = startNum;
+  request->options = (indri::api::QueryRequest::Options) options;
+  return request;
+}
+
+void indri_go_query_request_add_formulator( indri::api::QueryRequest* request, _gostring_ formulator ) {
+  request->formulators.push_back( std::string( formulator.p, formulator.n ) );
+}
+
+void indri_go_query_request_add_metadata( indri::api::QueryRequest* request, _gostring_ field ) {
+  request->metadata.push_back( std::string( field.p, field.n ) );
+}
+
+void indri_go_query_request_delete( indri::api::QueryRequest* request ) {
+  delete request;
+}
+
+indri::api::QueryResults* indri_go_query_request_run( indri::api::QueryEnvironment* env, indri::api::QueryRequest* request ) {
+  indri::api::QueryResults* results = new indri::api::QueryResults();
+  try {
+    *results = env->runQuery( *request );
+  } catch( lemur::api::Exception& e ) {
+    delete results;
+    indri_go_lemur_exception( e );
+    return 0;
+  }
+  return results;
+}
+
+void indri_go_query_results_times( indri::api::QueryResults* results, double* parseTime, double* executeTime, double* documentsTime, intgo* estimatedMatches ) {
+  *parseTime = results->parseTime;
+  *executeTime = results->executeTime;
+  *documentsTime = results->documentsTime;
+  *estimatedMatches = results->estimatedMatches;
+}
+
+intgo indri_go_query_results_size( indri::api::QueryResults* results ) {
+  return (intgo) results->results.size();
+}
+
+// the strings in out point into results, copy them before delete
+void indri_go_query_results_get( indri::api::QueryResults* results, intgo i, indri_go_query_result* out ) {
+  indri::api::QueryResult& r = results->results[i];
+  out->snippet.p = (char*) r.snippet.data();
+  out->snippet.n = r.snippet.length();
+  out->documentName.p = (char*) r.documentName.data();
+  out->documentName.n = r.documentName.length();
+  out->docid = r.docid;
+  out->score = r.score;
+  out->begin = r.begin;
+  out->end = r.end;
+}
+
+intgo indri_go_query_results_metadata_size( indri::api::QueryResults* results, intgo i ) {
+  return (intgo) results->results[i].metadata.size();
+}
+
+void indri_go_query_results_metadata( indri::api::QueryResults* results, intgo i, intgo j, _gostring_* key, _gostring_* value ) {
+  indri::api::MetadataPair& pair = results->results[i].metadata[j];
+  key->p = (char*) pair.key.data();
+  key->n = pair.key.length();
+  value->p = (char*) pair.value.data();
+  value->n = pair.value.length();
+}
+
+void indri_go_query_results_delete( indri::api::QueryResults* results ) {
+  delete results;
+}
+
+}
+
+
+extern "C" {
+
+intgo indri_go_annotation_node_children_size( indri::api::QueryAnnotationNode* node ) {
+  return (intgo) node->children.size();
+}
+
+void indri_go_annotation_node_children( indri::api::QueryAnnotationNode* node, indri::api::QueryAnnotationNode** out ) {
+  for( size_t i=0; i<node->children.size(); i++ )
+    out[i] = node->children[i];
+}
+
+intgo indri_go_annotations_size( std::map< std::string, std::vector<indri::api::ScoredExtentResult> >* annotations ) {
+  return (intgo) annotations->size();
+}
+
+// the keys point into annotations, copy them before delete
+void indri_go_annotations_entries( std::map< std::string, std::vector<indri::api::ScoredExtentResult> >* annotations, _gostring_* keys, std::vector<indri::api::ScoredExtentResult>** values ) {
+  std::map< std::string, std::vector<indri::api::ScoredExtentResult> >::iterator iter;
+  size_t i = 0;
+  for( iter = annotations->begin(); iter != annotations->end(); iter++, i++ ) {
+    keys[i].p = (char*) iter->first.data();
+    keys[i].n = iter->first.length();
+    values[i] = &iter->second;
+  }
+}
+
+}
+
+
+//
+// the repositories QueryEnvironment opens for its indexes, in the order
+// added, are private too, see indri_go_repository_of.
+//
+struct indri_go_repositories_tag {
+  typedef std::vector<indri::collection::Repository*> indri::api::QueryEnvironment::*type;
+  friend type indri_go_repository_member( indri_go_repositories_tag );
+};
+
+template struct indri_go_repository_of<indri_go_repositories_tag, &indri::api::QueryEnvironment::_repositories>;
+
+extern "C" {
+
+//
+// 1 when docid is a document of the index-th repository of env that is not
+// deleted, 0 for a deleted document or an id the repository never had.
+//
+intgo indri_go_query_environment_document_live( indri::api::QueryEnvironment* env, intgo index, intgo docid ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    if( index < 0 || index >= (intgo) repositories.size() )
+      return 0;
+    indri::collection::Repository* repository = repositories[index];
+    return repository->collection()->exists( (lemur::api::DOCID_T) docid ) && !repository->deletedList().isDeleted( (lemur::api::DOCID_T) docid );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+
+
+extern "C" {
+
+//
+// the power of two histogram of the lengths of the documents of the
+// index-th repository of env that are not deleted, in the one call rather
+// than one a document. bucket 0 counts the empty documents, bucket i the
+// lengths 2^(i-1) to 2^i-1.
+//
+std::vector<int>* indri_go_document_length_histogram( indri::api::QueryEnvironment* env, intgo index ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    std::vector<int> buckets;
+    if( index >= 0 && index < (intgo) repositories.size() ) {
+      indri::collection::Repository* repository = repositories[index];
+      // ids run from 1 to the documents of all its indexes, deleted ones
+      // included, though the collection may not have them all
+      INT64 maximum = 0;
+      indri::collection::Repository::index_state indexes = repository->indexes();
+      for( size_t i = 0; i < indexes->size(); i++ ) {
+        indri::thread::ScopedLock lock( (*indexes)[i]->statisticsLock() );
+        maximum += (*indexes)[i]->documentCount();
+      }
+      for( lemur::api::DOCID_T id = 1; id <= maximum; id++ ) {
+        if( !repository->collection()->exists( id ) || repository->deletedList().isDeleted( id ) )
+          continue;
+        size_t i = 0;
+        for( int n = env->documentLength( id ); n > 0; n >>= 1 )
+          i++;
+        if( buckets.size() <= i )
+          buckets.resize( i + 1, 0 );
+        buckets[i]++;
+      }
+    }
+    return new std::vector<int>( buckets );
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+//
+// the terms in the extents of field over the local repositories of env,
+// from the statistics of their indexes.
+//
+intgo indri_go_field_term_count( indri::api::QueryEnvironment* env, _gostring_ field ) {
+  try {
+    std::vector<indri::collection::Repository*>& repositories = env->*indri_go_repository_member( indri_go_repositories_tag() );
+    std::string name( field.p, field.n );
+    INT64 total = 0;
+    for( size_t r = 0; r < repositories.size(); r++ ) {
+      indri::collection::Repository::index_state indexes = repositories[r]->indexes();
+      for( size_t i = 0; i < indexes->size(); i++ ) {
+        indri::thread::ScopedLock lock( (*indexes)[i]->statisticsLock() );
+        total += (*indexes)[i]->fieldTermCount( name );
+      }
+    }
+    return total;
+  } catch( lemur::api::Exception& e ) {
+    indri_go_lemur_exception( e );
+  }
+  return 0;
+}
+
+}
+
+
 // C++ director class methods.
 #include "indri_wrap.h"
 
@@ -1718,7 +2286,7 @@ bool _wrap_buildindex_recoverRepository_indri_go_add17ee78870902e(_gostring_ _sw
     try {
       result = (bool)_recoverRepository((std::string const &)*arg1);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -1810,7 +2378,7 @@ bool _wrap_buildindex_augmentSpec_indri_go_add17ee78870902e(indri::parse::FileCl
     try {
       result = (bool)augmentSpec(arg1,*arg2,*arg3,*arg4,*arg5);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -1837,7 +2405,7 @@ void _wrap_buildindex_mymain_indri_go_add17ee78870902e(intgo _swig_go_0, _gostri
     try {
       buildindex_mymain(arg1,arg2,arg3,arg4,arg5,arg6);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -2803,14 +3371,14 @@ void _wrap_Wrapped_Parameters_load_indri_go_add17ee78870902e(indri::api::Paramet
     try {
       (arg1)->load((std::string const &)*arg2);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_TermExtent_begin_set_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0, intgo _swig_go_1) {
+void _wrap_Wrapped_TermExtent_begin_set_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0, intgo _swig_go_1) {
   indri::parse::TermExtent *arg1 = (indri::parse::TermExtent *) 0 ;
   int arg2 ;
   
@@ -2822,7 +3390,7 @@ void _wrap_TermExtent_begin_set_indri_go_add17ee78870902e(indri::parse::TermExte
 }
 
 
-intgo _wrap_TermExtent_begin_get_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
+intgo _wrap_Wrapped_TermExtent_begin_get_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
   indri::parse::TermExtent *arg1 = (indri::parse::TermExtent *) 0 ;
   int result;
   intgo _swig_go_result;
@@ -2835,7 +3403,7 @@ intgo _wrap_TermExtent_begin_get_indri_go_add17ee78870902e(indri::parse::TermExt
 }
 
 
-void _wrap_TermExtent_end_set_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0, intgo _swig_go_1) {
+void _wrap_Wrapped_TermExtent_end_set_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0, intgo _swig_go_1) {
   indri::parse::TermExtent *arg1 = (indri::parse::TermExtent *) 0 ;
   int arg2 ;
   
@@ -2847,7 +3415,7 @@ void _wrap_TermExtent_end_set_indri_go_add17ee78870902e(indri::parse::TermExtent
 }
 
 
-intgo _wrap_TermExtent_end_get_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
+intgo _wrap_Wrapped_TermExtent_end_get_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
   indri::parse::TermExtent *arg1 = (indri::parse::TermExtent *) 0 ;
   int result;
   intgo _swig_go_result;
@@ -2860,7 +3428,7 @@ intgo _wrap_TermExtent_end_get_indri_go_add17ee78870902e(indri::parse::TermExten
 }
 
 
-void _wrap_delete_TermExtent_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
+void _wrap_delete_Wrapped_TermExtent_indri_go_add17ee78870902e(indri::parse::TermExtent *_swig_go_0) {
   indri::parse::TermExtent *arg1 = (indri::parse::TermExtent *) 0 ;
   
   arg1 = *(indri::parse::TermExtent **)&_swig_go_0; 
@@ -2870,7 +3438,7 @@ void _wrap_delete_TermExtent_indri_go_add17ee78870902e(indri::parse::TermExtent
 }
 
 
-indri::parse::TermExtent *_wrap_new_TermExtent_indri_go_add17ee78870902e() {
+indri::parse::TermExtent *_wrap_new_Wrapped_TermExtent_indri_go_add17ee78870902e() {
   indri::parse::TermExtent *result = 0 ;
   indri::parse::TermExtent *_swig_go_result;
   
@@ -3077,8 +3645,7 @@ indri::api::QueryAnnotationNode *_wrap_QueryAnnotation_getQueryTree_indri_go_add
     result = (indri::api::QueryAnnotationNode *)((indri::api::QueryAnnotation const *)arg1)->getQueryTree();
   }
   catch(lemur::api::Exception &_e) {
-    (void)_e;
-    _swig_gopanic("C++ lemur::api::Exception exception thrown");
+    indri_go_lemur_exception( _e );
     
   }
   
@@ -3098,8 +3665,7 @@ std::map< std::string,std::vector< indri::api::ScoredExtentResult > > *_wrap_Que
     result = (std::map< std::string,std::vector< indri::api::ScoredExtentResult > > *) &((indri::api::QueryAnnotation const *)arg1)->getAnnotations();
   }
   catch(lemur::api::Exception &_e) {
-    (void)_e;
-    _swig_gopanic("C++ lemur::api::Exception exception thrown");
+    indri_go_lemur_exception( _e );
     
   }
   
@@ -3119,8 +3685,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryAnnotation_getResults_
     result = (std::vector< indri::api::ScoredExtentResult > *) &((indri::api::QueryAnnotation const *)arg1)->getResults();
   }
   catch(lemur::api::Exception &_e) {
-    (void)_e;
-    _swig_gopanic("C++ lemur::api::Exception exception thrown");
+    indri_go_lemur_exception( _e );
     
   }
   
@@ -3150,7 +3715,7 @@ void _wrap_delete_QueryAnnotation_indri_go_add17ee78870902e(indri::api::QueryAnn
 }
 
 
-indri::api::QueryEnvironment *_wrap_new_QueryEnvironment_indri_go_add17ee78870902e() {
+indri::api::QueryEnvironment *_wrap_new_Wrapped_QueryEnvironment_indri_go_add17ee78870902e() {
   indri::api::QueryEnvironment *result = 0 ;
   indri::api::QueryEnvironment *_swig_go_result;
   
@@ -3161,7 +3726,7 @@ indri::api::QueryEnvironment *_wrap_new_QueryEnvironment_indri_go_add17ee7887090
 }
 
 
-void _wrap_QueryEnvironment_addServer_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_addServer_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   
@@ -3177,20 +3742,19 @@ void _wrap_QueryEnvironment_addServer_indri_go_add17ee78870902e(indri::api::Quer
         (arg1)->addServer((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_addIndex_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_addIndex_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   
@@ -3206,20 +3770,19 @@ void _wrap_QueryEnvironment_addIndex_indri_go_add17ee78870902e(indri::api::Query
         (arg1)->addIndex((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_removeServer_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_removeServer_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   
@@ -3235,20 +3798,19 @@ void _wrap_QueryEnvironment_removeServer_indri_go_add17ee78870902e(indri::api::Q
         (arg1)->removeServer((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_removeIndex_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_removeIndex_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   
@@ -3264,20 +3826,19 @@ void _wrap_QueryEnvironment_removeIndex_indri_go_add17ee78870902e(indri::api::Qu
         (arg1)->removeIndex((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_close_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_close_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   
   arg1 = *(indri::api::QueryEnvironment **)&_swig_go_0; 
@@ -3288,20 +3849,19 @@ void _wrap_QueryEnvironment_close_indri_go_add17ee78870902e(indri::api::QueryEnv
         (arg1)->close();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_setMemory_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, long long _swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_setMemory_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, long long _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   UINT64 arg2 ;
   
@@ -3314,20 +3874,19 @@ void _wrap_QueryEnvironment_setMemory_indri_go_add17ee78870902e(indri::api::Quer
         (arg1)->setMemory(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_setScoringRules_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< std::string > *_swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_setScoringRules_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< std::string > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< std::string > *arg2 = 0 ;
   
@@ -3340,20 +3899,19 @@ void _wrap_QueryEnvironment_setScoringRules_indri_go_add17ee78870902e(indri::api
         (arg1)->setScoringRules((std::vector< std::string > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-void _wrap_QueryEnvironment_setStopwords_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< std::string > *_swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_setStopwords_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< std::string > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< std::string > *arg2 = 0 ;
   
@@ -3366,20 +3924,19 @@ void _wrap_QueryEnvironment_setStopwords_indri_go_add17ee78870902e(indri::api::Q
         (arg1)->setStopwords((std::vector< std::string > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   int arg3 ;
@@ -3399,13 +3956,12 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__
         result = (arg1)->runQuery((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ScoredExtentResult > **)&_swig_go_result = new std::vector< indri::api::ScoredExtentResult >(result); 
@@ -3413,7 +3969,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< int > *_swig_go_2, intgo _swig_go_3) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_QueryEnvironment_Wrapped_runQuery__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< int > *_swig_go_2, intgo _swig_go_3) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< int > *arg3 = 0 ;
@@ -3435,13 +3991,12 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__
         result = (arg1)->runQuery((std::string const &)*arg2,(std::vector< int > const &)*arg3,arg4);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ScoredExtentResult > **)&_swig_go_result = new std::vector< indri::api::ScoredExtentResult >(result); 
@@ -3449,7 +4004,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuery__
 }
 
 
-indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
+indri::api::QueryAnnotation *_wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   int arg3 ;
@@ -3469,13 +4024,12 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_0_in
         result = (indri::api::QueryAnnotation *)(arg1)->runAnnotatedQuery((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(indri::api::QueryAnnotation **)&_swig_go_result = (indri::api::QueryAnnotation *)result; 
@@ -3483,7 +4037,7 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_0_in
 }
 
 
-indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< int > *_swig_go_2, intgo _swig_go_3) {
+indri::api::QueryAnnotation *_wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuery__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< int > *_swig_go_2, intgo _swig_go_3) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< int > *arg3 = 0 ;
@@ -3505,13 +4059,12 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_1_in
         result = (indri::api::QueryAnnotation *)(arg1)->runAnnotatedQuery((std::string const &)*arg2,(std::vector< int > const &)*arg3,arg4);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(indri::api::QueryAnnotation **)&_swig_go_result = (indri::api::QueryAnnotation *)result; 
@@ -3519,7 +4072,7 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuery__SWIG_1_in
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuerydocset_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< lemur::api::DOCID_T > *_swig_go_2, intgo _swig_go_3) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_QueryEnvironment_Wrapped_runQuerydocset_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< lemur::api::DOCID_T > *_swig_go_2, intgo _swig_go_3) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< lemur::api::DOCID_T > *arg3 = 0 ;
@@ -3539,7 +4092,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuerydo
     try {
       result = (arg1)->runQuerydocset((std::string const &)*arg2,(std::vector< lemur::api::DOCID_T > const &)*arg3,arg4);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ScoredExtentResult > **)&_swig_go_result = new std::vector< indri::api::ScoredExtentResult >(result); 
@@ -3547,7 +4100,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_runQuerydo
 }
 
 
-indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuerydocset_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< lemur::api::DOCID_T > *_swig_go_2, intgo _swig_go_3) {
+indri::api::QueryAnnotation *_wrap_Wrapped_QueryEnvironment_Wrapped_runAnnotatedQuerydocset_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< lemur::api::DOCID_T > *_swig_go_2, intgo _swig_go_3) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< lemur::api::DOCID_T > *arg3 = 0 ;
@@ -3567,7 +4120,7 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuerydocset_indr
     try {
       result = (indri::api::QueryAnnotation *)(arg1)->runAnnotatedQuerydocset((std::string const &)*arg2,(std::vector< lemur::api::DOCID_T > const &)*arg3,arg4);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(indri::api::QueryAnnotation **)&_swig_go_result = (indri::api::QueryAnnotation *)result; 
@@ -3575,7 +4128,7 @@ indri::api::QueryAnnotation *_wrap_QueryEnvironment_runAnnotatedQuerydocset_indr
 }
 
 
-std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1) {
+std::vector< indri::api::ParsedDocument * > *_wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< int > *arg2 = 0 ;
   SwigValueWrapper< std::vector< indri::api::ParsedDocument * > > result;
@@ -3590,13 +4143,12 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__S
         result = (arg1)->documents((std::vector< int > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ParsedDocument * > **)&_swig_go_result = new std::vector< indri::api::ParsedDocument * >(result); 
@@ -3604,7 +4156,7 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__S
 }
 
 
-std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< indri::api::ScoredExtentResult > *_swig_go_1) {
+std::vector< indri::api::ParsedDocument * > *_wrap_Wrapped_QueryEnvironment_Wrapped_documents__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< indri::api::ScoredExtentResult > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< indri::api::ScoredExtentResult > *arg2 = 0 ;
   SwigValueWrapper< std::vector< indri::api::ParsedDocument * > > result;
@@ -3619,13 +4171,12 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__S
         result = (arg1)->documents((std::vector< indri::api::ScoredExtentResult > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ParsedDocument * > **)&_swig_go_result = new std::vector< indri::api::ParsedDocument * >(result); 
@@ -3633,7 +4184,7 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documents__S
 }
 
 
-std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1, _gostring_ _swig_go_2) {
+std::vector< std::string > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< int > *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -3653,13 +4204,12 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_0_indr
         result = (arg1)->documentMetadata((std::vector< int > const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< std::string > **)&_swig_go_result = new std::vector< std::string >(result); 
@@ -3667,7 +4217,7 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_0_indr
 }
 
 
-std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< indri::api::ScoredExtentResult > *_swig_go_1, _gostring_ _swig_go_2) {
+std::vector< std::string > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadata__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< indri::api::ScoredExtentResult > *_swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< indri::api::ScoredExtentResult > *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -3687,13 +4237,12 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_1_indr
         result = (arg1)->documentMetadata((std::vector< indri::api::ScoredExtentResult > const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< std::string > **)&_swig_go_result = new std::vector< std::string >(result); 
@@ -3701,7 +4250,7 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadata__SWIG_1_indr
 }
 
 
-std::vector< int > *_wrap_QueryEnvironment_documentIDsFromMetadata_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< std::string > *_swig_go_2) {
+std::vector< int > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentIDsFromMetadata_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< std::string > *_swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< std::string > *arg3 = 0 ;
@@ -3721,13 +4270,12 @@ std::vector< int > *_wrap_QueryEnvironment_documentIDsFromMetadata_indri_go_add1
         result = (arg1)->documentIDsFromMetadata((std::string const &)*arg2,(std::vector< std::string > const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< int > **)&_swig_go_result = new std::vector< int >(result); 
@@ -3735,7 +4283,7 @@ std::vector< int > *_wrap_QueryEnvironment_documentIDsFromMetadata_indri_go_add1
 }
 
 
-std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsFromMetadata_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< std::string > *_swig_go_2) {
+std::vector< indri::api::ParsedDocument * > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentsFromMetadata_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, std::vector< std::string > *_swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::vector< std::string > *arg3 = 0 ;
@@ -3755,13 +4303,12 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsFro
         result = (arg1)->documentsFromMetadata((std::string const &)*arg2,(std::vector< std::string > const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ParsedDocument * > **)&_swig_go_result = new std::vector< indri::api::ParsedDocument * >(result); 
@@ -3769,7 +4316,7 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsFro
 }
 
 
-long long _wrap_QueryEnvironment_termCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   INT64 result;
   long long _swig_go_result;
@@ -3782,13 +4329,12 @@ long long _wrap_QueryEnvironment_termCount__SWIG_0_indri_go_add17ee78870902e(ind
         result = (INT64)(arg1)->termCount();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -3796,7 +4342,7 @@ long long _wrap_QueryEnvironment_termCount__SWIG_0_indri_go_add17ee78870902e(ind
 }
 
 
-long long _wrap_QueryEnvironment_termCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_termCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -3814,13 +4360,12 @@ long long _wrap_QueryEnvironment_termCount__SWIG_1_indri_go_add17ee78870902e(ind
         result = (INT64)(arg1)->termCount((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -3828,7 +4373,7 @@ long long _wrap_QueryEnvironment_termCount__SWIG_1_indri_go_add17ee78870902e(ind
 }
 
 
-long long _wrap_QueryEnvironment_termFieldCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_termFieldCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -3851,13 +4396,12 @@ long long _wrap_QueryEnvironment_termFieldCount_indri_go_add17ee78870902e(indri:
         result = (INT64)(arg1)->termFieldCount((std::string const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -3865,7 +4409,7 @@ long long _wrap_QueryEnvironment_termFieldCount_indri_go_add17ee78870902e(indri:
 }
 
 
-std::vector< std::string > *_wrap_QueryEnvironment_fieldList_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+std::vector< std::string > *_wrap_Wrapped_QueryEnvironment_Wrapped_fieldList_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< std::string > result;
   std::vector< std::string > *_swig_go_result;
@@ -3878,13 +4422,12 @@ std::vector< std::string > *_wrap_QueryEnvironment_fieldList_indri_go_add17ee788
         result = (arg1)->fieldList();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< std::string > **)&_swig_go_result = new std::vector< std::string >(result); 
@@ -3892,7 +4435,7 @@ std::vector< std::string > *_wrap_QueryEnvironment_fieldList_indri_go_add17ee788
 }
 
 
-long long _wrap_QueryEnvironment_documentCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   INT64 result;
   long long _swig_go_result;
@@ -3905,13 +4448,12 @@ long long _wrap_QueryEnvironment_documentCount__SWIG_0_indri_go_add17ee78870902e
         result = (INT64)(arg1)->documentCount();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -3919,7 +4461,7 @@ long long _wrap_QueryEnvironment_documentCount__SWIG_0_indri_go_add17ee78870902e
 }
 
 
-long long _wrap_QueryEnvironment_documentCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_documentCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -3937,13 +4479,12 @@ long long _wrap_QueryEnvironment_documentCount__SWIG_1_indri_go_add17ee78870902e
         result = (INT64)(arg1)->documentCount((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -3951,7 +4492,7 @@ long long _wrap_QueryEnvironment_documentCount__SWIG_1_indri_go_add17ee78870902e
 }
 
 
-std::vector< indri::api::DocumentVector * > *_wrap_QueryEnvironment_documentVectors_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1) {
+std::vector< indri::api::DocumentVector * > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentVectors_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< int > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< int > *arg2 = 0 ;
   SwigValueWrapper< std::vector< indri::api::DocumentVector * > > result;
@@ -3966,13 +4507,12 @@ std::vector< indri::api::DocumentVector * > *_wrap_QueryEnvironment_documentVect
         result = (arg1)->documentVectors((std::vector< int > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::DocumentVector * > **)&_swig_go_result = new std::vector< indri::api::DocumentVector * >(result); 
@@ -3980,7 +4520,7 @@ std::vector< indri::api::DocumentVector * > *_wrap_QueryEnvironment_documentVect
 }
 
 
-double _wrap_QueryEnvironment_expressionCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
+double _wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -4003,13 +4543,12 @@ double _wrap_QueryEnvironment_expressionCount__SWIG_0_indri_go_add17ee78870902e(
         result = (double)(arg1)->expressionCount((std::string const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -4017,7 +4556,7 @@ double _wrap_QueryEnvironment_expressionCount__SWIG_0_indri_go_add17ee78870902e(
 }
 
 
-double _wrap_QueryEnvironment_expressionCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+double _wrap_Wrapped_QueryEnvironment_Wrapped_expressionCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   double result;
@@ -4035,13 +4574,12 @@ double _wrap_QueryEnvironment_expressionCount__SWIG_1_indri_go_add17ee78870902e(
         result = (double)(arg1)->expressionCount((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -4049,7 +4587,7 @@ double _wrap_QueryEnvironment_expressionCount__SWIG_1_indri_go_add17ee78870902e(
 }
 
 
-double _wrap_QueryEnvironment_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
+double _wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -4072,13 +4610,12 @@ double _wrap_QueryEnvironment_documentExpressionCount__SWIG_0_indri_go_add17ee78
         result = (double)(arg1)->documentExpressionCount((std::string const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -4086,7 +4623,7 @@ double _wrap_QueryEnvironment_documentExpressionCount__SWIG_0_indri_go_add17ee78
 }
 
 
-double _wrap_QueryEnvironment_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+double _wrap_Wrapped_QueryEnvironment_Wrapped_documentExpressionCount__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   double result;
@@ -4104,13 +4641,12 @@ double _wrap_QueryEnvironment_documentExpressionCount__SWIG_1_indri_go_add17ee78
         result = (double)(arg1)->documentExpressionCount((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -4118,7 +4654,7 @@ double _wrap_QueryEnvironment_documentExpressionCount__SWIG_1_indri_go_add17ee78
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expressionList__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_0_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -4141,13 +4677,12 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expression
         result = (arg1)->expressionList((std::string const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ScoredExtentResult > **)&_swig_go_result = new std::vector< indri::api::ScoredExtentResult >(result); 
@@ -4155,7 +4690,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expression
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expressionList__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_QueryEnvironment_Wrapped_expressionList__SWIG_1_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   SwigValueWrapper< std::vector< indri::api::ScoredExtentResult > > result;
@@ -4173,13 +4708,12 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expression
         result = (arg1)->expressionList((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ScoredExtentResult > **)&_swig_go_result = new std::vector< indri::api::ScoredExtentResult >(result); 
@@ -4187,7 +4721,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryEnvironment_expression
 }
 
 
-intgo _wrap_QueryEnvironment_documentLength_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, intgo _swig_go_1) {
+intgo _wrap_Wrapped_QueryEnvironment_Wrapped_documentLength_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, intgo _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   int arg2 ;
   int result;
@@ -4202,13 +4736,12 @@ intgo _wrap_QueryEnvironment_documentLength_indri_go_add17ee78870902e(indri::api
         result = (int)(arg1)->documentLength(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -4216,19 +4749,25 @@ intgo _wrap_QueryEnvironment_documentLength_indri_go_add17ee78870902e(indri::api
 }
 
 
-void _wrap_QueryEnvironment_setFormulationParameters_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
+void _wrap_Wrapped_QueryEnvironment_Wrapped_setFormulationParameters_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   indri::api::Parameters *arg2 = 0 ;
   
   arg1 = *(indri::api::QueryEnvironment **)&_swig_go_0; 
   arg2 = *(indri::api::Parameters **)&_swig_go_1; 
   
-  (arg1)->setFormulationParameters(*arg2);
+  {
+    try {
+      (arg1)->setFormulationParameters(*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   
 }
 
 
-_gostring_ _wrap_QueryEnvironment_reformulateQuery_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+_gostring_ _wrap_Wrapped_QueryEnvironment_Wrapped_reformulateQuery_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string result;
@@ -4240,13 +4779,19 @@ _gostring_ _wrap_QueryEnvironment_reformulateQuery_indri_go_add17ee78870902e(ind
   arg2 = &arg2_str;
   
   
-  result = (arg1)->reformulateQuery((std::string const &)*arg2);
+  {
+    try {
+      result = (arg1)->reformulateQuery((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = Swig_AllocateString((&result)->data(), (&result)->length()); 
   return _swig_go_result;
 }
 
 
-_gostring_ _wrap_QueryEnvironment_stemTerm_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+_gostring_ _wrap_Wrapped_QueryEnvironment_Wrapped_stemTerm_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string result;
@@ -4258,26 +4803,38 @@ _gostring_ _wrap_QueryEnvironment_stemTerm_indri_go_add17ee78870902e(indri::api:
   arg2 = &arg2_str;
   
   
-  result = (arg1)->stemTerm((std::string const &)*arg2);
+  {
+    try {
+      result = (arg1)->stemTerm((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = Swig_AllocateString((&result)->data(), (&result)->length()); 
   return _swig_go_result;
 }
 
 
-long long _wrap_QueryEnvironment_termCountUnique_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_termCountUnique_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   INT64 result;
   long long _swig_go_result;
   
   arg1 = *(indri::api::QueryEnvironment **)&_swig_go_0; 
   
-  result = (INT64)(arg1)->termCountUnique();
+  {
+    try {
+      result = (INT64)(arg1)->termCountUnique();
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-long long _wrap_QueryEnvironment_stemCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_stemCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -4289,13 +4846,19 @@ long long _wrap_QueryEnvironment_stemCount_indri_go_add17ee78870902e(indri::api:
   arg2 = &arg2_str;
   
   
-  result = (INT64)(arg1)->stemCount((std::string const &)*arg2);
+  {
+    try {
+      result = (INT64)(arg1)->stemCount((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-long long _wrap_QueryEnvironment_stemFieldCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_stemFieldCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -4312,13 +4875,19 @@ long long _wrap_QueryEnvironment_stemFieldCount_indri_go_add17ee78870902e(indri:
   arg3 = &arg3_str;
   
   
-  result = (INT64)(arg1)->stemFieldCount((std::string const &)*arg2,(std::string const &)*arg3);
+  {
+    try {
+      result = (INT64)(arg1)->stemFieldCount((std::string const &)*arg2,(std::string const &)*arg3);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-long long _wrap_QueryEnvironment_documentStemCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_documentStemCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -4330,13 +4899,19 @@ long long _wrap_QueryEnvironment_documentStemCount_indri_go_add17ee78870902e(ind
   arg2 = &arg2_str;
   
   
-  result = (INT64)(arg1)->documentStemCount((std::string const &)*arg2);
+  {
+    try {
+      result = (INT64)(arg1)->documentStemCount((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsdocids_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< lemur::api::DOCID_T > *_swig_go_1) {
+std::vector< indri::api::ParsedDocument * > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentsdocids_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< lemur::api::DOCID_T > *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< lemur::api::DOCID_T > *arg2 = 0 ;
   SwigValueWrapper< std::vector< indri::api::ParsedDocument * > > result;
@@ -4349,7 +4924,7 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsdoc
     try {
       result = (arg1)->documentsdocids((std::vector< lemur::api::DOCID_T > const &)*arg2);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< indri::api::ParsedDocument * > **)&_swig_go_result = new std::vector< indri::api::ParsedDocument * >(result); 
@@ -4357,7 +4932,7 @@ std::vector< indri::api::ParsedDocument * > *_wrap_QueryEnvironment_documentsdoc
 }
 
 
-std::vector< std::string > *_wrap_QueryEnvironment_documentMetadatadocids_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< lemur::api::DOCID_T > *_swig_go_1, _gostring_ _swig_go_2) {
+std::vector< std::string > *_wrap_Wrapped_QueryEnvironment_Wrapped_documentMetadatadocids_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, std::vector< lemur::api::DOCID_T > *_swig_go_1, _gostring_ _swig_go_2) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::vector< lemur::api::DOCID_T > *arg2 = 0 ;
   std::string *arg3 = 0 ;
@@ -4375,7 +4950,7 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadatadocids_indri_
     try {
       result = (arg1)->documentMetadatadocids((std::vector< lemur::api::DOCID_T > const &)*arg2,(std::string const &)*arg3);
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(std::vector< std::string > **)&_swig_go_result = new std::vector< std::string >(result); 
@@ -4383,7 +4958,7 @@ std::vector< std::string > *_wrap_QueryEnvironment_documentMetadatadocids_indri_
 }
 
 
-long long _wrap_QueryEnvironment_onetermCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_onetermCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -4395,13 +4970,19 @@ long long _wrap_QueryEnvironment_onetermCount_indri_go_add17ee78870902e(indri::a
   arg2 = &arg2_str;
   
   
-  result = (INT64)(arg1)->onetermCount((std::string const &)*arg2);
+  {
+    try {
+      result = (INT64)(arg1)->onetermCount((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-long long _wrap_QueryEnvironment_onedocumentCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
+long long _wrap_Wrapped_QueryEnvironment_Wrapped_onedocumentCount_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, _gostring_ _swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   std::string *arg2 = 0 ;
   INT64 result;
@@ -4413,13 +4994,19 @@ long long _wrap_QueryEnvironment_onedocumentCount_indri_go_add17ee78870902e(indr
   arg2 = &arg2_str;
   
   
-  result = (INT64)(arg1)->onedocumentCount((std::string const &)*arg2);
+  {
+    try {
+      result = (INT64)(arg1)->onedocumentCount((std::string const &)*arg2);
+    } catch( lemur::api::Exception& e ) {
+      indri_go_lemur_exception( e );
+    }
+  }
   _swig_go_result = result; 
   return _swig_go_result;
 }
 
 
-void _wrap_delete_QueryEnvironment_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
+void _wrap_delete_Wrapped_QueryEnvironment_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   
   arg1 = *(indri::api::QueryEnvironment **)&_swig_go_0; 
@@ -4439,7 +5026,7 @@ void _wrap_delete_QueryExpander_indri_go_add17ee78870902e(indri::query::QueryExp
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::QueryExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::QueryExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
   indri::query::QueryExpander *arg1 = (indri::query::QueryExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -4458,7 +5045,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_QueryExpander_runExpandedQu
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_QueryExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::QueryExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_QueryExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::QueryExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
   indri::query::QueryExpander *arg1 = (indri::query::QueryExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -4492,7 +5079,7 @@ _gostring_ _wrap_QueryExpander_expand_indri_go_add17ee78870902e(indri::query::Qu
 }
 
 
-indri::query::RMExpander *_wrap_new_RMExpander_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
+indri::query::RMExpander *_wrap_new_Wrapped_RMExpander_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   indri::api::Parameters *arg2 = 0 ;
   indri::query::RMExpander *result = 0 ;
@@ -4507,7 +5094,7 @@ indri::query::RMExpander *_wrap_new_RMExpander_indri_go_add17ee78870902e(indri::
 }
 
 
-_gostring_ _wrap_RMExpander_expand_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, std::vector< indri::api::ScoredExtentResult > *_swig_go_2) {
+_gostring_ _wrap_Wrapped_RMExpander_expand_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, std::vector< indri::api::ScoredExtentResult > *_swig_go_2) {
   indri::query::RMExpander *arg1 = (indri::query::RMExpander *) 0 ;
   std::string arg2 ;
   std::vector< indri::api::ScoredExtentResult > *arg3 = 0 ;
@@ -4524,7 +5111,7 @@ _gostring_ _wrap_RMExpander_expand_indri_go_add17ee78870902e(indri::query::RMExp
 }
 
 
-void _wrap_delete_RMExpander_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0) {
+void _wrap_delete_Wrapped_RMExpander_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0) {
   indri::query::RMExpander *arg1 = (indri::query::RMExpander *) 0 ;
   
   arg1 = *(indri::query::RMExpander **)&_swig_go_0; 
@@ -4534,7 +5121,7 @@ void _wrap_delete_RMExpander_indri_go_add17ee78870902e(indri::query::RMExpander
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_RMExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
   indri::query::RMExpander *arg1 = (indri::query::RMExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -4554,7 +5141,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_RMExpander_runExpandedQuery
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_RMExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_RMExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::RMExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
   indri::query::RMExpander *arg1 = (indri::query::RMExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -4572,7 +5159,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_RMExpander_runExpandedQuery
 }
 
 
-indri::query::PonteExpander *_wrap_new_PonteExpander_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
+indri::query::PonteExpander *_wrap_new_Wrapped_PonteExpander_indri_go_add17ee78870902e(indri::api::QueryEnvironment *_swig_go_0, indri::api::Parameters *_swig_go_1) {
   indri::api::QueryEnvironment *arg1 = (indri::api::QueryEnvironment *) 0 ;
   indri::api::Parameters *arg2 = 0 ;
   indri::query::PonteExpander *result = 0 ;
@@ -4587,7 +5174,7 @@ indri::query::PonteExpander *_wrap_new_PonteExpander_indri_go_add17ee78870902e(i
 }
 
 
-_gostring_ _wrap_PonteExpander_expand_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, std::vector< indri::api::ScoredExtentResult > *_swig_go_2) {
+_gostring_ _wrap_Wrapped_PonteExpander_expand_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, std::vector< indri::api::ScoredExtentResult > *_swig_go_2) {
   indri::query::PonteExpander *arg1 = (indri::query::PonteExpander *) 0 ;
   std::string arg2 ;
   std::vector< indri::api::ScoredExtentResult > *arg3 = 0 ;
@@ -4604,7 +5191,7 @@ _gostring_ _wrap_PonteExpander_expand_indri_go_add17ee78870902e(indri::query::Po
 }
 
 
-void _wrap_delete_PonteExpander_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0) {
+void _wrap_delete_Wrapped_PonteExpander_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0) {
   indri::query::PonteExpander *arg1 = (indri::query::PonteExpander *) 0 ;
   
   arg1 = *(indri::query::PonteExpander **)&_swig_go_0; 
@@ -4614,7 +5201,7 @@ void _wrap_delete_PonteExpander_indri_go_add17ee78870902e(indri::query::PonteExp
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_PonteExpander_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_0_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2, bool _swig_go_3) {
   indri::query::PonteExpander *arg1 = (indri::query::PonteExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -4634,7 +5221,7 @@ std::vector< indri::api::ScoredExtentResult > *_wrap_PonteExpander_runExpandedQu
 }
 
 
-std::vector< indri::api::ScoredExtentResult > *_wrap_PonteExpander_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
+std::vector< indri::api::ScoredExtentResult > *_wrap_Wrapped_PonteExpander_Wrapped_runExpandedQuery__SWIG_1_indri_go_add17ee78870902e(indri::query::PonteExpander *_swig_go_0, _gostring_ _swig_go_1, intgo _swig_go_2) {
   indri::query::PonteExpander *arg1 = (indri::query::PonteExpander *) 0 ;
   std::string arg2 ;
   int arg3 ;
@@ -5078,13 +5665,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setDocumentRoot_indri_go_add17ee7887
         (arg1)->setDocumentRoot((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5107,13 +5693,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setAnchorTextPath_indri_go_add17ee78
         (arg1)->setAnchorTextPath((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5136,13 +5721,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setOffsetMetadataPath_indri_go_add17
         (arg1)->setOffsetMetadataPath((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5165,13 +5749,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setOffsetAnnotationsPath_indri_go_ad
         (arg1)->setOffsetAnnotationsPath((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5234,13 +5817,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_addFileClass__SWIG_0_indri_go_add17e
         (arg1)->addFileClass((std::string const &)*arg2,(std::string const &)*arg3,(std::string const &)*arg4,(std::string const &)*arg5,(std::string const &)*arg6,(std::string const &)*arg7,(std::string const &)*arg8,(std::vector< std::string > const &)*arg9,(std::vector< std::string > const &)*arg10,(std::vector< std::string > const &)*arg11,(std::vector< std::string > const &)*arg12,(std::map< indri::parse::ConflationPattern *,std::string > const &)*arg13);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5265,13 +5847,12 @@ indri::parse::FileClassEnvironmentFactory::Specification *_wrap_Wrapped_IndexEnv
         result = (indri::parse::FileClassEnvironmentFactory::Specification *)(arg1)->getFileClassSpec((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   *(indri::parse::FileClassEnvironmentFactory::Specification **)&_swig_go_result = (indri::parse::FileClassEnvironmentFactory::Specification *)result; 
@@ -5292,13 +5873,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_addFileClass__SWIG_1_indri_go_add17e
         (arg1)->addFileClass((indri::parse::FileClassEnvironmentFactory::Specification const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5318,13 +5898,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_deleteDocument_indri_go_add17ee78870
         (arg1)->deleteDocument(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5344,13 +5923,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setIndexedFields_indri_go_add17ee788
         (arg1)->setIndexedFields((std::vector< std::string > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5380,13 +5958,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setNumericField__SWIG_0_indri_go_add
         (arg1)->setNumericField((std::string const &)*arg2,arg3,(std::string const &)*arg4);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5411,13 +5988,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setNumericField__SWIG_1_indri_go_add
         (arg1)->setNumericField((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5442,13 +6018,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setOrdinalField_indri_go_add17ee7887
         (arg1)->setOrdinalField((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5473,13 +6048,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setParentalField_indri_go_add17ee788
         (arg1)->setParentalField((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5501,13 +6075,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setMetadataIndexedFields_indri_go_ad
         (arg1)->setMetadataIndexedFields((std::vector< std::string > const &)*arg2,(std::vector< std::string > const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5527,13 +6100,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setStopwords_indri_go_add17ee7887090
         (arg1)->setStopwords((std::vector< std::string > const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5556,13 +6128,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setStemmer_indri_go_add17ee78870902e
         (arg1)->setStemmer((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5582,13 +6153,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setMemory_indri_go_add17ee78870902e(
         (arg1)->setMemory(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5608,13 +6178,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setNormalization_indri_go_add17ee788
         (arg1)->setNormalization(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5634,13 +6203,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_setStoreDocs_indri_go_add17ee7887090
         (arg1)->setStoreDocs(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5665,13 +6233,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_create__SWIG_0_indri_go_add17ee78870
         (arg1)->create((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5694,13 +6261,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_create__SWIG_1_indri_go_add17ee78870
         (arg1)->create((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5725,13 +6291,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_open__SWIG_0_indri_go_add17ee7887090
         (arg1)->open((std::string const &)*arg2,arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5754,13 +6319,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_open__SWIG_1_indri_go_add17ee7887090
         (arg1)->open((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5778,13 +6342,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_close_indri_go_add17ee78870902e(indr
         (arg1)->close();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5807,13 +6370,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_addFile__SWIG_0_indri_go_add17ee7887
         (arg1)->addFile((std::string const &)*arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5841,13 +6403,12 @@ void _wrap_Wrapped_IndexEnvironment_Wrapped_addFile__SWIG_1_indri_go_add17ee7887
         (arg1)->addFile((std::string const &)*arg2,(std::string const &)*arg3);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   
@@ -5879,13 +6440,12 @@ intgo _wrap_Wrapped_IndexEnvironment_Wrapped_addString_indri_go_add17ee78870902e
         result = (int)(arg1)->addString((std::string const &)*arg2,(std::string const &)*arg3,(std::vector< indri::parse::MetadataPair > const &)*arg4);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -5908,13 +6468,12 @@ intgo _wrap_Wrapped_IndexEnvironment_Wrapped_addParsedDocument_indri_go_add17ee7
         result = (int)(arg1)->addParsedDocument(arg2);
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -5935,13 +6494,12 @@ intgo _wrap_Wrapped_IndexEnvironment_Wrapped_documentsIndexed_indri_go_add17ee78
         result = (int)(arg1)->documentsIndexed();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
@@ -5962,13 +6520,12 @@ intgo _wrap_Wrapped_IndexEnvironment_Wrapped_documentsSeen_indri_go_add17ee78870
         result = (int)(arg1)->documentsSeen();
       }
       catch(lemur::api::Exception &_e) {
-        (void)_e;
-        _swig_gopanic("C++ lemur::api::Exception exception thrown");
+        indri_go_lemur_exception( _e );
         
       }
       
     } catch( lemur::api::Exception& e ) {
-      SWIG_exception( SWIG_RuntimeError, e.what().c_str() );
+      indri_go_lemur_exception( e );
     }
   }
   _swig_go_result = result; 
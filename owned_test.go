@@ -0,0 +1,250 @@
+package indri_go
+
+import (
+    "fmt"
+    "io"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "runtime"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+/**
+ * Test owned wrappers close once and report ErrClosed when used after.
+**/
+func TestOwnedClose(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testOwnedClose()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test an owned query environment searches a repository.
+**/
+func TestOwnedQueryEnvironment(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testOwnedQueryEnvironment()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test concurrent calls to Do run one at a time.
+**/
+func TestOwnedDoSerialized(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testOwnedDoSerialized()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test an owned index environment closes the monitor it holds after the repository.
+**/
+func TestOwnedIndexEnvironmentMonitor(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testOwnedIndexEnvironmentMonitor()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test unreferenced owned wrappers are freed by their finalizers.
+**/
+func TestOwnedFinalizer(t *testing.T) {
+    forcepanic, forceerror = false, false
+    for i := 0; i < 100; i++ {
+        NewOwnedStringVector([]string{"a", "b"})
+        NewOwnedQueryEnvironment()
+        NewOwnedIndexEnvironment(NewOwnedStatusMonitor())
+    }
+    runtime.GC()
+    runtime.GC()
+}
+
+//
+// start of test logic implimentations
+//
+
+func testOwnedClose() (err error) {
+
+    defer catch(&err)
+
+    strs := NewOwnedStringVector([]string{"a", "b", "c"})
+    ints := NewOwnedIntVector([]int{1, 2})
+
+    closers := []io.Closer{
+        NewOwnedParameters(),
+        strs,
+        ints,
+        NewOwnedMetadataPairVector(),
+        NewOwnedQueryEnvironment(),
+        NewOwnedIndexEnvironment(nil),
+        NewOwnedStatusMonitor(),
+    }
+
+    var size int64
+    if err = strs.Do(func(v StringVector) error { size = v.Size(); return nil }); err != nil {
+        return
+    }
+    if size != 3 {
+        err = fmt.Errorf("owned string vector has size %v, expected 3", size)
+        return
+    }
+
+    // errors from fn are returned as is
+    if e := ints.Do(func(v IntVector) error { return io.EOF }); e != io.EOF {
+        err = fmt.Errorf("Do returned %v, expected %v", e, io.EOF)
+        return
+    }
+
+    for i, c := range closers {
+        if err = c.Close(); err != nil {
+            err = fmt.Errorf("closer %v: first Close returned %v", i, err)
+            return
+        }
+        if err = c.Close(); err != nil {
+            err = fmt.Errorf("closer %v: second Close returned %v", i, err)
+            return
+        }
+    }
+
+    called := false
+    if e := strs.Do(func(v StringVector) error { called = true; return nil }); e != ErrClosed || called {
+        err = fmt.Errorf("Do after Close returned %v, called %v", e, called)
+        return
+    }
+    return
+}
+
+func testOwnedDoSerialized() (err error) {
+
+    defer catch(&err)
+
+    qe := NewOwnedQueryEnvironment()
+    defer qe.Close()
+
+    var inside, most int32
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            qe.Do(func(env QueryEnvironment) error {
+                n := atomic.AddInt32(&inside, 1)
+                defer atomic.AddInt32(&inside, -1)
+                for {
+                    m := atomic.LoadInt32(&most)
+                    if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+                        break
+                    }
+                }
+                time.Sleep(5 * time.Millisecond)
+                return nil
+            })
+        }()
+    }
+    wg.Wait()
+    if most != 1 {
+        err = fmt.Errorf("%v calls to Do ran at once, expected 1", most)
+    }
+    return
+}
+
+func testOwnedQueryEnvironment() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    qe := NewOwnedQueryEnvironment()
+    defer qe.Close()
+
+    var results []ScoredResult
+    err = qe.Do(func(env QueryEnvironment) (err error) {
+        if err = env.AddIndex(repositoryPath); err != nil {
+            return
+        }
+        results, err = env.RunQuery("pizza", 10)
+        return
+    })
+    if err != nil {
+        return
+    }
+    if len(results) != 2 {
+        err = fmt.Errorf("expected 2 results, got %+v", results)
+        return
+    }
+
+    // a failing call is an error, the environment is still usable
+    if e := qe.Do(func(env QueryEnvironment) error { return env.AddIndex(dir + "/missing") }); e == nil {
+        err = fmt.Errorf("AddIndex of a missing repository expected an error")
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testOwnedIndexEnvironmentMonitor() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    monitor := NewOwnedStatusMonitor()
+    ie := NewOwnedIndexEnvironment(monitor)
+    defer ie.Close()
+
+    err = ie.Do(func(env IndexEnvironment) error {
+        return monitor.Do(func(m MyStatusMonitor) error {
+            return env.Create(filepath.Join(dir, "owned-monitor"), m)
+        })
+    })
+    if err != nil {
+        return
+    }
+
+    // the environment still holds the monitor
+    if err = monitor.Close(); err != nil {
+        return
+    }
+    if e := monitor.Do(func(m MyStatusMonitor) error { return nil }); e != nil {
+        err = fmt.Errorf("Do on a held monitor returned %v", e)
+        return
+    }
+
+    if err = ie.Close(); err != nil {
+        return
+    }
+    if e := monitor.Do(func(m MyStatusMonitor) error { return nil }); e != ErrClosed {
+        err = fmt.Errorf("Do on the monitor of a closed environment returned %v, expected %v", e, ErrClosed)
+        return
+    }
+    return
+}
@@ -5,8 +5,26 @@
  * protect_post.i - see protect_pre.i for comments
  */
 
+%go_import("context")
+%go_import("crypto/hmac")
+%go_import("crypto/rand")
+%go_import("crypto/sha256")
+%go_import("encoding/base64")
+%go_import("encoding/json")
+%go_import("encoding/xml")
+%go_import("errors")
 %go_import("fmt")
+%go_import("hash/fnv")
+%go_import("io")
+%go_import("io/ioutil")
+%go_import("math")
+%go_import("os")
+%go_import("path/filepath")
 %go_import("runtime")
+%go_import("strconv")
+%go_import("strings")
+%go_import("time")
+%go_import("github.com/dms3-fs/go-idx-indri/query")
 
 %insert(go_wrapper) %{
 
@@ -33,30 +51,46 @@
 func catch(err *error) {
     if r := recover(); r != nil {
         var sf []string = []string{}
-        var pc []uintptr
-        var skip, fc int = 0, 0
+        var pc []uintptr = make([]uintptr, 64)
+        var skip, fc int = 2, 0
         fc = runtime.Callers(skip, pc)
         if fc > 0 {
-            fp := runtime.CallersFrames(pc)
+            fp := runtime.CallersFrames(pc[:fc])
             if fp != nil {
-                var more bool
+                var more bool = true
                 var f runtime.Frame
-                for more = true; more; f, more = fp.Next() {
-                    sf = append(sf, fmt.Sprintf("%v %v %v %v\n", f.PC, f.Function, f.File, f.Line))
+                for more {
+                    f, more = fp.Next()
+                    sf = append(sf, fmt.Sprintf("%v %v %v %v", f.PC, f.Function, f.File, f.Line))
                 }
             }
         }
-        *err = fmt.Errorf("Panic stack %v, error %v", sf, r)
+        *err = newPanicError(r, sf)
     }
 }
 
 %}
 
+%include "Errors_post.i"
 %include "Parameters_post.i"
 %include "IndexEnvironment_post.i"
 %include "IndriBuildIndex_post.i"
+%include "IndexConfig_post.i"
+%include "IndexConfigXML_post.i"
 %include "MetadataPair_post.i"
 %include "MetadataPairVector_post.i"
+%include "ScoredExtentResult_post.i"
+%include "ParsedDocument_post.i"
+%include "DocumentVector_post.i"
+%include "QueryRequest_post.i"
+%include "QueryAnnotation_post.i"
+%include "QueryEnvironment_post.i"
+%include "IndexStats_post.i"
+%include "Search_post.i"
+%include "QueryExpander_post.i"
+%include "Owned_post.i"
+%include "QueryPool_post.i"
+%include "IndexWriter_post.i"
 
 
 #endif
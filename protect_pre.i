@@ -132,6 +132,50 @@ extern const std::string indriVersion;
 %rename(Wrapped_documentsSeen) indri::api::IndexEnvironment::documentsSeen;
 
 
+%rename(Wrapped_QueryEnvironment) indri::api::QueryEnvironment;
+%rename(Wrapped_addServer) indri::api::QueryEnvironment::addServer;
+%rename(Wrapped_addIndex) indri::api::QueryEnvironment::addIndex;
+%rename(Wrapped_removeServer) indri::api::QueryEnvironment::removeServer;
+%rename(Wrapped_removeIndex) indri::api::QueryEnvironment::removeIndex;
+%rename(Wrapped_close) indri::api::QueryEnvironment::close;
+%rename(Wrapped_setMemory) indri::api::QueryEnvironment::setMemory;
+%rename(Wrapped_setScoringRules) indri::api::QueryEnvironment::setScoringRules;
+%rename(Wrapped_setStopwords) indri::api::QueryEnvironment::setStopwords;
+%rename(Wrapped_runQuery) indri::api::QueryEnvironment::runQuery;
+%rename(Wrapped_runAnnotatedQuery) indri::api::QueryEnvironment::runAnnotatedQuery;
+%rename(Wrapped_runQuerydocset) indri::api::QueryEnvironment::runQuerydocset;
+%rename(Wrapped_runAnnotatedQuerydocset) indri::api::QueryEnvironment::runAnnotatedQuerydocset;
+%rename(Wrapped_documents) indri::api::QueryEnvironment::documents;
+%rename(Wrapped_documentMetadata) indri::api::QueryEnvironment::documentMetadata;
+%rename(Wrapped_documentIDsFromMetadata) indri::api::QueryEnvironment::documentIDsFromMetadata;
+%rename(Wrapped_documentsFromMetadata) indri::api::QueryEnvironment::documentsFromMetadata;
+%rename(Wrapped_termCount) indri::api::QueryEnvironment::termCount;
+%rename(Wrapped_termFieldCount) indri::api::QueryEnvironment::termFieldCount;
+%rename(Wrapped_fieldList) indri::api::QueryEnvironment::fieldList;
+%rename(Wrapped_documentCount) indri::api::QueryEnvironment::documentCount;
+%rename(Wrapped_documentVectors) indri::api::QueryEnvironment::documentVectors;
+%rename(Wrapped_expressionCount) indri::api::QueryEnvironment::expressionCount;
+%rename(Wrapped_documentExpressionCount) indri::api::QueryEnvironment::documentExpressionCount;
+%rename(Wrapped_expressionList) indri::api::QueryEnvironment::expressionList;
+%rename(Wrapped_documentLength) indri::api::QueryEnvironment::documentLength;
+%rename(Wrapped_setFormulationParameters) indri::api::QueryEnvironment::setFormulationParameters;
+%rename(Wrapped_reformulateQuery) indri::api::QueryEnvironment::reformulateQuery;
+%rename(Wrapped_stemTerm) indri::api::QueryEnvironment::stemTerm;
+%rename(Wrapped_termCountUnique) indri::api::QueryEnvironment::termCountUnique;
+%rename(Wrapped_stemCount) indri::api::QueryEnvironment::stemCount;
+%rename(Wrapped_stemFieldCount) indri::api::QueryEnvironment::stemFieldCount;
+%rename(Wrapped_documentStemCount) indri::api::QueryEnvironment::documentStemCount;
+%rename(Wrapped_documentsdocids) indri::api::QueryEnvironment::documentsdocids;
+%rename(Wrapped_documentMetadatadocids) indri::api::QueryEnvironment::documentMetadatadocids;
+%rename(Wrapped_onetermCount) indri::api::QueryEnvironment::onetermCount;
+%rename(Wrapped_onedocumentCount) indri::api::QueryEnvironment::onedocumentCount;
+
+
+%rename(Wrapped_RMExpander) indri::query::RMExpander;
+%rename(Wrapped_PonteExpander) indri::query::PonteExpander;
+%rename(Wrapped_runExpandedQuery) indri::query::QueryExpander::runExpandedQuery;
+
+
 %rename(Wrapped_deleteFileClassSpec) deleteFileClassSpec;
 
 
@@ -141,4 +185,7 @@ extern const std::string indriVersion;
 // see MetadataPairVector_post.i
 //%rename(Wrapped_MetadataPairVector) MetadataPairVector;
 
+
+%rename(Wrapped_TermExtent) indri::parse::TermExtent;
+
 #endif
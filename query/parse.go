@@ -0,0 +1,473 @@
+package query
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+//
+// SyntaxError is a problem in query text at Offset, a byte offset. Column
+// counts characters from 1.
+//
+type SyntaxError struct {
+    Query string
+    Offset int
+    Message string
+}
+
+// Column is the character position of the error, counted from 1
+func (e *SyntaxError) Column() int {
+    return utf8.RuneCountInString(e.Query[:e.Offset]) + 1
+}
+
+func (e *SyntaxError) Error() string {
+    return fmt.Sprintf("query column %v: %v", e.Column(), e.Message)
+}
+
+//
+// Parse reads Indri query text into the nodes of this package. Several
+// nodes at the top level are combined with #combine, as Indri does. The
+// operators without a node type here, such as #band, #max or extent
+// restrictions like #combine[title], are syntax errors, so the text
+// accepted is exactly the text Render can produce.
+//
+func Parse(s string) (Node, error) {
+    p := &parser{s: s}
+    nodes, err := p.nodes()
+    if err != nil {
+        return nil, err
+    }
+    if p.pos < len(s) {
+        return nil, p.errorf("unexpected %q", p.s[p.pos])
+    }
+    switch len(nodes) {
+    case 0:
+        return nil, p.errorf("empty query")
+    case 1:
+        return nodes[0], nil
+    }
+    return Combine(nodes...), nil
+}
+
+type parser struct {
+    s string
+    pos int
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+    return p.errorAt(p.pos, format, a...)
+}
+
+func (p *parser) errorAt(pos int, format string, a ...interface{}) error {
+    return &SyntaxError{Query: p.s, Offset: pos, Message: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) peek() rune {
+    if p.pos >= len(p.s) {
+        return -1
+    }
+    r, _ := utf8.DecodeRuneInString(p.s[p.pos:])
+    return r
+}
+
+func (p *parser) skipSpace() {
+    for p.pos < len(p.s) {
+        r, n := utf8.DecodeRuneInString(p.s[p.pos:])
+        if !unicode.IsSpace(r) {
+            return
+        }
+        p.pos += n
+    }
+}
+
+// accept skips space and c, it reports whether c was there
+func (p *parser) accept(c rune) bool {
+    p.skipSpace()
+    if p.peek() == c {
+        p.pos++
+        return true
+    }
+    return false
+}
+
+func (p *parser) expect(c rune) error {
+    if !p.accept(c) {
+        if p.pos >= len(p.s) {
+            return p.errorf("expected %q at end of query", c)
+        }
+        return p.errorf("expected %q, found %q", c, p.peek())
+    }
+    return nil
+}
+
+func isTermRune(r rune) bool {
+    return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// word reads a run of letters and digits, joined by - or _, or a decimal number
+func (p *parser) word() string {
+    start := p.pos
+    for p.pos < len(p.s) {
+        r, n := utf8.DecodeRuneInString(p.s[p.pos:])
+        if isTermRune(r) || (r == '-' || r == '_') && p.pos > start && p.pos+n < len(p.s) && isTermRune(p.runeAt(p.pos+n)) || r == '.' && isDecimalPoint(p.s, start, p.pos) {
+            p.pos += n
+            continue
+        }
+        break
+    }
+    return p.s[start:p.pos]
+}
+
+func (p *parser) runeAt(pos int) rune {
+    r, _ := utf8.DecodeRuneInString(p.s[pos:])
+    return r
+}
+
+// number reads a signed decimal number, with an optional fraction and exponent
+func (p *parser) number() (string, int) {
+    p.skipSpace()
+    start := p.pos
+    if p.pos < len(p.s) && (p.s[p.pos] == '-' || p.s[p.pos] == '+') {
+        p.pos++
+    }
+    for p.pos < len(p.s) && strings.IndexByte("0123456789.eE", p.s[p.pos]) >= 0 {
+        if (p.s[p.pos] == 'e' || p.s[p.pos] == 'E') && p.pos+1 < len(p.s) && (p.s[p.pos+1] == '-' || p.s[p.pos+1] == '+') {
+            p.pos++
+        }
+        p.pos++
+    }
+    return p.s[start:p.pos], start
+}
+
+// nodes reads nodes up to a ')' or the end of the query
+func (p *parser) nodes() (nodes []Node, err error) {
+    for {
+        p.skipSpace()
+        if p.pos >= len(p.s) || p.peek() == ')' {
+            return
+        }
+        var n Node
+        if n, err = p.node(); err != nil {
+            return
+        }
+        nodes = append(nodes, n)
+    }
+}
+
+func (p *parser) node() (Node, error) {
+    p.skipSpace()
+    start := p.pos
+    switch r := p.peek(); {
+    case r == '#':
+        return p.operator()
+    case r == '"':
+        p.pos++
+        w := &Window{Ordered: true, Size: 1}
+        for {
+            p.skipSpace()
+            if p.peek() == '"' {
+                p.pos++
+                break
+            }
+            if p.pos >= len(p.s) {
+                return nil, p.errorAt(start, "unterminated quoted phrase")
+            }
+            t := p.word()
+            if t == "" {
+                return nil, p.errorf("unexpected %q in quoted phrase", p.peek())
+            }
+            w.Children = append(w.Children, &Term{Text: t})
+        }
+        if len(w.Children) == 0 {
+            return nil, p.errorAt(start, "empty quoted phrase")
+        }
+        return w, p.field(&w.Field)
+    case isTermRune(r):
+        t := &Term{Text: p.word()}
+        return t, p.field(&t.Field)
+    case r < 0:
+        return nil, p.errorf("unexpected end of query")
+    default:
+        return nil, p.errorf("unexpected %q", r)
+    }
+}
+
+// field reads an optional .field suffix
+func (p *parser) field(field *string) error {
+    if p.peek() != '.' {
+        return nil
+    }
+    p.pos++
+    start := p.pos
+    name := p.word()
+    if !isName(name) {
+        if p.peek() == '(' {
+            return p.errorAt(start, "field lists are not supported")
+        }
+        return p.errorAt(start, "expected a field name after '.'")
+    }
+    *field = name
+    return nil
+}
+
+func (p *parser) operator() (Node, error) {
+    start := p.pos
+    p.pos++ // #
+    name := strings.ToLower(p.word())
+    op := "#" + name
+    if p.peek() == '[' {
+        return nil, p.errorf("extent restrictions like %v[field] are not supported", op)
+    }
+    if err := p.expect('('); err != nil {
+        return nil, err
+    }
+
+    var n Node
+    var err error
+    switch {
+    case name == "combine" || name == "and" || name == "or" || name == "not":
+        b := &Belief{Op: op}
+        if b.Children, err = p.nodes(); err == nil && name == "not" && len(b.Children) != 1 {
+            err = p.errorAt(start, "#not has %v children, expected 1", len(b.Children))
+        }
+        n = b
+    case name == "weight" || name == "wsum":
+        n, err = p.weighted(op)
+    case name == "syn":
+        s := &SynNode{}
+        s.Children, err = p.extents(op)
+        n = s
+    case name == "filreq" || name == "filrej":
+        n, err = p.filter(op, name == "filrej")
+    case name == "prior":
+        n, err = p.prior()
+    case name == "less" || name == "greater" || name == "between":
+        n, err = p.compare(op)
+    default:
+        w := &Window{}
+        if w.Ordered, w.Size, err = windowSize(name); err != nil {
+            return nil, p.errorAt(start, "%v", err)
+        }
+        if w.Children, err = p.extents(op); err == nil {
+            n = w
+        }
+    }
+    if err != nil {
+        return nil, err
+    }
+    if p.skipSpace(); p.pos >= len(p.s) {
+        return nil, p.errorAt(start, "%v is not closed", op)
+    }
+    if err = p.expect(')'); err != nil {
+        return nil, err
+    }
+    if len(childrenOf(n)) == 0 {
+        return nil, p.errorAt(start, "%v has no children", op)
+    }
+    if w, ok := n.(*Window); ok {
+        return w, p.field(&w.Field)
+    }
+    return n, nil
+}
+
+// windowSize reads the window operators #N, #odN, #uwN and #uw
+func windowSize(name string) (ordered bool, size int, err error) {
+    digits := name
+    switch {
+    case strings.HasPrefix(name, "uw"):
+        digits = name[2:]
+        if digits == "" {
+            return false, 0, nil
+        }
+    case strings.HasPrefix(name, "od"):
+        ordered, digits = true, name[2:]
+    default:
+        ordered = true
+    }
+    if size, err = strconv.Atoi(digits); err != nil || size < 1 {
+        return false, 0, fmt.Errorf("unsupported operator #%v", name)
+    }
+    return
+}
+
+func (p *parser) extents(op string) ([]Extent, error) {
+    var extents []Extent
+    for {
+        p.skipSpace()
+        if p.pos >= len(p.s) || p.peek() == ')' {
+            return extents, nil
+        }
+        start := p.pos
+        n, err := p.node()
+        if err != nil {
+            return nil, err
+        }
+        x, ok := n.(Extent)
+        if !ok {
+            return nil, p.errorAt(start, "%v children must be terms, windows or #syn", op)
+        }
+        extents = append(extents, x)
+    }
+}
+
+func (p *parser) weighted(op string) (Node, error) {
+    n := &WeightNode{Op: op}
+    for {
+        p.skipSpace()
+        if p.pos >= len(p.s) || p.peek() == ')' {
+            return n, nil
+        }
+        s, start := p.number()
+        w, err := strconv.ParseFloat(s, 64)
+        if err != nil {
+            return nil, p.errorAt(start, "expected a %v weight", op)
+        }
+        p.skipSpace()
+        if p.peek() == ')' || p.pos >= len(p.s) {
+            return nil, p.errorAt(start, "%v weight %v has no node", op, s)
+        }
+        child, err := p.node()
+        if err != nil {
+            return nil, err
+        }
+        n.Children = append(n.Children, Weighted{Weight: w, Node: child})
+    }
+}
+
+func (p *parser) filter(op string, reject bool) (Node, error) {
+    start := p.pos
+    nodes, err := p.nodes()
+    if err != nil {
+        return nil, err
+    }
+    if len(nodes) != 2 {
+        return nil, p.errorAt(start, "%v has %v children, expected 2", op, len(nodes))
+    }
+    return &Filter{Reject: reject, Filter: nodes[0], Query: nodes[1]}, nil
+}
+
+func (p *parser) prior() (Node, error) {
+    p.skipSpace()
+    start := p.pos
+    name := p.word()
+    if !isName(name) {
+        return nil, p.errorAt(start, "expected a prior name")
+    }
+    return Prior(name), nil
+}
+
+func (p *parser) compare(op string) (Node, error) {
+    p.skipSpace()
+    start := p.pos
+    field := p.word()
+    if !isName(field) {
+        return nil, p.errorAt(start, "expected a field name")
+    }
+    c := &Compare{Op: op, Field: field}
+    values := []*int64{&c.Low}
+    if op == "#between" {
+        values = append(values, &c.High)
+    }
+    for _, v := range values {
+        s, start := p.number()
+        n, err := strconv.ParseInt(s, 10, 64)
+        if err != nil {
+            return nil, p.errorAt(start, "expected an integer %v value", op)
+        }
+        *v = n
+        if v == &c.High && c.High < c.Low {
+            return nil, p.errorAt(start, "#between high bound %v is below the low bound %v", c.High, c.Low)
+        }
+    }
+    return c, nil
+}
+
+// childrenOf returns the children of an operator node, or n itself for a leaf
+func childrenOf(n Node) []Node {
+    switch x := n.(type) {
+    case *Belief:
+        return x.Children
+    case *WeightNode:
+        nodes := make([]Node, len(x.Children))
+        for i, c := range x.Children {
+            nodes[i] = c.Node
+        }
+        return nodes
+    case *Window:
+        return extentNodes(x.Children)
+    case *SynNode:
+        return extentNodes(x.Children)
+    case *Filter:
+        return []Node{x.Filter, x.Query}
+    }
+    return []Node{n}
+}
+
+func extentNodes(extents []Extent) []Node {
+    nodes := make([]Node, len(extents))
+    for i, x := range extents {
+        nodes[i] = x
+    }
+    return nodes
+}
+
+func isLeaf(n Node) bool {
+    switch n.(type) {
+    case *Term, *PriorNode, *Compare:
+        return true
+    }
+    return false
+}
+
+//
+// Format renders n as Render does, with each operator holding another
+// operator broken over indented lines. The text is still valid Indri
+// query text.
+//
+func Format(n Node) (string, error) {
+    if _, err := Render(n); err != nil {
+        return "", err
+    }
+    var b strings.Builder
+    format(&b, n, "")
+    return b.String(), nil
+}
+
+func format(b *strings.Builder, n Node, indent string) {
+    children := childrenOf(n)
+    flat := isLeaf(n)
+    if !flat {
+        flat = true
+        for _, c := range children {
+            flat = flat && isLeaf(c)
+        }
+    }
+    if flat {
+        b.WriteString(n.String())
+        return
+    }
+
+    s := n.String()
+    b.WriteString(s[:strings.IndexByte(s, '(')+1])
+    inner := indent + "    "
+    weights, _ := n.(*WeightNode)
+    for i, c := range children {
+        b.WriteString("\n")
+        b.WriteString(inner)
+        if weights != nil {
+            b.WriteString(FormatWeight(weights.Children[i].Weight))
+            b.WriteString(" ")
+        }
+        format(b, c, inner)
+    }
+    b.WriteString("\n")
+    b.WriteString(indent)
+    b.WriteString(")")
+    if w, ok := n.(*Window); ok && w.Field != "" {
+        b.WriteString(".")
+        b.WriteString(w.Field)
+    }
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+/**
+ * Test query text parses into the nodes the builder makes.
+**/
+func TestParse(t *testing.T) {
+    err := testParse()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test rendered queries parse back to the same text.
+**/
+func TestParseRoundTrip(t *testing.T) {
+    err := testParseRoundTrip()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test syntax errors report their position.
+**/
+func TestParseErrors(t *testing.T) {
+    err := testParseErrors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test Format indents nested operators.
+**/
+func TestFormat(t *testing.T) {
+    err := testFormat()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testParse() (err error) {
+    cases := []struct {
+        text string
+        node Node
+    }{
+        {"pizza", Word("pizza")},
+        {"pizza.title", Word("pizza").In("title")},
+        {"food court", Combine(Word("food"), Word("court"))},
+        {"#Combine( pizza  mall )", Combine(Word("pizza"), Word("mall"))},
+        {"\"food court\".title", Phrase("food court").In("title")},
+        {"#od2(food court)", Ordered(2, Word("food"), Word("court"))},
+        {"#uw(a b)", Unordered(0, Word("a"), Word("b"))},
+        {"#weight(0.5 pizza -1e-05 #1(a b))", Weight(W(0.5, Word("pizza")), W(-0.00001, Ordered(1, Word("a"), Word("b"))))},
+        {"#filrej(#less(year -5) #combine(x))", Filrej(Less("year", -5), Combine(Word("x")))},
+        {"#combine(#prior(recent) #between(year 1990 2000))", Combine(Prior("recent"), Between("year", 1990, 2000))},
+        {"food-court", Word("food-court")},
+        {"3.5", Word("3.5")},
+        {"#combine(version 3.5.title)", Combine(Word("version"), Word("3.5").In("title"))},
+        {"2000.year", Word("2000").In("year")},
+    }
+    for _, c := range cases {
+        n, e := Parse(c.text)
+        if e != nil {
+            return fmt.Errorf("%q: %v", c.text, e)
+        }
+        if !reflect.DeepEqual(n, c.node) {
+            return fmt.Errorf("%q parsed to %v, expected %v", c.text, n, c.node)
+        }
+    }
+    return
+}
+
+func testParseRoundTrip() (err error) {
+    queries := []string{
+        "#combine(food court pizza)",
+        "#weight(0.7 pizza 0.3 #1(food court))",
+        "#wsum(2 a 0.00001 b)",
+        "#and(pizza #or(pasta #not(parking)))",
+        "#syn(car #1(motor car).title)",
+        "#combine(#uw8(food pizza).body #3(food court))",
+        "#filreq(#greater(year 2000) #combine(#prior(recent) mall))",
+        "#combine(python 3.5 #1(version 2.7))",
+        // ReformulateQuery output
+        "#weight( 0.5 #combine( pizza mall ) 0.5 #weight( 0.25 pizza 0.125 food 0.0625 court ) )",
+    }
+    for _, q := range queries {
+        n, e := Parse(q)
+        if e != nil {
+            return fmt.Errorf("%q: %v", q, e)
+        }
+        s, e := Render(n)
+        if e != nil {
+            return fmt.Errorf("%q: %v", q, e)
+        }
+        again, e := Parse(s)
+        if e != nil || !reflect.DeepEqual(again, n) {
+            return fmt.Errorf("%q rendered %q, which parses to %v, %v", q, s, again, e)
+        }
+        f, e := Format(n)
+        if e != nil {
+            return e
+        }
+        if again, e = Parse(f); e != nil || !reflect.DeepEqual(again, n) {
+            return fmt.Errorf("%q formatted %q, which parses to %v, %v", q, f, again, e)
+        }
+    }
+    return
+}
+
+func testParseErrors() (err error) {
+    cases := []struct {
+        text string
+        column int
+    }{
+        {"", 1},
+        {"pizza )", 7},
+        {"#combine(pizza", 1},
+        {"#combine pizza", 10},
+        {"#band(a b)", 1},
+        {"#combine[title](a)", 9},
+        {"#1(a #combine(b))", 6},
+        {"#weight(pizza)", 9},
+        {"#weight(0.5)", 9},
+        {"#not(a b)", 1},
+        {"#less(year x)", 12},
+        {"#between(year 10 5)", 18},
+        {"#prior()", 8},
+        {"\"food court", 1},
+        {"pizza.(title,body)", 7},
+        {"pizza. mall", 7},
+        {"café !", 6},
+        {"#combine()", 1},
+    }
+    for _, c := range cases {
+        _, e := Parse(c.text)
+        se, ok := e.(*SyntaxError)
+        if !ok {
+            return fmt.Errorf("%q: expected a *SyntaxError, got %v", c.text, e)
+        }
+        if se.Column() != c.column {
+            return fmt.Errorf("%q: error %v at column %v, expected %v", c.text, se, se.Column(), c.column)
+        }
+    }
+    return
+}
+
+func testFormat() (err error) {
+    n := Weight(W(0.5, Combine(Word("pizza"), Word("mall"))), W(0.5, Filreq(Word("a"), Combine(Phrase("food court")))))
+    expected := `#weight(
+    0.5 #combine(pizza mall)
+    0.5 #filreq(
+        a
+        #combine(
+            #1(food court)
+        )
+    )
+)`
+    s, err := Format(n)
+    if err != nil {
+        return
+    }
+    if s != expected {
+        return fmt.Errorf("formatted\n%v\nexpected\n%v", s, expected)
+    }
+    return
+}
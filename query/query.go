@@ -0,0 +1,545 @@
+//
+// Package query builds Indri query language text from Go values. User text
+// goes through Word, Words and Phrase, which keep only the characters Indri
+// indexes, so punctuation in user input cannot break the query:
+//
+//   q := query.Combine(
+//       query.Phrase("food court"),
+//       query.Word("pizza").In("title"),
+//       query.Prior("recent"),
+//   )
+//   text, err := query.Render(q) // #combine(#1(food court) pizza.title #prior(recent))
+//
+// text is then run with QueryEnvironment.RunQuery.
+//
+package query
+
+import (
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+//
+// Node is a node of an Indri query. Render checks the whole tree and
+// returns its query text, String returns the text without the checks.
+//
+type Node interface {
+    fmt.Stringer
+    render(b *strings.Builder) error
+}
+
+//
+// Extent is a Node matching extents of the document text, a Term, Syn or
+// window. Only extents may be the children of Syn and windows.
+//
+type Extent interface {
+    Node
+    extent()
+}
+
+// Render returns the query text of n, or the first problem found in it
+func Render(n Node) (string, error) {
+    if n == nil {
+        return "", fmt.Errorf("empty query")
+    }
+    var b strings.Builder
+    if err := n.render(&b); err != nil {
+        return "", err
+    }
+    return b.String(), nil
+}
+
+func text(n Node) string {
+    var b strings.Builder
+    n.render(&b)
+    return b.String()
+}
+
+//
+// Tokens splits user text into the terms Indri indexes, runs of letters and
+// digits. Everything else separates terms.
+//
+func Tokens(s string) []string {
+    return strings.FieldsFunc(s, func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+    })
+}
+
+// isDecimalPoint reports whether the '.' at s[i] joins the digits s[start:i] to a digit after it
+func isDecimalPoint(s string, start, i int) bool {
+    if start >= i || i+1 >= len(s) || s[i+1] < '0' || s[i+1] > '9' {
+        return false
+    }
+    for j := start; j < i; j++ {
+        if s[j] < '0' || s[j] > '9' {
+            return false
+        }
+    }
+    return true
+}
+
+// isDecimal reports whether s is a decimal number such as 3.5, a single Indri term
+func isDecimal(s string) bool {
+    i := strings.IndexByte(s, '.')
+    return i > 0 && isDecimalPoint(s, 0, i) && strings.Trim(s[i+1:], "0123456789") == ""
+}
+
+// isName reports whether s is usable as a field or prior name
+func isName(s string) bool {
+    if s == "" {
+        return false
+    }
+    for i, r := range s {
+        if r == '_' || r < unicode.MaxASCII && unicode.IsLetter(r) {
+            continue
+        }
+        if i > 0 && r < unicode.MaxASCII && unicode.IsDigit(r) {
+            continue
+        }
+        return false
+    }
+    return true
+}
+
+func checkName(kind, name string) error {
+    if !isName(name) {
+        return fmt.Errorf("invalid %v name %q", kind, name)
+    }
+    return nil
+}
+
+func renderField(b *strings.Builder, field string) error {
+    if field == "" {
+        return nil
+    }
+    if err := checkName("field", field); err != nil {
+        return err
+    }
+    b.WriteString(".")
+    b.WriteString(field)
+    return nil
+}
+
+func renderChildren(b *strings.Builder, op string, children []Node) error {
+    if len(children) == 0 {
+        return fmt.Errorf("%v has no children", op)
+    }
+    b.WriteString(op)
+    b.WriteString("(")
+    for i, c := range children {
+        if c == nil {
+            return fmt.Errorf("%v child %v is nil", op, i)
+        }
+        if i > 0 {
+            b.WriteString(" ")
+        }
+        if err := c.render(b); err != nil {
+            return err
+        }
+    }
+    b.WriteString(")")
+    return nil
+}
+
+func renderExtents(b *strings.Builder, op string, children []Extent) error {
+    nodes := make([]Node, len(children))
+    for i, c := range children {
+        if c == nil {
+            return fmt.Errorf("%v child %v is nil", op, i)
+        }
+        nodes[i] = c
+    }
+    return renderChildren(b, op, nodes)
+}
+
+//
+// Term is a term of user text, optionally restricted to a field. Text
+// holding more than one token is rendered as the phrase #1 of them, as
+// Indri itself does for hyphenated words. A decimal number such as 3.5 is
+// kept as the one term Indri's query parser reads it as.
+//
+type Term struct {
+    Text string
+    Field string
+}
+
+// Word returns the Term for the user text s
+func Word(s string) *Term {
+    return &Term{Text: s}
+}
+
+// Words returns a Term for each token of the user text s
+func Words(s string) []Node {
+    var terms []Node
+    for _, t := range Tokens(s) {
+        terms = append(terms, &Term{Text: t})
+    }
+    return terms
+}
+
+// In restricts t to field, t.field in Indri
+func (t *Term) In(field string) *Term {
+    t.Field = field
+    return t
+}
+
+func (t *Term) render(b *strings.Builder) error {
+    if isDecimal(t.Text) {
+        b.WriteString(t.Text)
+        return renderField(b, t.Field)
+    }
+    tokens := Tokens(t.Text)
+    switch len(tokens) {
+    case 0:
+        return fmt.Errorf("term %q has no letters or digits", t.Text)
+    case 1:
+        b.WriteString(tokens[0])
+    default:
+        b.WriteString("#1(")
+        b.WriteString(strings.Join(tokens, " "))
+        b.WriteString(")")
+    }
+    return renderField(b, t.Field)
+}
+
+func (t *Term) String() string { return text(t) }
+func (t *Term) extent() {}
+
+//
+// Window matches its children near each other. An ordered window, #N, has
+// them in order with at most Size-1 terms between each, an unordered one,
+// #uwN, has them in any order within Size terms. Size 0 is an unlimited
+// unordered window, #uw.
+//
+type Window struct {
+    Ordered bool
+    Size int
+    Children []Extent
+    Field string
+}
+
+// Ordered returns the ordered window #n(children)
+func Ordered(n int, children ...Extent) *Window {
+    return &Window{Ordered: true, Size: n, Children: children}
+}
+
+// Unordered returns the unordered window #uwn(children)
+func Unordered(n int, children ...Extent) *Window {
+    return &Window{Size: n, Children: children}
+}
+
+// Phrase returns the exact phrase #1 of the tokens of the user text s
+func Phrase(s string) *Window {
+    w := &Window{Ordered: true, Size: 1}
+    for _, t := range Tokens(s) {
+        w.Children = append(w.Children, &Term{Text: t})
+    }
+    return w
+}
+
+// In restricts w to field, #1(...).field in Indri
+func (w *Window) In(field string) *Window {
+    w.Field = field
+    return w
+}
+
+func (w *Window) render(b *strings.Builder) error {
+    var op string
+    switch {
+    case w.Ordered && w.Size < 1:
+        return fmt.Errorf("ordered window size %v is less than 1", w.Size)
+    case w.Ordered:
+        op = "#" + strconv.Itoa(w.Size)
+    case w.Size < 0:
+        return fmt.Errorf("unordered window size %v is negative", w.Size)
+    case w.Size == 0:
+        op = "#uw"
+    default:
+        op = "#uw" + strconv.Itoa(w.Size)
+    }
+    if err := renderExtents(b, op, w.Children); err != nil {
+        return err
+    }
+    return renderField(b, w.Field)
+}
+
+func (w *Window) String() string { return text(w) }
+func (w *Window) extent() {}
+
+// SynNode treats its children as one term, #syn
+type SynNode struct {
+    Children []Extent
+}
+
+// Syn returns #syn(children)
+func Syn(children ...Extent) *SynNode {
+    return &SynNode{Children: children}
+}
+
+func (s *SynNode) render(b *strings.Builder) error {
+    return renderExtents(b, "#syn", s.Children)
+}
+
+func (s *SynNode) String() string { return text(s) }
+func (s *SynNode) extent() {}
+
+//
+// Belief is a belief operator over its children, Op is one of #combine,
+// #and, #or and #not. #not has a single child.
+//
+type Belief struct {
+    Op string
+    Children []Node
+}
+
+// Combine returns #combine(children)
+func Combine(children ...Node) *Belief {
+    return &Belief{Op: "#combine", Children: children}
+}
+
+// And returns #and(children)
+func And(children ...Node) *Belief {
+    return &Belief{Op: "#and", Children: children}
+}
+
+// Or returns #or(children)
+func Or(children ...Node) *Belief {
+    return &Belief{Op: "#or", Children: children}
+}
+
+// Not returns #not(child)
+func Not(child Node) *Belief {
+    return &Belief{Op: "#not", Children: []Node{child}}
+}
+
+func (n *Belief) render(b *strings.Builder) error {
+    switch n.Op {
+    case "#combine", "#and", "#or":
+    case "#not":
+        if len(n.Children) != 1 {
+            return fmt.Errorf("#not has %v children, expected 1", len(n.Children))
+        }
+    default:
+        return fmt.Errorf("unknown belief operator %q", n.Op)
+    }
+    return renderChildren(b, n.Op, n.Children)
+}
+
+func (n *Belief) String() string { return text(n) }
+
+// Weighted is a child of Weight or Wsum with its weight
+type Weighted struct {
+    Weight float64
+    Node Node
+}
+
+// W pairs node with weight, for Weight and Wsum
+func W(weight float64, node Node) Weighted {
+    return Weighted{Weight: weight, Node: node}
+}
+
+//
+// WeightNode combines weighted children, Op is #weight or #wsum.
+//
+type WeightNode struct {
+    Op string
+    Children []Weighted
+}
+
+// Weight returns #weight(w1 n1 w2 n2 ...)
+func Weight(children ...Weighted) *WeightNode {
+    return &WeightNode{Op: "#weight", Children: children}
+}
+
+// Wsum returns #wsum(w1 n1 w2 n2 ...)
+func Wsum(children ...Weighted) *WeightNode {
+    return &WeightNode{Op: "#wsum", Children: children}
+}
+
+// FormatWeight formats a weight as Indri reads it, without an exponent
+func FormatWeight(w float64) string {
+    return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+func (n *WeightNode) render(b *strings.Builder) error {
+    if n.Op != "#weight" && n.Op != "#wsum" {
+        return fmt.Errorf("unknown weight operator %q", n.Op)
+    }
+    if len(n.Children) == 0 {
+        return fmt.Errorf("%v has no children", n.Op)
+    }
+    b.WriteString(n.Op)
+    b.WriteString("(")
+    for i, c := range n.Children {
+        if math.IsNaN(c.Weight) || math.IsInf(c.Weight, 0) {
+            return fmt.Errorf("%v weight %v is not a number", n.Op, c.Weight)
+        }
+        if c.Node == nil {
+            return fmt.Errorf("%v child %v is nil", n.Op, i)
+        }
+        if i > 0 {
+            b.WriteString(" ")
+        }
+        b.WriteString(FormatWeight(c.Weight))
+        b.WriteString(" ")
+        if err := c.Node.render(b); err != nil {
+            return err
+        }
+    }
+    b.WriteString(")")
+    return nil
+}
+
+func (n *WeightNode) String() string { return text(n) }
+
+//
+// Filter scores Query only for documents matching Filter, #filreq, or
+// only for documents not matching it, #filrej.
+//
+type Filter struct {
+    Reject bool
+    Filter Node
+    Query Node
+}
+
+// Filreq returns #filreq(filter query)
+func Filreq(filter, query Node) *Filter {
+    return &Filter{Filter: filter, Query: query}
+}
+
+// Filrej returns #filrej(filter query)
+func Filrej(filter, query Node) *Filter {
+    return &Filter{Reject: true, Filter: filter, Query: query}
+}
+
+func (f *Filter) render(b *strings.Builder) error {
+    op := "#filreq"
+    if f.Reject {
+        op = "#filrej"
+    }
+    return renderChildren(b, op, []Node{f.Filter, f.Query})
+}
+
+func (f *Filter) String() string { return text(f) }
+
+// PriorNode is a document prior defined in the repository, #prior(NAME)
+type PriorNode struct {
+    Name string
+}
+
+// Prior returns #prior(name)
+func Prior(name string) *PriorNode {
+    return &PriorNode{Name: name}
+}
+
+func (p *PriorNode) render(b *strings.Builder) error {
+    if err := checkName("prior", p.Name); err != nil {
+        return err
+    }
+    b.WriteString("#prior(")
+    b.WriteString(p.Name)
+    b.WriteString(")")
+    return nil
+}
+
+func (p *PriorNode) String() string { return text(p) }
+
+//
+// Compare matches documents by the value of a numeric field, Op is one of
+// #less, #greater and #between. High is only used by #between.
+//
+type Compare struct {
+    Op string
+    Field string
+    Low int64
+    High int64
+}
+
+// Less returns #less(field value)
+func Less(field string, value int64) *Compare {
+    return &Compare{Op: "#less", Field: field, Low: value}
+}
+
+// Greater returns #greater(field value)
+func Greater(field string, value int64) *Compare {
+    return &Compare{Op: "#greater", Field: field, Low: value}
+}
+
+// Between returns #between(field low high)
+func Between(field string, low, high int64) *Compare {
+    return &Compare{Op: "#between", Field: field, Low: low, High: high}
+}
+
+func (c *Compare) render(b *strings.Builder) error {
+    switch c.Op {
+    case "#less", "#greater", "#between":
+    default:
+        return fmt.Errorf("unknown numeric operator %q", c.Op)
+    }
+    if err := checkName("field", c.Field); err != nil {
+        return err
+    }
+    b.WriteString(c.Op)
+    b.WriteString("(")
+    b.WriteString(c.Field)
+    b.WriteString(" ")
+    b.WriteString(strconv.FormatInt(c.Low, 10))
+    if c.Op == "#between" {
+        if c.High < c.Low {
+            return fmt.Errorf("#between low %v is above high %v", c.Low, c.High)
+        }
+        b.WriteString(" ")
+        b.WriteString(strconv.FormatInt(c.High, 10))
+    }
+    b.WriteString(")")
+    return nil
+}
+
+func (c *Compare) String() string { return text(c) }
+
+var (
+    _ Extent = (*Term)(nil)
+    _ Extent = (*Window)(nil)
+    _ Extent = (*SynNode)(nil)
+    _ Node = (*Belief)(nil)
+    _ Node = (*WeightNode)(nil)
+    _ Node = (*Filter)(nil)
+    _ Node = (*PriorNode)(nil)
+    _ Node = (*Compare)(nil)
+)
+
+//
+// Extents returns the extents a document can match to score for n, in
+// order: the terms, windows and #syn of n outside #not and outside the
+// filter of #filreq and #filrej.
+//
+func Extents(n Node) []Extent {
+    var extents []Extent
+    var walk func(n Node)
+    walk = func(n Node) {
+        switch x := n.(type) {
+        case Extent:
+            extents = append(extents, x)
+        case *Belief:
+            if x.Op == "#not" {
+                return
+            }
+            for _, c := range x.Children {
+                walk(c)
+            }
+        case *WeightNode:
+            for _, c := range x.Children {
+                walk(c.Node)
+            }
+        case *Filter:
+            walk(x.Query)
+        }
+    }
+    if n != nil {
+        walk(n)
+    }
+    return extents
+}
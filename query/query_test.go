@@ -0,0 +1,166 @@
+package query
+
+import (
+    "fmt"
+    "math"
+    "reflect"
+    "testing"
+)
+
+/**
+ * Test nodes render the Indri query text expected.
+**/
+func TestRender(t *testing.T) {
+    err := testRender()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test user text is escaped into terms.
+**/
+func TestEscape(t *testing.T) {
+    err := testEscape()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test invalid trees are reported by Render.
+**/
+func TestRenderErrors(t *testing.T) {
+    err := testRenderErrors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testRender() (err error) {
+    cases := []struct {
+        node Node
+        expected string
+    }{
+        {Word("pizza"), "pizza"},
+        {Word("pizza").In("title"), "pizza.title"},
+        {Combine(Words("food court pizza")...), "#combine(food court pizza)"},
+        {Weight(W(0.7, Word("pizza")), W(0.3, Phrase("food court"))), "#weight(0.7 pizza 0.3 #1(food court))"},
+        {Wsum(W(2, Word("a")), W(0.00001, Word("b"))), "#wsum(2 a 0.00001 b)"},
+        {And(Word("pizza"), Word("mall")), "#and(pizza mall)"},
+        {Or(Word("pizza"), Word("pasta")), "#or(pizza pasta)"},
+        {Not(Word("parking")), "#not(parking)"},
+        {Syn(Word("car"), Word("automobile")), "#syn(car automobile)"},
+        {Ordered(3, Word("food"), Word("court")), "#3(food court)"},
+        {Unordered(8, Word("food"), Word("pizza")), "#uw8(food pizza)"},
+        {Unordered(0, Word("food"), Word("pizza")), "#uw(food pizza)"},
+        {Phrase("white house").In("title"), "#1(white house).title"},
+        {Filreq(Word("pizza"), Combine(Word("mall"))), "#filreq(pizza #combine(mall))"},
+        {Filrej(Word("parking"), Combine(Word("mall"))), "#filrej(parking #combine(mall))"},
+        {Combine(Prior("recent"), Word("pizza")), "#combine(#prior(recent) pizza)"},
+        {Less("year", 2000), "#less(year 2000)"},
+        {Greater("year", -5), "#greater(year -5)"},
+        {Between("year", 1990, 2000), "#between(year 1990 2000)"},
+        {Combine(Syn(Word("car"), Ordered(1, Word("motor"), Word("car"))), Filreq(Between("year", 1, 2), Word("x"))),
+            "#combine(#syn(car #1(motor car)) #filreq(#between(year 1 2) x))"},
+    }
+    for _, c := range cases {
+        s, e := Render(c.node)
+        if e != nil {
+            return fmt.Errorf("%v: %v", c.expected, e)
+        }
+        if s != c.expected {
+            return fmt.Errorf("rendered %q, expected %q", s, c.expected)
+        }
+        if c.node.String() != s {
+            return fmt.Errorf("String %q differs from Render %q", c.node.String(), s)
+        }
+    }
+    return
+}
+
+func testEscape() (err error) {
+    cases := []struct {
+        text string
+        tokens []string
+        word string
+    }{
+        {"pizza", []string{"pizza"}, "pizza"},
+        {"  pizza  ", []string{"pizza"}, "pizza"},
+        {"food-court", []string{"food", "court"}, "#1(food court)"},
+        {"#combine(evil)", []string{"combine", "evil"}, "#1(combine evil)"},
+        {"a.b", []string{"a", "b"}, "#1(a b)"},
+        {"café 3.14", []string{"café", "3", "14"}, "#1(café 3 14)"},
+        {"\"quoted\" [x]", []string{"quoted", "x"}, "#1(quoted x)"},
+    }
+    for _, c := range cases {
+        if tokens := Tokens(c.text); !reflect.DeepEqual(tokens, c.tokens) {
+            return fmt.Errorf("Tokens(%q) = %q, expected %q", c.text, tokens, c.tokens)
+        }
+        s, e := Render(Word(c.text))
+        if e != nil || s != c.word {
+            return fmt.Errorf("Word(%q) rendered %q, %v, expected %q", c.text, s, e, c.word)
+        }
+    }
+    if s, _ := Render(Combine(Words("pizza) #combine(x")...)); s != "#combine(pizza combine x)" {
+        return fmt.Errorf("Words rendered %q", s)
+    }
+    return
+}
+
+func testRenderErrors() (err error) {
+    invalid := []Node{
+        nil,
+        Word(""),
+        Word("()"),
+        Word("pizza").In("ti tle"),
+        Word("pizza").In("1st"),
+        Combine(),
+        Combine(Word("a"), nil),
+        Combine(Word("a"), Word("!")),
+        &Belief{Op: "#not", Children: []Node{Word("a"), Word("b")}},
+        &Belief{Op: "#max", Children: []Node{Word("a")}},
+        Weight(),
+        Weight(W(math.NaN(), Word("a"))),
+        Wsum(W(math.Inf(1), Word("a"))),
+        Ordered(0, Word("a")),
+        Unordered(-1, Word("a")),
+        Phrase("!!"),
+        Syn(),
+        Filreq(Word("a"), nil),
+        Prior("no such"),
+        Less("", 1),
+        Between("year", 2000, 1990),
+    }
+    for i, n := range invalid {
+        if s, e := Render(n); e == nil {
+            return fmt.Errorf("case %v: rendered %q, expected an error", i, s)
+        }
+    }
+    return
+}
+
+/**
+ * Test Extents collects the scoring extents of a query.
+**/
+func TestExtents(t *testing.T) {
+    q := Combine(
+        Word("pizza"),
+        Not(Word("parking")),
+        Weight(W(1, Phrase("food court")), W(1, Prior("recent"))),
+        Filrej(Word("mall"), Syn(Word("car"), Word("auto"))),
+        Less("year", 2000),
+    )
+    var s []string
+    for _, x := range Extents(q) {
+        s = append(s, x.String())
+    }
+    expected := []string{"pizza", "#1(food court)", "#syn(car auto)"}
+    if !reflect.DeepEqual(s, expected) {
+        t.Fatalf("Extents returned %q, expected %q", s, expected)
+    }
+}
@@ -0,0 +1,152 @@
+package indri_go
+
+import (
+    "fmt"
+    "io/ioutil"
+    "os"
+    "testing"
+
+    "github.com/dms3-fs/go-idx-indri/query"
+)
+
+/**
+ * Test queries built with the query package run against a repository.
+**/
+func TestQueryBuilder(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryBuilder()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test ReformulateQuery output parses with the query package.
+**/
+func TestQueryParseReformulated(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryParseReformulated()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testQueryBuilder() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    cases := []struct {
+        node query.Node
+        results int
+    }{
+        {query.Combine(query.Word("pizza")), 2},
+        {query.Combine(query.Words("pizza!! (parking)")...), 3},
+        {query.Combine(query.Word("food-court")), 1},
+        {query.Combine(query.Phrase("food court")), 1},
+        {query.Combine(query.Unordered(4, query.Word("pizza"), query.Word("food"))), 2},
+        {query.Filreq(query.Word("mall"), query.Combine(query.Word("pizza"))), 1},
+        {query.Filrej(query.Word("mall"), query.Combine(query.Word("pizza"))), 1},
+        {query.Weight(query.W(0.8, query.Word("pizza")), query.W(0.2, query.Syn(query.Word("parking"), query.Word("free")))), 3},
+        {query.Combine(query.Or(query.Word("pizza"), query.Word("weekends")), query.Not(query.Word("mall"))), 3},
+    }
+    for _, c := range cases {
+        text, e := query.Render(c.node)
+        if e != nil {
+            err = e
+            return
+        }
+        results, e := qe.RunQuery(text, 10)
+        if e != nil {
+            err = fmt.Errorf("query %q: %v", text, e)
+            return
+        }
+        if len(results) != c.results {
+            err = fmt.Errorf("query %q returned %v results, expected %v", text, len(results), c.results)
+            return
+        }
+    }
+    return
+}
+
+func testQueryParseReformulated() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    p := NewParameters()
+    defer DeleteWrapped_Parameters(p)
+    if err = p.MyLoad("<parameters><fbDocs>2</fbDocs><fbTerms>5</fbTerms><fbOrigWeight>0.5</fbOrigWeight></parameters>"); err != nil {
+        return
+    }
+    if err = qe.SetFormulationParameters(p); err != nil {
+        return
+    }
+
+    for _, q := range []string{"pizza", "#combine(food court)"} {
+        reformulated, e := qe.ReformulateQuery(q)
+        if e != nil {
+            err = e
+            return
+        }
+        n, e := query.Parse(reformulated)
+        if e != nil {
+            err = fmt.Errorf("ReformulateQuery(%q) returned %q: %v", q, reformulated, e)
+            return
+        }
+        text, e := query.Render(n)
+        if e != nil {
+            err = e
+            return
+        }
+        if _, err = qe.RunQuery(text, 10); err != nil {
+            err = fmt.Errorf("reformulated query %q: %v", text, err)
+            return
+        }
+    }
+    return
+}
@@ -0,0 +1,597 @@
+package indri_go
+
+import (
+    "fmt"
+    "strings"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+/**
+ * Test query results are copied into go ScoredResult slices.
+**/
+func TestRunQuery(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testRunQuery()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test query environment methods return errors instead of panics.
+**/
+func TestQueryEnvErrors(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvErrors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test collection statistics and metadata lookups.
+**/
+func TestQueryEnvMetadata(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvMetadata()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test parsed documents are copied into go Document structs.
+**/
+func TestQueryEnvDocuments(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvDocuments()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test document vectors are copied into go DocumentVector structs.
+**/
+func TestQueryEnvDocumentVectors(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvDocumentVectors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test a query request returns snippets, metadata and timings.
+**/
+func TestQueryEnvRequest(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvRequest()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test an annotated query returns a walkable query tree and node matches.
+**/
+func TestQueryEnvAnnotation(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryEnvAnnotation()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+var queryTestDocuments = []string{
+    "<docno>q1</docno><text>pizza and chinese food at the burlington mall</text>",
+    "<docno>q2</docno><text>the food court serves pizza</text>",
+    "<docno>q3</docno><text>parking is free on weekends</text>",
+}
+
+//
+// buildQueryTestRepository creates a small repository under dir holding
+// queryTestDocuments, and returns the repository path.
+//
+func buildQueryTestRepository(dir string) (repositoryPath string, err error) {
+
+    defer catch(&err)
+
+    repositoryPath = filepath.Join(dir, "index-q")
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+
+    if err = env.SetMemory(int64(64*1024*1024)); err != nil {
+        return
+    }
+    if err = env.SetStoreDocs(true); err != nil {
+        return
+    }
+    if err = env.Create(repositoryPath); err != nil {
+        return
+    }
+
+    pairVector := NewWrapped_MetadataPairVector()
+    defer DeleteWrapped_MetadataPairVector(pairVector)
+
+    for _, doc := range queryTestDocuments {
+        if _, err = env.AddString(doc, "trectext", pairVector); err != nil {
+            env.Close()
+            return
+        }
+    }
+
+    err = env.Close()
+    return
+}
+
+func testRunQuery() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    err = qe.AddIndex(repositoryPath)
+    if err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    results, err := qe.RunQuery("pizza", 10)
+    if err != nil {
+        err = fmt.Errorf("qe.RunQuery error %v", err)
+        return
+    }
+    if len(results) != 2 {
+        err = fmt.Errorf("qe.RunQuery returned %v results, expected 2", len(results))
+        return
+    }
+    for i, r := range results {
+        if r.Document < 1 || r.End <= r.Begin {
+            err = fmt.Errorf("qe.RunQuery result %v is malformed %+v", i, r)
+            return
+        }
+        if i > 0 && r.Score > results[i-1].Score {
+            err = fmt.Errorf("qe.RunQuery results are not ranked by score %+v", results)
+            return
+        }
+    }
+
+    // restrict the query to the second ranked document
+    docset, err := qe.RunQuery("pizza", []int{results[1].Document}, 10)
+    if err != nil {
+        err = fmt.Errorf("qe.RunQuery document set error %v", err)
+        return
+    }
+    if len(docset) != 1 || docset[0].Document != results[1].Document {
+        err = fmt.Errorf("qe.RunQuery document set returned %+v", docset)
+        return
+    }
+
+    extents, err := qe.ExpressionList("food")
+    if err != nil {
+        err = fmt.Errorf("qe.ExpressionList error %v", err)
+        return
+    }
+    if len(extents) != 2 {
+        err = fmt.Errorf("qe.ExpressionList returned %v extents, expected 2", len(extents))
+        return
+    }
+
+    _, err = qe.RunQuery("#combine(pizza", 10)
+    if err == nil {
+        err = fmt.Errorf("qe.RunQuery expected a parse error")
+        return
+    }
+    err = nil
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvErrors() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    err = qe.AddIndex(filepath.Join(dir, "no-such-repository"))
+    if err == nil {
+        err = fmt.Errorf("qe.AddIndex expected an error for a missing repository")
+        return
+    }
+
+    _, err = qe.RunQuery("pizza", 10)
+    if err == nil {
+        err = fmt.Errorf("qe.RunQuery expected an error without an open index")
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvMetadata() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    count, err := qe.DocumentCount()
+    if err != nil || count != int64(len(queryTestDocuments)) {
+        err = fmt.Errorf("qe.DocumentCount returned %v, %v", count, err)
+        return
+    }
+
+    count, err = qe.DocumentCount("pizza")
+    if err != nil || count != 2 {
+        err = fmt.Errorf("qe.DocumentCount(pizza) returned %v, %v", count, err)
+        return
+    }
+
+    ids, err := qe.DocumentIDsFromMetadata("docno", []string{"q3"})
+    if err != nil || len(ids) != 1 {
+        err = fmt.Errorf("qe.DocumentIDsFromMetadata returned %v, %v", ids, err)
+        return
+    }
+
+    docnos, err := qe.DocumentMetadata(ids, "docno")
+    if err != nil || len(docnos) != 1 || docnos[0] != "q3" {
+        err = fmt.Errorf("qe.DocumentMetadata returned %v, %v", docnos, err)
+        return
+    }
+
+    results, err := qe.RunQuery("parking", 10)
+    if err != nil || len(results) != 1 {
+        err = fmt.Errorf("qe.RunQuery returned %v, %v", results, err)
+        return
+    }
+
+    docnos, err = qe.DocumentMetadata(results, "docno")
+    if err != nil || len(docnos) != 1 || docnos[0] != "q3" {
+        err = fmt.Errorf("qe.DocumentMetadata from results returned %v, %v", docnos, err)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvDocuments() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    results, err := qe.RunQuery("parking", 10)
+    if err != nil || len(results) != 1 {
+        err = fmt.Errorf("qe.RunQuery returned %v, %v", results, err)
+        return
+    }
+
+    docs, err := qe.Documents(results)
+    if err != nil || len(docs) != 1 {
+        err = fmt.Errorf("qe.Documents returned %v, %v", docs, err)
+        return
+    }
+
+    doc := docs[0]
+    if string(doc.Metadata["docno"]) != "q3" {
+        err = fmt.Errorf("document docno is %q", doc.Metadata["docno"])
+        return
+    }
+    if !strings.Contains(doc.Text, "parking is free") {
+        err = fmt.Errorf("document text is %q", doc.Text)
+        return
+    }
+    if len(doc.Terms) == 0 || len(doc.Terms) != len(doc.Positions) {
+        err = fmt.Errorf("document has %v terms and %v positions", len(doc.Terms), len(doc.Positions))
+        return
+    }
+    found := false
+    for i, term := range doc.Terms {
+        p := doc.Positions[i]
+        if p.Begin < 0 || p.End > len(doc.Text) || p.Begin >= p.End {
+            err = fmt.Errorf("term %v has a bad position %+v", term, p)
+            return
+        }
+        if term == "parking" && doc.Text[p.Begin:p.End] == "parking" {
+            found = true
+        }
+    }
+    if !found {
+        err = fmt.Errorf("term parking not found at its position in %+v", doc)
+        return
+    }
+
+    docs, err = qe.DocumentsFromMetadata("docno", []string{"q1", "q2"})
+    if err != nil || len(docs) != 2 {
+        err = fmt.Errorf("qe.DocumentsFromMetadata returned %v documents, %v", len(docs), err)
+        return
+    }
+
+    ids, err := qe.DocumentIDsFromMetadata("docno", []string{"q2"})
+    if err != nil || len(ids) != 1 {
+        err = fmt.Errorf("qe.DocumentIDsFromMetadata returned %v, %v", ids, err)
+        return
+    }
+    docs, err = qe.Documentsdocids(ids)
+    if err != nil || len(docs) != 1 || string(docs[0].Metadata["docno"]) != "q2" {
+        err = fmt.Errorf("qe.Documentsdocids returned %v, %v", docs, err)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvDocumentVectors() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    ids, err := qe.DocumentIDsFromMetadata("docno", []string{"q1"})
+    if err != nil || len(ids) != 1 {
+        err = fmt.Errorf("qe.DocumentIDsFromMetadata returned %v, %v", ids, err)
+        return
+    }
+
+    vectors, err := qe.DocumentVectors(ids)
+    if err != nil || len(vectors) != 1 {
+        err = fmt.Errorf("qe.DocumentVectors returned %v, %v", vectors, err)
+        return
+    }
+
+    v := vectors[0]
+    for _, p := range v.Positions {
+        if p < 0 || p >= len(v.Stems) {
+            err = fmt.Errorf("position %v is not a stem index %+v", p, v)
+            return
+        }
+    }
+    tf := v.TermFrequencies()
+    if tf["pizza"] != 1 || tf["mall"] != 1 {
+        err = fmt.Errorf("unexpected term frequencies %v", tf)
+        return
+    }
+    length, err := qe.DocumentLength(ids[0])
+    if err != nil || length != len(v.Positions) {
+        err = fmt.Errorf("qe.DocumentLength returned %v, %v for %v positions", length, err, len(v.Positions))
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvRequest() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    request := QueryRequest{
+        Query: "pizza",
+        Metadata: []string{"docno"},
+        ResultsRequested: 10,
+        Options: TextSnippet,
+    }
+    results, err := qe.RunQueryRequest(request)
+    if err != nil {
+        err = fmt.Errorf("qe.RunQueryRequest error %v", err)
+        return
+    }
+    if len(results.Results) != 2 || results.EstimatedMatches < 2 {
+        err = fmt.Errorf("qe.RunQueryRequest returned %+v", results)
+        return
+    }
+    for _, r := range results.Results {
+        docno := r.Metadata["docno"]
+        if docno != "q1" && docno != "q2" {
+            err = fmt.Errorf("unexpected result docno %q in %+v", docno, r)
+            return
+        }
+        if !strings.Contains(r.Snippet, "pizza") {
+            err = fmt.Errorf("result snippet %q does not contain the query", r.Snippet)
+            return
+        }
+    }
+
+    // second page holds the second result
+    request.StartNum = 1
+    page, err := qe.RunQueryRequest(request)
+    if err != nil || len(page.Results) != 1 || page.Results[0].Docid != results.Results[1].Docid {
+        err = fmt.Errorf("qe.RunQueryRequest second page returned %+v, %v", page, err)
+        return
+    }
+
+    request.Query = "#combine(pizza"
+    _, err = qe.RunQueryRequest(request)
+    if err == nil {
+        err = fmt.Errorf("qe.RunQueryRequest expected a parse error")
+        return
+    }
+
+    err = qe.Close()
+    return
+}
+
+func testQueryEnvAnnotation() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        err = fmt.Errorf("qe.AddIndex error %v", err)
+        return
+    }
+
+    annotation, err := qe.RunAnnotatedQuery("#combine(pizza food)", 10)
+    if err != nil {
+        err = fmt.Errorf("qe.RunAnnotatedQuery error %v", err)
+        return
+    }
+    if annotation.QueryTree == nil || len(annotation.Results) != 2 {
+        err = fmt.Errorf("qe.RunAnnotatedQuery returned %+v", annotation)
+        return
+    }
+
+    // both terms appear as leaves, each matching in the results
+    terms := make(map[string]*AnnotationNode)
+    annotation.QueryTree.Walk(func(node *AnnotationNode) bool {
+        if len(node.Children) == 0 {
+            terms[node.QueryText] = node
+        }
+        return true
+    })
+    for _, term := range []string{"pizza", "food"} {
+        node, ok := terms[term]
+        if !ok {
+            err = fmt.Errorf("query tree has no %q leaf: %+v", term, terms)
+            return
+        }
+        if len(annotation.Matches[node.Name]) == 0 {
+            err = fmt.Errorf("query node %+v has no matches", node)
+            return
+        }
+    }
+    pizza := terms["pizza"]
+    for _, r := range annotation.Results {
+        if len(annotation.NodeMatches(pizza, r.Document)) != 1 {
+            err = fmt.Errorf("expected one pizza match in document %v, got %+v", r.Document, annotation.NodeMatches(pizza, r.Document))
+            return
+        }
+    }
+
+    // a document set restricts the annotated results
+    annotation, err = qe.RunAnnotatedQuery("pizza", []int{annotation.Results[0].Document}, 10)
+    if err != nil || len(annotation.Results) != 1 {
+        err = fmt.Errorf("qe.RunAnnotatedQuery document set returned %+v, %v", annotation, err)
+        return
+    }
+
+    err = qe.Close()
+    return
+}
@@ -0,0 +1,389 @@
+package indri_go
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "sync"
+    "testing"
+    "time"
+)
+
+/**
+ * Test concurrent queries through a QueryPool.
+**/
+func TestQueryPool(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryPool()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test Acquire waits, is cancelled by its context and fails once closed.
+**/
+func TestQueryPoolAcquire(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryPoolAcquire()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test a malformed query leaves its environment in the pool.
+**/
+func TestQueryPoolQueryError(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryPoolQueryError()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test Refresh reopens idle and held environments to see committed documents.
+**/
+func TestQueryPoolRefresh(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testQueryPoolRefresh()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testQueryPool() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    if _, e := NewQueryPool(0, []string{repositoryPath}, nil); e == nil {
+        err = fmt.Errorf("NewQueryPool of size 0 expected an error")
+        return
+    }
+    if _, e := NewQueryPool(2, []string{dir + "/missing"}, nil); e == nil {
+        err = fmt.Errorf("NewQueryPool of a missing repository expected an error")
+        return
+    }
+
+    pool, err := NewQueryPool(2, []string{repositoryPath}, nil)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    const queries = 20
+    errs := make(chan error, queries)
+    var wg sync.WaitGroup
+    for i := 0; i < queries; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            env, err := pool.Acquire(context.Background())
+            if err != nil {
+                errs <- err
+                return
+            }
+            results, err := env.RunQuery("pizza", 10)
+            pool.Release(env, err)
+            if err == nil && len(results) != 2 {
+                err = fmt.Errorf("expected 2 results, got %+v", results)
+            }
+            errs <- err
+        }()
+    }
+    wg.Wait()
+    close(errs)
+    for e := range errs {
+        if e != nil {
+            err = e
+            return
+        }
+    }
+
+    // a query error keeps the environment, an i/o error reopens it
+    env, err := pool.Acquire(context.Background())
+    if err != nil {
+        return
+    }
+    _, queryErr := env.RunQuery("#combine(pizza", 10)
+    if queryErr == nil {
+        err = fmt.Errorf("expected a parse error")
+        return
+    }
+    if err = pool.Release(env, queryErr); err != nil {
+        return
+    }
+    if env, err = pool.Acquire(context.Background()); err != nil {
+        return
+    }
+    if err = pool.Release(env, &LemurError{Code: "LEMUR_IO_ERROR", Message: "connection lost", Kind: ErrIO}); err != nil {
+        return
+    }
+    if e := pool.Release(env, nil); e == nil {
+        err = fmt.Errorf("Release of an environment already released expected an error")
+        return
+    }
+    if e := pool.Release(nil, nil); e == nil {
+        err = fmt.Errorf("Release of a nil environment expected an error")
+        return
+    }
+
+    stats := pool.Stats()
+    if stats.Size != 2 || stats.InUse != 0 || stats.Waiters != 0 {
+        err = fmt.Errorf("unexpected pool stats %+v", stats)
+        return
+    }
+    if stats.Acquires != queries + 2 || stats.Errors != 2 || stats.Reopens != 1 {
+        err = fmt.Errorf("unexpected pool counts %+v", stats)
+        return
+    }
+    if stats.AverageHold() <= 0 {
+        err = fmt.Errorf("expected a positive average hold time, got %+v", stats)
+        return
+    }
+
+    // the reopened environment still searches
+    if env, err = pool.Acquire(context.Background()); err != nil {
+        return
+    }
+    defer pool.Release(env, nil)
+    if _, err = env.RunQuery("pizza", 10); err != nil {
+        return
+    }
+    return
+}
+
+func testQueryPoolAcquire() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    pool, err := NewQueryPool(1, []string{repositoryPath}, nil)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    env, err := pool.Acquire(context.Background())
+    if err != nil {
+        return
+    }
+
+    // the only environment is held, so Acquire waits until ctx is done
+    ctx, cancel := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+    defer cancel()
+    if _, e := pool.Acquire(ctx); e != context.DeadlineExceeded {
+        err = fmt.Errorf("Acquire returned %v, expected %v", e, context.DeadlineExceeded)
+        return
+    }
+
+    // a waiting Acquire gets the environment when it is released
+    acquired := make(chan error, 1)
+    go func() {
+        env, err := pool.Acquire(context.Background())
+        if err == nil {
+            pool.Release(env, nil)
+        }
+        acquired <- err
+    }()
+    time.Sleep(10 * time.Millisecond)
+    pool.Release(env, nil)
+    if err = <-acquired; err != nil {
+        return
+    }
+
+    // a waiting Acquire fails when the pool is closed
+    if env, err = pool.Acquire(context.Background()); err != nil {
+        return
+    }
+    go func() {
+        _, err := pool.Acquire(context.Background())
+        acquired <- err
+    }()
+    time.Sleep(10 * time.Millisecond)
+    if err = pool.Close(); err != nil {
+        return
+    }
+    if e := <-acquired; e != ErrClosed {
+        err = fmt.Errorf("waiting Acquire returned %v, expected %v", e, ErrClosed)
+        return
+    }
+    pool.Release(env, nil)
+
+    if _, e := pool.Acquire(context.Background()); e != ErrClosed {
+        err = fmt.Errorf("Acquire after Close returned %v, expected %v", e, ErrClosed)
+        return
+    }
+    if stats := pool.Stats(); stats.InUse != 0 || stats.Waiters != 0 {
+        err = fmt.Errorf("unexpected pool stats %+v", stats)
+        return
+    }
+    return
+}
+
+func testQueryPoolQueryError() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    pool, err := NewQueryPool(1, []string{repositoryPath}, nil)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    env, err := pool.Acquire(context.Background())
+    if err != nil {
+        return
+    }
+    _, queryErr := env.RunQuery("#combine(pizza", 10)
+    if !errors.Is(queryErr, ErrParse) {
+        pool.Release(env, queryErr)
+        err = fmt.Errorf("RunQuery of a malformed query returned %T %v", queryErr, queryErr)
+        return
+    }
+    if err = pool.Release(env, queryErr); err != nil {
+        return
+    }
+
+    // the one slot holds the same environment, not a reopened one
+    again, err := pool.Acquire(context.Background())
+    if err != nil {
+        return
+    }
+    defer pool.Release(again, nil)
+    if again.Swigcptr() != env.Swigcptr() {
+        err = fmt.Errorf("a parse error replaced the pooled environment")
+        return
+    }
+    if stats := pool.Stats(); stats.Reopens != 0 || stats.Errors != 1 {
+        err = fmt.Errorf("unexpected pool counts %+v", stats)
+        return
+    }
+    results, err := again.RunQuery("pizza", 10)
+    if err == nil && len(results) != 2 {
+        err = fmt.Errorf("expected 2 results, got %+v", results)
+    }
+    return
+}
+
+func testQueryPoolRefresh() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    pool, err := NewQueryPool(2, []string{repositoryPath}, nil)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    // one environment is held across the commit, the other idle
+    held, err := pool.Acquire(context.Background())
+    if err != nil {
+        return
+    }
+
+    cfg := NewIndexConfig()
+    cfg.Index = repositoryPath
+    cfg.Memory = 64 * 1024 * 1024
+    commits := 0
+    w, err := NewIndexWriter(cfg, IndexWriterOptions{Committed: func() { commits++; pool.Refresh() }})
+    if err != nil {
+        pool.Release(held, nil)
+        return
+    }
+    defer w.Close()
+    if _, err = w.AddString("<DOC><DOCNO>q4</DOCNO><TEXT>pizza delivery</TEXT></DOC>", "trectext", nil).Wait(); err != nil {
+        pool.Release(held, nil)
+        return
+    }
+    if _, err = w.Commit().Wait(); err != nil {
+        pool.Release(held, nil)
+        return
+    }
+    if commits != 1 {
+        pool.Release(held, nil)
+        return fmt.Errorf("Committed was called %v times, expected 1", commits)
+    }
+    if err = pool.Release(held, nil); err != nil {
+        return
+    }
+
+    envs := make([]QueryEnvironment, 2)
+    for i := range envs {
+        if envs[i], err = pool.Acquire(context.Background()); err != nil {
+            return
+        }
+        defer pool.Release(envs[i], nil)
+    }
+    for i, env := range envs {
+        results, e := env.RunQuery("pizza", 10)
+        if e != nil {
+            return e
+        }
+        if len(results) != 3 {
+            return fmt.Errorf("environment %v matched pizza %v times after Refresh, expected 3", i, len(results))
+        }
+    }
+    if stats := pool.Stats(); stats.Reopens != 2 {
+        return fmt.Errorf("unexpected pool counts %+v, expected 2 reopens", stats)
+    }
+    return
+}
@@ -0,0 +1,236 @@
+package indri_go
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+/**
+ * Test Search pages through results with offsets and cursors.
+**/
+func TestSearch(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testSearch()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test SearchCursor rejects invalid and stale tokens.
+**/
+func TestSearchCursorErrors(t *testing.T) {
+    forcepanic, forceerror = false, false
+    err := testSearchCursorErrors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+func testSearch() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    first, err := qe.Search("pizza", 0, 1)
+    if err != nil {
+        return
+    }
+    if len(first.Results) != 1 || first.TotalExact || first.Total < 2 || first.Next == "" || first.Prev != "" {
+        err = fmt.Errorf("first page %+v, expected 1 of an estimated 2 or more results and a next cursor", first)
+        return
+    }
+
+    second, err := qe.SearchCursor(first.Next)
+    if err != nil {
+        return
+    }
+    if len(second.Results) != 1 || second.Offset != 1 || !second.TotalExact || second.Total != 2 || second.Next != "" || second.Prev == "" {
+        err = fmt.Errorf("second page %+v, expected the last of exactly 2 results", second)
+        return
+    }
+    if second.Results[0].Docid == first.Results[0].Docid {
+        err = fmt.Errorf("both pages returned document %v", first.Results[0].Docid)
+        return
+    }
+
+    back, err := qe.SearchCursor(second.Prev)
+    if err != nil {
+        return
+    }
+    if back.Offset != 0 || len(back.Results) != 1 || back.Results[0].Docid != first.Results[0].Docid {
+        err = fmt.Errorf("previous page %+v, expected the first page", back)
+        return
+    }
+
+    all, err := qe.Search("pizza", 0, 10)
+    if err != nil {
+        return
+    }
+    if len(all.Results) != 2 || !all.TotalExact || all.Total != 2 || all.Next != "" {
+        err = fmt.Errorf("single page %+v, expected exactly 2 results", all)
+        return
+    }
+
+    past, err := qe.Search("pizza", 5, 10)
+    if err != nil {
+        return
+    }
+    if len(past.Results) != 0 || past.TotalExact || past.Total != 2 || past.Prev == "" {
+        err = fmt.Errorf("page past the end %+v, expected no results", past)
+        return
+    }
+
+    for _, bad := range [][2]int{{-1, 10}, {0, 0}} {
+        if _, e := qe.Search("pizza", bad[0], bad[1]); e == nil {
+            err = fmt.Errorf("Search offset %v limit %v did not fail", bad[0], bad[1])
+            return
+        }
+    }
+    return
+}
+
+func testSearchCursorErrors() (err error) {
+
+    defer catch(&err)
+
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    repositoryPath, err := buildQueryTestRepository(dir)
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+    if err = os.Mkdir(filepath.Join(dir, "other"), 0755); err != nil {
+        return
+    }
+    otherPath, err := buildQueryTestRepository(filepath.Join(dir, "other"))
+    if err != nil {
+        err = fmt.Errorf("failed to build repository: %v", err)
+        return
+    }
+
+    var qe QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(qe)
+
+    if err = qe.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer qe.Close()
+
+    for _, token := range []string{"", "not a cursor", "e30"} {
+        if _, e := qe.SearchCursor(token); !errors.Is(e, ErrInvalidCursor) {
+            err = fmt.Errorf("SearchCursor(%q) returned %v, expected ErrInvalidCursor", token, e)
+            return
+        }
+    }
+
+    page, err := qe.Search("pizza", 0, 1)
+    if err != nil {
+        return
+    }
+
+    // a token rewritten to a larger page keeps its old signature
+    parts := strings.SplitN(page.Next, ".", 2)
+    payload, e := base64.RawURLEncoding.DecodeString(parts[0])
+    if e != nil || len(parts) != 2 {
+        err = fmt.Errorf("unexpected token %q", page.Next)
+        return
+    }
+    var fields map[string]interface{}
+    if err = json.Unmarshal(payload, &fields); err != nil {
+        return
+    }
+    fields["l"] = 1000000
+    payload, _ = json.Marshal(fields)
+    forged := base64.RawURLEncoding.EncodeToString(payload) + "." + parts[1]
+    if _, e := qe.SearchCursor(forged); !errors.Is(e, ErrInvalidCursor) {
+        err = fmt.Errorf("SearchCursor of a rewritten token returned %v, expected ErrInvalidCursor", e)
+        return
+    }
+
+    if err = qe.AddIndex(otherPath); err != nil {
+        return
+    }
+    if _, e := qe.SearchCursor(page.Next); !errors.Is(e, ErrStaleCursor) {
+        err = fmt.Errorf("SearchCursor after AddIndex returned %v, expected ErrStaleCursor", e)
+        return
+    }
+
+    // a document added and another deleted leave the count as it was
+    var before QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(before)
+    if err = before.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    page, err = before.Search("pizza", 0, 1)
+    before.Close()
+    if err != nil {
+        return
+    }
+
+    var env IndexEnvironment = NewIndexEnvironment()
+    defer DeleteWrapped_IndexEnvironment(env)
+    if err = env.Open(repositoryPath); err != nil {
+        return
+    }
+    if _, err = env.AddDocument("<docno>q4</docno><text>pizza delivery</text>", "trectext", nil); err != nil {
+        env.Close()
+        return
+    }
+    if err = env.DeleteDocument(1); err != nil {
+        env.Close()
+        return
+    }
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    var after QueryEnvironment = NewQueryEnvironment()
+    defer DeleteQueryEnvironment(after)
+    if err = after.AddIndex(repositoryPath); err != nil {
+        return
+    }
+    defer after.Close()
+    if _, e := after.SearchCursor(page.Next); !errors.Is(e, ErrStaleCursor) {
+        err = fmt.Errorf("SearchCursor after an add and a delete returned %v, expected ErrStaleCursor", e)
+        return
+    }
+    return
+}
@@ -0,0 +1,289 @@
+package server
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+//
+// IndexOptions of an IndexHandler, a zero value uses the defaults.
+// Timeout bounds the wait for a write, which is still made once queued.
+// MaxBatch is the most documents of a request, and MaxBodyBytes the
+// largest request body.
+//
+type IndexOptions struct {
+    Timeout time.Duration
+    MaxBatch int
+    MaxBodyBytes int64
+}
+
+const (
+    DefaultMaxBatch = 1000
+    DefaultMaxBodyBytes = 32 << 20
+)
+
+//
+// IndexHandler is an http.Handler writing to an IndexEnvironment:
+//
+//   POST   /index/documents          a JSON IndexDocument, a JSON array of
+//                                    them, or NDJSON with one per line
+//   DELETE /index/documents/{docid}
+//   DELETE /index/documents?docno=q1
+//   GET    /index/status
+//
+// Every request is run by an indri_go.IndexWriter, in the order they reach
+// it. The documents written are only seen by QueryEnvironments opened
+// after the writer commits them.
+//
+type IndexHandler struct {
+    writer *indri_go.IndexWriter
+    opts IndexOptions
+    mux *http.ServeMux
+}
+
+//
+// NewIndexHandler returns an IndexHandler writing with writer. Closing the
+// writer is left to the caller, requests are then answered as unavailable.
+//
+func NewIndexHandler(writer *indri_go.IndexWriter, opts IndexOptions) *IndexHandler {
+    if opts.Timeout <= 0 {
+        opts.Timeout = DefaultTimeout
+    }
+    if opts.MaxBatch <= 0 {
+        opts.MaxBatch = DefaultMaxBatch
+    }
+    if opts.MaxBodyBytes <= 0 {
+        opts.MaxBodyBytes = DefaultMaxBodyBytes
+    }
+    h := &IndexHandler{writer: writer, opts: opts, mux: http.NewServeMux()}
+    h.mux.HandleFunc("/index/documents", handle(h.documents, http.MethodPost, http.MethodDelete))
+    h.mux.HandleFunc("/index/documents/", handle(h.deleteDocument, http.MethodDelete))
+    h.mux.HandleFunc("/index/status", handle(h.status, http.MethodGet))
+    h.mux.HandleFunc("/", handle(noEndpoint, http.MethodGet))
+    return h
+}
+
+func (h *IndexHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    h.mux.ServeHTTP(w, r)
+}
+
+//
+// run runs f with the environment of the writer and returns its result.
+//
+func (h *IndexHandler) run(r *http.Request, f func(env indri_go.IndexEnvironment) (interface{}, error)) (interface{}, error) {
+    ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+    defer cancel()
+    var v interface{}
+    _, err := h.writer.Do(func(env indri_go.IndexEnvironment) (indri_go.IndexResult, error) {
+        var err error
+        v, err = f(env)
+        return indri_go.IndexResult{}, err
+    }).WaitContext(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return v, nil
+}
+
+//
+//  endpoints
+//
+
+//
+// IndexDocument is a document to index. FileClass is the indri file class
+// parsing Text, such as trectext or html, Metadata is stored with it.
+//
+type IndexDocument struct {
+    Text string `json:"text"`
+    FileClass string `json:"fileClass"`
+    Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// IndexResponse lists the ids of the documents indexed, in request order
+type IndexResponse struct {
+    Docids []int `json:"docids"`
+}
+
+// DeleteResponse lists the ids of the documents deleted
+type DeleteResponse struct {
+    Deleted []int `json:"deleted"`
+}
+
+//
+// StatusResponse counts the documents indexed and seen since the last
+// commit, and the operations of the writer.
+//
+type StatusResponse struct {
+    DocumentsIndexed int `json:"documentsIndexed"`
+    DocumentsSeen int `json:"documentsSeen"`
+    Queued int `json:"queued"`
+    Operations int64 `json:"operations"`
+    Errors int64 `json:"errors"`
+    Commits int64 `json:"commits"`
+}
+
+func (h *IndexHandler) documents(r *http.Request) (interface{}, error) {
+    if r.Method == http.MethodDelete {
+        return h.deleteDocno(r)
+    }
+    documents, err := h.decodeDocuments(r)
+    if err != nil {
+        return nil, err
+    }
+    return h.run(r, func(env indri_go.IndexEnvironment) (interface{}, error) {
+        response := IndexResponse{Docids: make([]int, 0, len(documents))}
+        for i, doc := range documents {
+            docid, err := env.AddDocument(doc.Text, doc.FileClass, doc.Metadata)
+            if err != nil {
+                return nil, batchError(err, i, len(documents))
+            }
+            response.Docids = append(response.Docids, docid)
+        }
+        return response, nil
+    })
+}
+
+//
+// batchError tells which document of a batch err failed on, the ones
+// before it stay indexed.
+//
+func batchError(err error, i, n int) error {
+    if n == 1 {
+        return err
+    }
+    e := *errorOf(err)
+    e.Message = fmt.Sprintf("document %v of %v: %v, the %v before it were indexed", i+1, n, e.Message, i)
+    return &e
+}
+
+//
+// decodeDocuments reads and checks every document of the request body
+// before any is written. Bodies of type application/x-ndjson hold a
+// document per line, others a document or an array of documents.
+//
+func (h *IndexHandler) decodeDocuments(r *http.Request) (documents []IndexDocument, err error) {
+    body := http.MaxBytesReader(nil, r.Body, h.opts.MaxBodyBytes)
+    contentType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+    switch contentType {
+    case "application/x-ndjson", "application/jsonl":
+        scanner := bufio.NewScanner(body)
+        scanner.Buffer(nil, int(h.opts.MaxBodyBytes))
+        for line := 1; scanner.Scan(); line++ {
+            if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+                continue
+            }
+            var doc IndexDocument
+            if err = json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+                return nil, badRequest("line %v: %v", line, err)
+            }
+            documents = append(documents, doc)
+            if len(documents) > h.opts.MaxBatch {
+                return nil, badRequest("more than %v documents", h.opts.MaxBatch)
+            }
+        }
+        if err = scanner.Err(); err != nil {
+            return nil, badRequest("reading body: %v", err)
+        }
+    default:
+        var b []byte
+        if b, err = ioutil.ReadAll(body); err != nil {
+            return nil, badRequest("reading body: %v", err)
+        }
+        b = bytes.TrimSpace(b)
+        if len(b) > 0 && b[0] == '[' {
+            err = json.Unmarshal(b, &documents)
+        } else {
+            documents = make([]IndexDocument, 1)
+            err = json.Unmarshal(b, &documents[0])
+        }
+        if err != nil {
+            return nil, badRequest("invalid JSON: %v", err)
+        }
+        if len(documents) > h.opts.MaxBatch {
+            return nil, badRequest("more than %v documents", h.opts.MaxBatch)
+        }
+    }
+    if len(documents) == 0 {
+        return nil, badRequest("no documents")
+    }
+    for i, doc := range documents {
+        if doc.Text == "" || doc.FileClass == "" {
+            return nil, badRequest("document %v has no text or fileClass", i+1)
+        }
+    }
+    return
+}
+
+//
+// deleteDocno deletes the documents with the docno parameter, which is not
+// found once no document with it is left, even if deleted ones had it.
+//
+func (h *IndexHandler) deleteDocno(r *http.Request) (interface{}, error) {
+    docno := r.URL.Query().Get("docno")
+    if docno == "" {
+        return nil, badRequest("docno is required")
+    }
+    return h.run(r, func(env indri_go.IndexEnvironment) (interface{}, error) {
+        deleted, err := env.DeleteByDocno(docno)
+        if err != nil {
+            return nil, err
+        }
+        if len(deleted) == 0 {
+            return nil, notFound("no document %q", docno)
+        }
+        return DeleteResponse{Deleted: deleted}, nil
+    })
+}
+
+//
+// deleteDocument deletes the document with the id in the path, which is not
+// found when the repository never had it or it is already deleted.
+//
+func (h *IndexHandler) deleteDocument(r *http.Request) (interface{}, error) {
+    s := strings.TrimPrefix(r.URL.Path, "/index/documents/")
+    docid, err := strconv.Atoi(s)
+    if err != nil || docid < 1 {
+        return nil, badRequest("document id %q is not a positive integer", s)
+    }
+    return h.run(r, func(env indri_go.IndexEnvironment) (interface{}, error) {
+        exists, err := env.DocumentExists(docid)
+        if err != nil {
+            return nil, err
+        }
+        if !exists {
+            return nil, notFound("no document %v", docid)
+        }
+        if err := env.DeleteDocument(docid); err != nil {
+            return nil, err
+        }
+        return DeleteResponse{Deleted: []int{docid}}, nil
+    })
+}
+
+func (h *IndexHandler) status(r *http.Request) (interface{}, error) {
+    return h.run(r, func(env indri_go.IndexEnvironment) (response interface{}, err error) {
+        var status StatusResponse
+        if status.DocumentsIndexed, err = env.DocumentsIndexed(); err != nil {
+            return
+        }
+        if status.DocumentsSeen, err = env.DocumentsSeen(); err != nil {
+            return
+        }
+        stats := h.writer.Stats()
+        status.Queued = stats.Queued
+        status.Operations = stats.Operations
+        status.Errors = stats.Errors
+        status.Commits = stats.Commits
+        return status, nil
+    })
+}
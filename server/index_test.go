@@ -0,0 +1,225 @@
+package server
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+/**
+ * Test documents are added and deleted through the IndexHandler.
+**/
+func TestIndexHandler(t *testing.T) {
+    err := testIndexHandler()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test concurrent writes are all made by the writer.
+**/
+func TestIndexHandlerConcurrent(t *testing.T) {
+    err := testIndexHandlerConcurrent()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+// send serves a request and decodes the JSON answered into v
+func send(h http.Handler, method, target, contentType, body string, v interface{}) (status int, err error) {
+    r := httptest.NewRequest(method, target, strings.NewReader(body))
+    if contentType != "" {
+        r.Header.Set("Content-Type", contentType)
+    }
+    w := httptest.NewRecorder()
+    h.ServeHTTP(w, r)
+    if err = json.Unmarshal(w.Body.Bytes(), v); err != nil {
+        err = fmt.Errorf("%v %v answered %q: %v", method, target, w.Body.String(), err)
+    }
+    return w.Code, err
+}
+
+// newTestIndexHandler creates a repository under dir and an IndexHandler writing it
+func newTestIndexHandler(dir string) (h *IndexHandler, writer *indri_go.IndexWriter, err error) {
+    cfg := indri_go.NewIndexConfig()
+    cfg.Index = filepath.Join(dir, "index-w")
+    cfg.Memory = 64 * 1024 * 1024
+    if writer, err = indri_go.NewIndexWriter(cfg, indri_go.IndexWriterOptions{}); err != nil {
+        return
+    }
+    h = NewIndexHandler(writer, IndexOptions{MaxBatch: 5})
+    return
+}
+
+func testIndexHandler() (err error) {
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    h, writer, err := newTestIndexHandler(dir)
+    if err != nil {
+        return
+    }
+    defer writer.Close()
+
+    var added IndexResponse
+    one := `{"text": "<DOC><DOCNO>w1</DOCNO><TEXT>pizza</TEXT></DOC>", "fileClass": "trectext", "metadata": {"title": "one"}}`
+    if _, err = send(h, http.MethodPost, "/index/documents", "application/json", one, &added); err != nil {
+        return
+    }
+    if len(added.Docids) != 1 {
+        return fmt.Errorf("single document answered %+v", added)
+    }
+
+    batch := `[{"text": "<DOC><DOCNO>w2</DOCNO><TEXT>pasta</TEXT></DOC>", "fileClass": "trectext"},
+               {"text": "<DOC><DOCNO>w3</DOCNO><TEXT>salad</TEXT></DOC>", "fileClass": "trectext"}]`
+    if _, err = send(h, http.MethodPost, "/index/documents", "application/json", batch, &added); err != nil {
+        return
+    }
+    if len(added.Docids) != 2 {
+        return fmt.Errorf("array of 2 documents answered %+v", added)
+    }
+
+    ndjson := "{\"text\": \"<DOC><DOCNO>w4</DOCNO><TEXT>soup</TEXT></DOC>\", \"fileClass\": \"trectext\"}\n\n" +
+        "{\"text\": \"<DOC><DOCNO>w4</DOCNO><TEXT>bread</TEXT></DOC>\", \"fileClass\": \"trectext\"}\n"
+    if _, err = send(h, http.MethodPost, "/index/documents", "application/x-ndjson", ndjson, &added); err != nil {
+        return
+    }
+    if len(added.Docids) != 2 {
+        return fmt.Errorf("NDJSON of 2 documents answered %+v", added)
+    }
+
+    var status StatusResponse
+    if _, err = send(h, http.MethodGet, "/index/status", "", "", &status); err != nil {
+        return
+    }
+    if status.DocumentsIndexed != 5 || status.DocumentsSeen != 5 || status.Operations != 3 {
+        return fmt.Errorf("status answered %+v, expected 5 documents in 3 operations", status)
+    }
+
+    var deleted DeleteResponse
+    if _, err = send(h, http.MethodDelete, "/index/documents?docno=w4", "", "", &deleted); err != nil {
+        return
+    }
+    if len(deleted.Deleted) != 2 {
+        return fmt.Errorf("delete of docno w4 answered %+v, expected 2 documents", deleted)
+    }
+    var again struct {
+        Error Error `json:"error"`
+    }
+    if status, e := send(h, http.MethodDelete, "/index/documents?docno=w4", "", "", &again); e != nil || status != http.StatusNotFound || again.Error.Code != CodeNotFound {
+        return fmt.Errorf("second delete of docno w4 answered %v %+v, %v, expected %v", status, again.Error, e, http.StatusNotFound)
+    }
+    target := fmt.Sprintf("/index/documents/%v", added.Docids[0])
+    if _, err = send(h, http.MethodDelete, target, "", "", &deleted); err != nil {
+        return
+    }
+    if len(deleted.Deleted) != 1 || deleted.Deleted[0] != added.Docids[0] {
+        return fmt.Errorf("delete of %v answered %+v", target, deleted)
+    }
+
+    cases := []struct {
+        method string
+        target string
+        contentType string
+        body string
+        status int
+        code string
+    }{
+        {http.MethodPost, "/index/documents", "application/json", "", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/json", "[]", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/json", "{", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/json", `{"text": "x"}`, http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/x-ndjson", "{}\nnot json\n", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/json", "[" + strings.Repeat(`{"text": "x", "fileClass": "txt"},`, 5) + `{"text": "x", "fileClass": "txt"}]`, http.StatusBadRequest, CodeBadRequest},
+        {http.MethodPost, "/index/documents", "application/x-ndjson", strings.Repeat(`{"text": "x", "fileClass": "txt"}`+"\n", 6), http.StatusBadRequest, CodeBadRequest},
+        {http.MethodDelete, "/index/documents", "", "", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodDelete, "/index/documents?docno=nothere", "", "", http.StatusNotFound, CodeNotFound},
+        {http.MethodDelete, "/index/documents/zero", "", "", http.StatusBadRequest, CodeBadRequest},
+        {http.MethodDelete, "/index/documents/1000000", "", "", http.StatusNotFound, CodeNotFound},
+        {http.MethodDelete, target, "", "", http.StatusNotFound, CodeNotFound},
+        {http.MethodPut, "/index/documents", "", "", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+    }
+    for _, c := range cases {
+        var body struct {
+            Error Error `json:"error"`
+        }
+        status, e := send(h, c.method, c.target, c.contentType, c.body, &body)
+        if e != nil {
+            return e
+        }
+        if status != c.status || body.Error.Code != c.code {
+            return fmt.Errorf("%v %v %q answered %v %+v, expected %v %v", c.method, c.target, c.body, status, body.Error, c.status, c.code)
+        }
+    }
+
+    writer.Close()
+    var body struct {
+        Error Error `json:"error"`
+    }
+    if status, e := send(h, http.MethodGet, "/index/status", "", "", &body); e != nil || status != http.StatusServiceUnavailable {
+        return fmt.Errorf("closed handler answered %v %+v, %v", status, body.Error, e)
+    }
+    return
+}
+
+func testIndexHandlerConcurrent() (err error) {
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    h, writer, err := newTestIndexHandler(dir)
+    if err != nil {
+        return
+    }
+    defer writer.Close()
+
+    const n = 20
+    errs := make(chan error, n)
+    var wg sync.WaitGroup
+    for i := 0; i < n; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            doc := fmt.Sprintf(`{"text": "<DOC><DOCNO>c%v</DOCNO><TEXT>pizza %v</TEXT></DOC>", "fileClass": "trectext"}`, i, i)
+            var added IndexResponse
+            if status, e := send(h, http.MethodPost, "/index/documents", "application/json", doc, &added); e != nil || status != http.StatusOK {
+                errs <- fmt.Errorf("document %v answered %v: %v", i, status, e)
+            }
+        }(i)
+    }
+    wg.Wait()
+    close(errs)
+    for e := range errs {
+        return e
+    }
+
+    var status StatusResponse
+    if _, err = send(h, http.MethodGet, "/index/status", "", "", &status); err != nil {
+        return
+    }
+    if status.DocumentsIndexed != n {
+        return fmt.Errorf("status answered %+v, expected %v documents", status, n)
+    }
+    return
+}
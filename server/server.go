@@ -0,0 +1,524 @@
+//
+// Package server serves the indexes of an indri_go QueryPool as a JSON http
+// API:
+//
+//   GET /search?q=pizza&offset=0&limit=10&fields=docno,title
+//   GET /search?cursor=<next or prev of a search>&fields=docno
+//   GET /document/{docid}
+//   GET /metadata/{field}/{value}
+//   GET /stats
+//
+// A cursor holds its own offset and limit, passing either with it is a bad
+// request. Cursors are signed, so a client cannot change them to get past
+// MaxLimit, and are only valid until the server restarts. Deleted documents are not found. Stats are computed once for
+// each generation of the indexes, see indri_go.QueryEnvironment.Generation.
+//
+// Every error is answered with its http status and a body of
+//
+//   {"error": {"status": 400, "code": "LEMUR_PARSE_ERROR", "message": "..."}}
+//
+// where code is the LemurErrorType of a lemur exception, or one of the
+// codes below for other errors. IndexHandler serves writes to a repository
+// the same way.
+//
+package server
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/url"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+//
+// Options of a Handler, a zero value uses the defaults. Timeout bounds
+// each request, including the wait for an idle QueryEnvironment.
+//
+type Options struct {
+    Timeout time.Duration
+    DefaultLimit int
+    MaxLimit int
+}
+
+const (
+    DefaultTimeout = 30 * time.Second
+    DefaultLimit = 10
+    DefaultMaxLimit = 1000
+)
+
+// error codes of errors that are not lemur exceptions
+const (
+    CodeBadRequest = "bad_request"
+    CodeNotFound = "not_found"
+    CodeMethodNotAllowed = "method_not_allowed"
+    CodeInvalidCursor = "invalid_cursor"
+    CodeStaleCursor = "stale_cursor"
+    CodeTimeout = "timeout"
+    CodeUnavailable = "unavailable"
+    CodeInternal = "internal"
+)
+
+//
+// Handler is an http.Handler running requests on the QueryEnvironments of
+// a QueryPool. Closing the pool is left to the caller.
+//
+type Handler struct {
+    pool *indri_go.QueryPool
+    opts Options
+    mux *http.ServeMux
+
+    // stats of the generation and document count in statsKey
+    statsMu sync.Mutex
+    statsKey [2]uint64
+    statsResponse *StatsResponse
+}
+
+// NewHandler returns a Handler serving pool
+func NewHandler(pool *indri_go.QueryPool, opts Options) *Handler {
+    if opts.Timeout <= 0 {
+        opts.Timeout = DefaultTimeout
+    }
+    if opts.MaxLimit <= 0 {
+        opts.MaxLimit = DefaultMaxLimit
+    }
+    if opts.DefaultLimit <= 0 {
+        opts.DefaultLimit = DefaultLimit
+    }
+    if opts.DefaultLimit > opts.MaxLimit {
+        opts.DefaultLimit = opts.MaxLimit
+    }
+    h := &Handler{pool: pool, opts: opts, mux: http.NewServeMux()}
+    h.mux.HandleFunc("/search", handle(h.search, http.MethodGet))
+    h.mux.HandleFunc("/document/", handle(h.document, http.MethodGet))
+    h.mux.HandleFunc("/metadata/", handle(h.metadata, http.MethodGet))
+    h.mux.HandleFunc("/stats", handle(h.stats, http.MethodGet))
+    h.mux.HandleFunc("/", handle(noEndpoint, http.MethodGet))
+    return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    h.mux.ServeHTTP(w, r)
+}
+
+//
+// Error is the body of an error response.
+//
+type Error struct {
+    Status int `json:"status"`
+    Code string `json:"code"`
+    Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+    return e.Message
+}
+
+func badRequest(format string, a ...interface{}) *Error {
+    return &Error{Status: http.StatusBadRequest, Code: CodeBadRequest, Message: fmt.Sprintf(format, a...)}
+}
+
+func notFound(format string, a ...interface{}) *Error {
+    return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: fmt.Sprintf(format, a...)}
+}
+
+//
+// errorOf maps err to the Error answered. Lemur exceptions caused by the
+// request, parse errors and bad parameters, are client errors.
+//
+func errorOf(err error) *Error {
+    var e *Error
+    var le *indri_go.LemurError
+    switch {
+    case errors.As(err, &e):
+        return e
+    case errors.Is(err, context.DeadlineExceeded):
+        return &Error{Status: http.StatusGatewayTimeout, Code: CodeTimeout, Message: "request timed out"}
+    case errors.Is(err, context.Canceled):
+        return &Error{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Message: "request canceled"}
+    case errors.Is(err, indri_go.ErrClosed):
+        return &Error{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Message: "server is shutting down"}
+    case errors.Is(err, indri_go.ErrInvalidCursor):
+        return &Error{Status: http.StatusBadRequest, Code: CodeInvalidCursor, Message: err.Error()}
+    case errors.Is(err, indri_go.ErrStaleCursor):
+        return &Error{Status: http.StatusGone, Code: CodeStaleCursor, Message: "the index changed since the cursor was made, search again"}
+    case errors.As(err, &le):
+        status := http.StatusInternalServerError
+        switch {
+        case errors.Is(le, indri_go.ErrParse), errors.Is(le, indri_go.ErrBadParameter):
+            status = http.StatusBadRequest
+        case errors.Is(le, indri_go.ErrRepositoryLocked):
+            status = http.StatusServiceUnavailable
+        }
+        return &Error{Status: status, Code: le.Code, Message: le.Message}
+    }
+    return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Message: err.Error()}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", "application/json; charset=utf-8")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+    e := errorOf(err)
+    writeJSON(w, e.Status, struct {
+        Error *Error `json:"error"`
+    }{e})
+}
+
+func noEndpoint(r *http.Request) (interface{}, error) {
+    return nil, notFound("no such endpoint %v", r.URL.Path)
+}
+
+//
+// handle adapts f to an http.HandlerFunc answering JSON to the methods
+// given, and to HEAD when GET is given.
+//
+func handle(f func(r *http.Request) (interface{}, error), methods ...string) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        allowed := false
+        for _, m := range methods {
+            if r.Method == m || r.Method == http.MethodHead && m == http.MethodGet {
+                allowed = true
+            }
+        }
+        if !allowed {
+            w.Header().Set("Allow", strings.Join(methods, ", "))
+            writeError(w, &Error{Status: http.StatusMethodNotAllowed, Code: CodeMethodNotAllowed, Message: r.Method + " is not allowed"})
+            return
+        }
+        v, err := f(r)
+        if err != nil {
+            writeError(w, err)
+            return
+        }
+        writeJSON(w, http.StatusOK, v)
+    }
+}
+
+//
+// run calls f with a QueryEnvironment of the pool within the timeout. A
+// call into indri cannot be interrupted, on timeout f keeps running and
+// releases the environment when it returns.
+//
+func (h *Handler) run(r *http.Request, f func(env indri_go.QueryEnvironment) (interface{}, error)) (interface{}, error) {
+    ctx, cancel := context.WithTimeout(r.Context(), h.opts.Timeout)
+    defer cancel()
+    env, err := h.pool.Acquire(ctx)
+    if err != nil {
+        return nil, err
+    }
+    type result struct {
+        v interface{}
+        err error
+    }
+    c := make(chan result, 1)
+    go func() {
+        v, err := f(env)
+        h.pool.Release(env, err)
+        c <- result{v, err}
+    }()
+    select {
+    case res := <-c:
+        return res.v, res.err
+    case <-ctx.Done():
+        return nil, ctx.Err()
+    }
+}
+
+//
+//  endpoints
+//
+
+// SearchResult is a ranked document, Fields holds the metadata requested
+type SearchResult struct {
+    Docid int `json:"docid"`
+    Score float64 `json:"score"`
+    Begin int `json:"begin"`
+    End int `json:"end"`
+    Fields map[string]string `json:"fields,omitempty"`
+}
+
+//
+// SearchResponse is a page of /search. Next and Prev are the cursors of
+// the neighbouring pages, fields is not part of a cursor and is passed
+// again with it.
+//
+type SearchResponse struct {
+    Offset int `json:"offset"`
+    Limit int `json:"limit"`
+    Total int `json:"total"`
+    TotalExact bool `json:"totalExact"`
+    Next string `json:"next,omitempty"`
+    Prev string `json:"prev,omitempty"`
+    Results []SearchResult `json:"results"`
+}
+
+// intParam returns the integer query parameter name, or def when absent
+func intParam(r *http.Request, name string, def int) (int, error) {
+    s := r.URL.Query().Get(name)
+    if s == "" {
+        return def, nil
+    }
+    n, err := strconv.Atoi(s)
+    if err != nil {
+        return 0, badRequest("%v %q is not an integer", name, s)
+    }
+    return n, nil
+}
+
+//
+// fieldsParam returns the metadata fields of a comma separated fields
+// parameter, docno when absent.
+//
+func fieldsParam(r *http.Request) []string {
+    v, ok := r.URL.Query()["fields"]
+    if !ok {
+        return []string{"docno"}
+    }
+    var fields []string
+    for _, s := range v {
+        for _, f := range strings.Split(s, ",") {
+            if f = strings.TrimSpace(f); f != "" {
+                fields = append(fields, f)
+            }
+        }
+    }
+    return fields
+}
+
+func (h *Handler) search(r *http.Request) (interface{}, error) {
+    q := r.URL.Query()
+    text, cursor := q.Get("q"), q.Get("cursor")
+    if (text == "") == (cursor == "") {
+        return nil, badRequest("exactly one of q and cursor is required")
+    }
+    if _, ok := q["offset"]; ok && cursor != "" {
+        return nil, badRequest("offset cannot be given with a cursor")
+    }
+    if _, ok := q["limit"]; ok && cursor != "" {
+        return nil, badRequest("limit cannot be given with a cursor")
+    }
+    offset, err := intParam(r, "offset", 0)
+    if err != nil {
+        return nil, err
+    }
+    limit, err := intParam(r, "limit", h.opts.DefaultLimit)
+    if err != nil {
+        return nil, err
+    }
+    if offset < 0 {
+        return nil, badRequest("offset %v is negative", offset)
+    }
+    if limit < 1 || limit > h.opts.MaxLimit {
+        return nil, badRequest("limit %v is not between 1 and %v", limit, h.opts.MaxLimit)
+    }
+    fields := fieldsParam(r)
+
+    return h.run(r, func(env indri_go.QueryEnvironment) (interface{}, error) {
+        var page indri_go.SearchPage
+        var err error
+        if cursor != "" {
+            page, err = env.SearchCursor(cursor)
+        } else {
+            page, err = env.Search(text, offset, limit)
+        }
+        if err != nil {
+            return nil, err
+        }
+        response := SearchResponse{
+            Offset: page.Offset,
+            Limit: page.Limit,
+            Total: page.Total,
+            TotalExact: page.TotalExact,
+            Next: page.Next,
+            Prev: page.Prev,
+            Results: make([]SearchResult, len(page.Results)),
+        }
+        docids := make([]int, len(page.Results))
+        for i, result := range page.Results {
+            docids[i] = result.Docid
+            response.Results[i] = SearchResult{Docid: result.Docid, Score: result.Score, Begin: result.Begin, End: result.End}
+        }
+        if len(docids) == 0 {
+            return response, nil
+        }
+        for _, field := range fields {
+            values, err := env.DocumentMetadatadocids(docids, field)
+            if err != nil {
+                return nil, err
+            }
+            for i := range response.Results {
+                if i >= len(values) || values[i] == "" {
+                    continue
+                }
+                if response.Results[i].Fields == nil {
+                    response.Results[i].Fields = make(map[string]string)
+                }
+                response.Results[i].Fields[field] = values[i]
+            }
+        }
+        return response, nil
+    })
+}
+
+// DocumentResponse is the stored text and metadata of a document
+type DocumentResponse struct {
+    Docid int `json:"docid"`
+    Text string `json:"text"`
+    Metadata map[string]string `json:"metadata"`
+}
+
+//
+// document answers /document/{docid}, ids never indexed and deleted
+// documents are not found.
+//
+func (h *Handler) document(r *http.Request) (interface{}, error) {
+    s := strings.TrimPrefix(r.URL.Path, "/document/")
+    docid, err := strconv.Atoi(s)
+    if err != nil || docid < 1 {
+        return nil, badRequest("document id %q is not a positive integer", s)
+    }
+    return h.run(r, func(env indri_go.QueryEnvironment) (interface{}, error) {
+        exists, err := env.DocumentExists(docid)
+        if err != nil {
+            return nil, err
+        }
+        if !exists {
+            return nil, notFound("no document %v", docid)
+        }
+        documents, err := env.Documentsdocids([]int{docid})
+        if err != nil {
+            return nil, err
+        }
+        if len(documents) == 0 {
+            return nil, notFound("no document %v", docid)
+        }
+        response := DocumentResponse{Docid: docid, Text: documents[0].Text, Metadata: make(map[string]string)}
+        for k, v := range documents[0].Metadata {
+            response.Metadata[k] = string(v)
+        }
+        return response, nil
+    })
+}
+
+// MetadataResponse lists the documents with a metadata value
+type MetadataResponse struct {
+    Field string `json:"field"`
+    Value string `json:"value"`
+    Docids []int `json:"docids"`
+}
+
+//
+// metadata answers /metadata/{field}/{value}, value may hold escaped
+// slashes. Deleted documents keep their metadata until the repository is
+// compacted, so they are left out.
+//
+func (h *Handler) metadata(r *http.Request) (interface{}, error) {
+    parts := strings.SplitN(strings.TrimPrefix(r.URL.EscapedPath(), "/metadata/"), "/", 2)
+    if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+        return nil, badRequest("expected /metadata/{field}/{value}")
+    }
+    field, err := url.PathUnescape(parts[0])
+    if err != nil {
+        return nil, badRequest("invalid field: %v", err)
+    }
+    value, err := url.PathUnescape(parts[1])
+    if err != nil {
+        return nil, badRequest("invalid value: %v", err)
+    }
+    return h.run(r, func(env indri_go.QueryEnvironment) (interface{}, error) {
+        found, err := env.DocumentIDsFromMetadata(field, []string{value})
+        if err != nil {
+            return nil, err
+        }
+        docids := []int{}
+        for _, docid := range found {
+            exists, err := env.DocumentExists(docid)
+            if err != nil {
+                return nil, err
+            }
+            if exists {
+                docids = append(docids, docid)
+            }
+        }
+        return MetadataResponse{Field: field, Value: value, Docids: docids}, nil
+    })
+}
+
+// FieldStats counts the extents of an indexed field and the terms in them
+type FieldStats struct {
+    Name string `json:"name"`
+    Extents int64 `json:"extents"`
+    Terms int64 `json:"terms"`
+}
+
+// LengthBucket counts the documents with min to max terms
+type LengthBucket struct {
+    Min int `json:"min"`
+    Max int `json:"max"`
+    Documents int64 `json:"documents"`
+}
+
+// StatsResponse is the indri_go.IndexStatistics of the pool indexes
+type StatsResponse struct {
+    Documents int64 `json:"documents"`
+    Terms int64 `json:"terms"`
+    UniqueTerms int64 `json:"uniqueTerms"`
+    AverageDocumentLength float64 `json:"averageDocumentLength"`
+    Fields []FieldStats `json:"fields"`
+    DocumentLengths []LengthBucket `json:"documentLengths,omitempty"`
+    MetadataFields []string `json:"metadataFields"`
+}
+
+//
+// stats answers /stats. Collecting them reads every extent of every field,
+// so they are kept until an environment of the pool reports another
+// generation or document count.
+//
+func (h *Handler) stats(r *http.Request) (interface{}, error) {
+    return h.run(r, func(env indri_go.QueryEnvironment) (interface{}, error) {
+        documents, err := env.DocumentCount()
+        if err != nil {
+            return nil, err
+        }
+        key := [2]uint64{env.Generation(), uint64(documents)}
+        h.statsMu.Lock()
+        defer h.statsMu.Unlock()
+        if h.statsResponse != nil && h.statsKey == key {
+            return *h.statsResponse, nil
+        }
+        stats, err := indri_go.IndexStats(env)
+        if err != nil {
+            return nil, err
+        }
+        response := StatsResponse{
+            Documents: stats.Documents,
+            Terms: stats.Terms,
+            UniqueTerms: stats.UniqueTerms,
+            AverageDocumentLength: stats.AverageDocumentLength,
+            Fields: []FieldStats{},
+            MetadataFields: stats.MetadataFields,
+        }
+        for _, f := range stats.Fields {
+            response.Fields = append(response.Fields, FieldStats{Name: f.Name, Extents: f.Extents, Terms: f.Terms})
+        }
+        for _, b := range stats.DocumentLengths {
+            response.DocumentLengths = append(response.DocumentLengths, LengthBucket{Min: b.Min, Max: b.Max, Documents: b.Documents})
+        }
+        if response.MetadataFields == nil {
+            response.MetadataFields = []string{}
+        }
+        h.statsKey, h.statsResponse = key, &response
+        return response, nil
+    })
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+    "time"
+
+    indri_go "github.com/dms3-fs/go-idx-indri"
+)
+
+/**
+ * Test the endpoints answer JSON for a small repository.
+**/
+func TestHandler(t *testing.T) {
+    err := testHandler()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+/**
+ * Test errors are answered with their status and code.
+**/
+func TestHandlerErrors(t *testing.T) {
+    err := testHandlerErrors()
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+//
+// start of test logic implimentations
+//
+
+var serverTestDocuments = []string{
+    "<docno>q1</docno><text>pizza and chinese food at the burlington mall</text>",
+    "<docno>q2</docno><text>the food court serves pizza</text>",
+    "<docno>q3</docno><text>parking is free on weekends</text>",
+}
+
+//
+// newTestHandler builds a repository of serverTestDocuments under dir, and
+// a fourth document it deletes, and returns a Handler serving it with its
+// pool.
+//
+func newTestHandler(dir string) (h *Handler, pool *indri_go.QueryPool, err error) {
+    repositoryPath := filepath.Join(dir, "index-s")
+
+    env := indri_go.NewIndexEnvironment()
+    defer indri_go.DeleteWrapped_IndexEnvironment(env)
+    if err = env.SetStoreDocs(true); err != nil {
+        return
+    }
+    if err = env.Create(repositoryPath); err != nil {
+        return
+    }
+    for _, doc := range serverTestDocuments {
+        if _, err = env.AddDocument(doc, "trectext", nil); err != nil {
+            env.Close()
+            return
+        }
+    }
+    deleted, err := env.AddDocument("<docno>q4</docno><text>deleted pizza</text>", "trectext", nil)
+    if err == nil {
+        err = env.DeleteDocument(deleted)
+    }
+    if err != nil {
+        env.Close()
+        return
+    }
+    if err = env.Close(); err != nil {
+        return
+    }
+
+    if pool, err = indri_go.NewQueryPool(2, []string{repositoryPath}, nil); err != nil {
+        return
+    }
+    h = NewHandler(pool, Options{Timeout: 10 * time.Second, MaxLimit: 50})
+    return
+}
+
+// get serves a GET of target and decodes the JSON answered into v
+func get(h http.Handler, target string, v interface{}) (status int, err error) {
+    w := httptest.NewRecorder()
+    h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+    if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+        return w.Code, fmt.Errorf("%v answered Content-Type %q", target, ct)
+    }
+    if err = json.Unmarshal(w.Body.Bytes(), v); err != nil {
+        err = fmt.Errorf("%v answered %q: %v", target, w.Body.String(), err)
+    }
+    return w.Code, err
+}
+
+func testHandler() (err error) {
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    h, pool, err := newTestHandler(dir)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    var first SearchResponse
+    if _, err = get(h, "/search?q=pizza&limit=1", &first); err != nil {
+        return
+    }
+    if len(first.Results) != 1 || first.Next == "" || first.Results[0].Fields["docno"] == "" {
+        err = fmt.Errorf("first page %+v, expected a result with a docno and a next cursor", first)
+        return
+    }
+    var second SearchResponse
+    if _, err = get(h, "/search?cursor="+first.Next+"&fields=docno", &second); err != nil {
+        return
+    }
+    if len(second.Results) != 1 || !second.TotalExact || second.Total != 2 || second.Next != "" {
+        err = fmt.Errorf("second page %+v, expected the last of 2 results", second)
+        return
+    }
+    if first.Results[0].Fields["docno"] == second.Results[0].Fields["docno"] {
+        err = fmt.Errorf("both pages returned %v", first.Results[0].Fields["docno"])
+        return
+    }
+
+    var ids MetadataResponse
+    if _, err = get(h, "/metadata/docno/q2", &ids); err != nil {
+        return
+    }
+    if len(ids.Docids) != 1 {
+        err = fmt.Errorf("metadata lookup of q2 answered %+v", ids)
+        return
+    }
+
+    var gone MetadataResponse
+    if _, err = get(h, "/metadata/docno/q4", &gone); err != nil {
+        return
+    }
+    if gone.Docids == nil || len(gone.Docids) != 0 {
+        err = fmt.Errorf("metadata lookup of the deleted q4 answered %+v", gone)
+        return
+    }
+
+    var doc DocumentResponse
+    if _, err = get(h, fmt.Sprintf("/document/%v", ids.Docids[0]), &doc); err != nil {
+        return
+    }
+    if doc.Metadata["docno"] != "q2" || doc.Text == "" {
+        err = fmt.Errorf("document %v answered %+v", ids.Docids[0], doc)
+        return
+    }
+
+    var stats StatsResponse
+    if _, err = get(h, "/stats", &stats); err != nil {
+        return
+    }
+    if stats.Documents != 3 || stats.UniqueTerms == 0 {
+        err = fmt.Errorf("stats answered %+v", stats)
+        return
+    }
+    var again StatsResponse
+    if _, err = get(h, "/stats", &again); err != nil {
+        return
+    }
+    if !reflect.DeepEqual(again, stats) {
+        err = fmt.Errorf("stats answered %+v, then %+v", stats, again)
+        return
+    }
+    return
+}
+
+func testHandlerErrors() (err error) {
+    dir, err := ioutil.TempDir("", "test-repo-root")
+    if err != nil {
+        err = fmt.Errorf("failed to create TempDir: %v", err)
+        return
+    }
+    defer os.RemoveAll(dir) // clean up
+
+    h, pool, err := newTestHandler(dir)
+    if err != nil {
+        return
+    }
+    defer pool.Close()
+
+    cases := []struct {
+        target string
+        status int
+        code string
+    }{
+        {"/search", http.StatusBadRequest, CodeBadRequest},
+        {"/search?q=pizza&limit=51", http.StatusBadRequest, CodeBadRequest},
+        {"/search?q=pizza&offset=x", http.StatusBadRequest, CodeBadRequest},
+        {"/search?q=%23combine(pizza", http.StatusBadRequest, "LEMUR_PARSE_ERROR"},
+        {"/search?cursor=nonsense", http.StatusBadRequest, CodeInvalidCursor},
+        {"/search?cursor=nonsense&offset=1", http.StatusBadRequest, CodeBadRequest},
+        {"/search?cursor=nonsense&limit=5", http.StatusBadRequest, CodeBadRequest},
+        {"/document/x", http.StatusBadRequest, CodeBadRequest},
+        {"/document/4", http.StatusNotFound, CodeNotFound},
+        {"/document/99", http.StatusNotFound, CodeNotFound},
+        {"/metadata/docno", http.StatusBadRequest, CodeBadRequest},
+        {"/nowhere", http.StatusNotFound, CodeNotFound},
+    }
+    for _, c := range cases {
+        var body struct {
+            Error Error `json:"error"`
+        }
+        status, e := get(h, c.target, &body)
+        if e != nil {
+            return e
+        }
+        if status != c.status || body.Error.Status != c.status || body.Error.Code != c.code || body.Error.Message == "" {
+            return fmt.Errorf("%v answered %v %+v, expected %v %v", c.target, status, body.Error, c.status, c.code)
+        }
+    }
+
+    w := httptest.NewRecorder()
+    h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/search?q=pizza", nil))
+    if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodGet {
+        return fmt.Errorf("POST answered %v, expected %v", w.Code, http.StatusMethodNotAllowed)
+    }
+
+    pool.Close()
+    var body struct {
+        Error Error `json:"error"`
+    }
+    if status, e := get(h, "/stats", &body); e != nil || status != http.StatusServiceUnavailable {
+        return fmt.Errorf("closed pool answered %v %+v, %v", status, body.Error, e)
+    }
+    return
+}